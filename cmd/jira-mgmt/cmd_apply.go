@@ -0,0 +1,171 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/relux-works/skill-jira-management/internal/plan"
+	"github.com/spf13/cobra"
+)
+
+var applyDryRun bool
+
+var applyCmd = &cobra.Command{
+	Use:   "apply <PLAN-FILE>",
+	Short: "Create issues from a YAML or Markdown plan",
+	Long: `Create a tree of issues (epics, stories, subtasks) declared in a plan file.
+Parents are created before their children, in bulk on Jira Cloud. The keys of
+created issues are written back into the file, so applying it again only
+creates what is still missing. Links between items and DoD comments are added
+after the issues exist.
+
+YAML plan:
+  project: PROJ
+  issues:
+    - type: epic
+      summary: Auth system
+      labels: [auth]
+      children:
+        - type: story
+          id: login
+          summary: Login flow
+          assignee: alice@corp.com
+          links: [{type: blocks, to: PROJ-9}]
+          dod: Tests pass; reviewed
+          children:
+            - summary: Write handler
+
+Markdown plan (.md): headings are issues nested by depth and read
+"Type: Summary"; "- [ ] ..." checklist items are children; "Name: value" lines
+under a heading (ID, Assignee, Priority, Labels, Components, Link, DoD) set
+fields; the rest is the description.
+
+Examples:
+  jira-mgmt apply plan.yaml --dry-run
+  jira-mgmt apply plan.yaml
+  jira-mgmt apply roadmap.md --format text`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, err := plan.Load(args[0])
+		if err != nil {
+			return err
+		}
+
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+
+		applier, err := newPlanApplier(client, p)
+		if err != nil {
+			return err
+		}
+		applier.DryRun = applyDryRun
+
+		res, err := applier.Apply(p)
+		if err != nil {
+			return err
+		}
+		if err := writeReport(cmd.OutOrStdout(), res, func(w io.Writer) { printApplyResult(w, res) }); err != nil {
+			return err
+		}
+		if res.Failed > 0 {
+			return fmt.Errorf("%d plan item(s) failed", res.Failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Show what would be created without changing Jira or the file")
+
+	rootCmd.AddCommand(applyCmd)
+}
+
+// newPlanApplier wires an applier to the CLI's type names, user resolution,
+// field catalog and localized DoD comments.
+func newPlanApplier(client *jira.Client, p *plan.Plan) (*plan.Applier, error) {
+	locale := getConfigLocale()
+	a := &plan.Applier{
+		Client:   client,
+		Project:  flagProject,
+		TypeName: normalizeIssueType,
+		ResolveUser: func(who, project string) (*jira.UserRef, error) {
+			ref, _, err := resolveAssignee(client, who, project, "")
+			return ref, err
+		},
+		PostDoD: func(key, text string) error {
+			_, err := client.AddComment(key, jira.NewADFWithHeading(3, getLocaleString(locale, "dod_heading"), text))
+			return err
+		},
+	}
+
+	// Server/DC attaches issues to epics through the Epic Link field.
+	epicChildren, needsFields := false, false
+	for _, it := range p.Items() {
+		if len(it.Fields) > 0 {
+			needsFields = true
+		}
+		if it.Parent != nil && strings.EqualFold(normalizeIssueType(it.Parent.Type), "Epic") {
+			epicChildren = true
+		}
+	}
+	needsEpicLink := epicChildren && !client.IsCloud()
+	if !needsEpicLink && !needsFields {
+		return a, nil
+	}
+
+	catalog, err := loadFieldCatalog(client, false)
+	if err != nil {
+		return nil, fmt.Errorf("loading field catalog: %w", err)
+	}
+	if needsEpicLink {
+		for _, f := range catalog {
+			if strings.EqualFold(f.Name, "Epic Link") {
+				a.EpicLinkField = f.ID
+				break
+			}
+		}
+	}
+	a.FieldID = func(name string) (string, error) {
+		for _, f := range catalog {
+			if f.ID == name || strings.EqualFold(f.Name, name) {
+				return f.ID, nil
+			}
+		}
+		return "", fmt.Errorf("unknown field %q", name)
+	}
+	return a, nil
+}
+
+func printApplyResult(out io.Writer, res *plan.Result) {
+	verb := map[string]string{
+		plan.StatusCreated:  "created",
+		plan.StatusExisting: "exists ",
+		plan.StatusPlanned:  "create ",
+		plan.StatusFailed:   "FAILED ",
+	}
+	if res.DryRun {
+		fmt.Fprintf(out, "Dry run for project %s; nothing was changed.\n\n", res.Project)
+	}
+	for _, e := range res.Issues {
+		fmt.Fprintf(out, "%s %s%s %s: %s", verb[e.Status], strings.Repeat("  ", e.Depth), cmp.Or(e.Key, "(new)"), e.Type, e.Summary)
+		if e.Error != "" {
+			fmt.Fprintf(out, " (%s)", e.Error)
+		}
+		fmt.Fprintln(out)
+	}
+	if len(res.Links) > 0 {
+		fmt.Fprintln(out)
+		for _, l := range res.Links {
+			fmt.Fprintf(out, "%s %s %s %s", verb[l.Status], l.From, l.Type, l.To)
+			if l.Error != "" {
+				fmt.Fprintf(out, " (%s)", l.Error)
+			}
+			fmt.Fprintln(out)
+		}
+	}
+}
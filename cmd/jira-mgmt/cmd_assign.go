@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/relux-works/skill-jira-management/internal/config"
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/relux-works/skill-jira-management/internal/users"
+	"github.com/spf13/cobra"
+)
+
+var assignCmd = &cobra.Command{
+	Use:   "assign <ISSUE-KEY> <USER>",
+	Short: "Assign an issue",
+	Long: `Assign an issue to a user given by email, display name, Server/DC username
+or "me"; "none" unassigns it. Only users assignable to the issue are matched,
+and resolved users are cached per instance.
+
+Examples:
+  jira-mgmt assign PROJ-1 alice@corp.com
+  jira-mgmt assign PROJ-1 "Alice Smith"
+  jira-mgmt assign PROJ-1 me
+  jira-mgmt assign PROJ-1 none`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		issueKey, who := args[0], args[1]
+
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+
+		ref, user, err := resolveAssignee(client, who, "", issueKey)
+		if err != nil {
+			return err
+		}
+		if err := client.AssignIssue(issueKey, ref); err != nil {
+			return fmt.Errorf("assigning %s: %w", issueKey, err)
+		}
+
+		out := cmd.OutOrStdout()
+		if user == nil {
+			fmt.Fprintf(out, "Unassigned %s\n", issueKey)
+		} else {
+			fmt.Fprintf(out, "Assigned %s to %s\n", issueKey, user.DisplayName)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(assignCmd)
+}
+
+// newUserResolver returns a user resolver caching per instance.
+func newUserResolver(client *jira.Client) *users.Resolver {
+	cachePath, err := config.UserCachePath(client.BaseURL())
+	if err != nil {
+		cachePath = ""
+	}
+	return users.NewResolver(client, cachePath)
+}
+
+// resolveAssignee resolves who among the users assignable in projectKey or to
+// issueKey. "none" and "unassigned" return a nil ref.
+func resolveAssignee(client *jira.Client, who, projectKey, issueKey string) (*jira.UserRef, *jira.User, error) {
+	switch strings.ToLower(strings.TrimSpace(who)) {
+	case "none", "unassigned":
+		return nil, nil, nil
+	}
+	user, err := newUserResolver(client).Resolve(who, projectKey, issueKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving assignee: %w", err)
+	}
+	return user.Ref(), user, nil
+}
@@ -55,7 +55,11 @@ Compatibility:
   jira-mgmt auth --instance URL --email EMAIL --token TOKEN
 
 Cloud uses Basic auth (email + API token).
-Server/DC PAT uses Bearer auth (token without email).`,
+Server/DC PAT uses Bearer auth (token without email).
+
+Credential helper (no stored secret):
+  jira-mgmt config set credential_command "pass show jira"
+  jira-mgmt config set credential_cache_ttl 15m`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runAuthSetAccess(cmd, authCompatOptions)
 	},
@@ -85,6 +89,24 @@ var authResolveCmd = &cobra.Command{
 	},
 }
 
+var authMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Encrypt the plaintext auth.json into auth.enc.json",
+	Long: `Move every profile from the plaintext auth.json into the encrypted auth.enc.json
+(AES-256-GCM, key derived from a passphrase) and delete the plaintext file.
+
+The passphrase comes from JIRA_MGMT_AUTH_PASSPHRASE or an interactive prompt. Once
+auth.enc.json exists, the env_or_file backend reads and writes only the encrypted file.
+
+Examples:
+  jira-mgmt auth migrate
+  JIRA_MGMT_AUTH_PASSPHRASE=... jira-mgmt auth migrate`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAuthMigrate(cmd)
+	},
+}
+
 var authCleanCmd = &cobra.Command{
 	Use:     "clean",
 	Aliases: []string{"clear-access"},
@@ -118,12 +140,12 @@ func bindAuthSetAccessFlags(fs *pflag.FlagSet, target *authSetAccessFlags) {
 
 func bindAuthLookupFlags(fs *pflag.FlagSet, target *authLookupFlags) {
 	fs.StringVar(&target.Instance, "instance", "", "Jira instance URL (defaults to configured value or env override)")
-	fs.StringVar(&target.Source, "source", string(config.SourceAuto), "Credential source: auto, keychain, env_or_file")
+	fs.StringVar(&target.Source, "source", string(config.SourceAuto), "Credential source: auto, keychain, env_or_file, command")
 }
 
 func bindAuthWhoamiFlags(fs *pflag.FlagSet, target *authWhoamiFlags) {
 	fs.StringVar(&target.Instance, "instance", "", "Jira instance URL (defaults to configured value or env override)")
-	fs.StringVar(&target.Source, "source", string(config.SourceAuto), "Credential source: auto, keychain, env_or_file")
+	fs.StringVar(&target.Source, "source", string(config.SourceAuto), "Credential source: auto, keychain, env_or_file, command")
 	fs.BoolVar(&target.Check, "check", true, "Run a live auth probe against Jira")
 }
 
@@ -203,6 +225,26 @@ func runAuthResolve(cmd *cobra.Command, opts authLookupFlags) error {
 	}
 
 	printResolvedCredentials(out, resolved)
+	printFileStorage(out, resolver)
+	return nil
+}
+
+func runAuthMigrate(cmd *cobra.Command) error {
+	out := cmd.OutOrStdout()
+
+	resolver := getCredentialResolver()
+	result, err := resolver.MigrateToEncrypted()
+	if err != nil {
+		return fmt.Errorf("migrating auth file: %w", err)
+	}
+
+	fmt.Fprintln(out, "Encrypted auth file written.")
+	fmt.Fprintf(out, "  encrypted file: %s\n", result.EncryptedPath)
+	fmt.Fprintf(out, "  profiles migrated: %d\n", result.Migrated)
+	if result.Skipped > 0 {
+		fmt.Fprintf(out, "  profiles skipped (already encrypted): %d\n", result.Skipped)
+	}
+	fmt.Fprintf(out, "  removed plaintext: %s\n", result.PlaintextPath)
 	return nil
 }
 
@@ -315,13 +357,16 @@ func promptForCredentials(out io.Writer, instanceURL, email, apiToken string) (s
 }
 
 func validateCredentials(creds config.Credentials, insecure bool) (jira.InstanceType, error) {
-	client, err := jira.NewClient(jira.Config{
-		BaseURL:            creds.InstanceURL,
-		Email:              creds.Email,
-		Token:              creds.APIToken,
-		AuthType:           jira.AuthType(creds.AuthType),
-		InsecureSkipVerify: insecure,
-	})
+	clientCfg := jira.Config{
+		BaseURL:  creds.InstanceURL,
+		Email:    creds.Email,
+		Token:    creds.APIToken,
+		AuthType: jira.AuthType(creds.AuthType),
+		CloudID:  creds.CloudID,
+	}
+	applyTransportSettings(&clientCfg, loadConfigOrDefault())
+	clientCfg.InsecureSkipVerify = clientCfg.InsecureSkipVerify || insecure
+	client, err := jira.NewClient(clientCfg)
 	if err != nil {
 		return "", fmt.Errorf("creating client: %w", err)
 	}
@@ -329,6 +374,9 @@ func validateCredentials(creds config.Credentials, insecure bool) (jira.Instance
 	if _, err := client.Get("/rest/api/2/myself", nil); err != nil {
 		return "", fmt.Errorf("authentication failed: %w", err)
 	}
+	if creds.IsOAuth() {
+		return jira.InstanceCloud, nil
+	}
 
 	instanceType, err := client.DetectInstanceType()
 	if err != nil {
@@ -338,6 +386,9 @@ func validateCredentials(creds config.Credentials, insecure bool) (jira.Instance
 }
 
 func inferInstanceType(creds config.Credentials) jira.InstanceType {
+	if creds.IsOAuth() {
+		return jira.InstanceCloud
+	}
 	if creds.AuthType == "bearer" {
 		return jira.InstanceServer
 	}
@@ -368,6 +419,10 @@ func printResolvedCredentials(out io.Writer, resolved config.ResolvedCredentials
 	fmt.Fprintf(out, "  instance: %s\n", valueOrNone(resolved.Credentials.InstanceURL))
 	fmt.Fprintf(out, "  email: %s\n", valueOrNone(resolved.Credentials.Email))
 	fmt.Fprintf(out, "  auth type: %s\n", valueOrNone(resolved.Credentials.AuthType))
+	if resolved.Credentials.IsOAuth() {
+		fmt.Fprintf(out, "  cloud id: %s\n", valueOrNone(resolved.Credentials.CloudID))
+		fmt.Fprintf(out, "  token expires: %s\n", valueOrNone(resolved.Credentials.ExpiresAt))
+	}
 	fmt.Fprintf(out, "  source: %s\n", resolved.Source)
 	fmt.Fprintf(out, "  resolved from: %s\n", resolved.ResolvedFrom)
 	if resolved.ConfigPath != "" {
@@ -384,6 +439,26 @@ func printResolvedCredentials(out io.Writer, resolved config.ResolvedCredentials
 	}
 }
 
+// printFileStorage reports whether the env_or_file backend keeps secrets encrypted.
+func printFileStorage(out io.Writer, resolver *config.Resolver) {
+	status, err := resolver.FileStorageStatus()
+	if err != nil {
+		return
+	}
+
+	switch {
+	case status.EncryptedExists:
+		fmt.Fprintf(out, "  file storage: encrypted (%s)\n", status.EncryptedPath)
+	case status.PlaintextExists:
+		fmt.Fprintf(out, "  file storage: plaintext (%s)\n", status.PlaintextPath)
+	default:
+		return
+	}
+	if status.PlaintextExists {
+		fmt.Fprintln(out, "  warning: plaintext auth.json holds API tokens; run 'jira-mgmt auth migrate' to encrypt it")
+	}
+}
+
 func joinSources(sources []config.Source) string {
 	if len(sources) == 0 {
 		return ""
@@ -406,6 +481,7 @@ func init() {
 	authCmd.AddCommand(authWhoamiCmd)
 	authCmd.AddCommand(authResolveCmd)
 	authCmd.AddCommand(authCleanCmd)
+	authCmd.AddCommand(authMigrateCmd)
 	authCmd.AddCommand(authConfigPathCmd)
 	rootCmd.AddCommand(authCmd)
 }
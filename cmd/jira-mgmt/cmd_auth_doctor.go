@@ -0,0 +1,391 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/relux-works/skill-jira-management/internal/config"
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+// doctorPermissions are checked by auth doctor, in report order.
+var doctorPermissions = []string{
+	jira.PermBrowseProjects,
+	jira.PermCreateIssues,
+	jira.PermEditIssues,
+	jira.PermTransitionIssues,
+	jira.PermAddComments,
+	jira.PermDeleteIssues,
+	jira.PermAssignIssues,
+	jira.PermLinkIssues,
+	jira.PermScheduleIssues,
+	jira.PermManageSprints,
+	jira.PermManageWatchers,
+	jira.PermAdministerProject,
+	jira.PermUserPicker,
+	jira.PermAdminister,
+}
+
+// doctorSoftwarePermissions are the doctorPermissions only Jira Software
+// defines. /mypermissions rejects the whole request for an unknown key, so they
+// are checked on their own and the core report survives without Jira Software.
+var doctorSoftwarePermissions = []string{
+	jira.PermManageSprints,
+}
+
+// doctorCommandNeeds maps each write/read command to the permissions it requires.
+var doctorCommandNeeds = []struct {
+	Command  string
+	Requires []string
+}{
+	{"q / grep", []string{jira.PermBrowseProjects}},
+	{"create", []string{jira.PermBrowseProjects, jira.PermCreateIssues}},
+	{"update", []string{jira.PermBrowseProjects, jira.PermEditIssues}},
+	{"transition", []string{jira.PermBrowseProjects, jira.PermTransitionIssues}},
+	{"cancel", []string{jira.PermBrowseProjects, jira.PermTransitionIssues}},
+	{"cancel --reason", []string{jira.PermBrowseProjects, jira.PermTransitionIssues, jira.PermAddComments}},
+	{"comment / dod", []string{jira.PermBrowseProjects, jira.PermAddComments}},
+	{"assign", []string{jira.PermBrowseProjects, jira.PermAssignIssues}},
+	{"watch / unwatch", []string{jira.PermBrowseProjects}},
+	{"watch --user", []string{jira.PermBrowseProjects, jira.PermManageWatchers}},
+	{"sprint create/start/update/close", []string{jira.PermBrowseProjects, jira.PermManageSprints}},
+	{"sprint move", []string{jira.PermBrowseProjects, jira.PermEditIssues, jira.PermScheduleIssues}},
+	{"sprint rank", []string{jira.PermBrowseProjects, jira.PermScheduleIssues}},
+	{"versions create/update/release", []string{jira.PermBrowseProjects, jira.PermAdministerProject}},
+	{"components create/update", []string{jira.PermBrowseProjects, jira.PermAdministerProject}},
+	{"apply", []string{jira.PermBrowseProjects, jira.PermCreateIssues, jira.PermLinkIssues, jira.PermAddComments}},
+	{"plan apply", []string{jira.PermBrowseProjects, jira.PermCreateIssues, jira.PermEditIssues, jira.PermTransitionIssues, jira.PermLinkIssues}},
+}
+
+type doctorReport struct {
+	Instance     string                  `json:"instance"`
+	AuthType     string                  `json:"auth_type"`
+	Source       string                  `json:"source"`
+	ResolvedFrom string                  `json:"resolved_from"`
+	InstanceType jira.InstanceType       `json:"instance_type,omitempty"`
+	Server       *jira.ServerInfo        `json:"server,omitempty"`
+	User         *jira.User              `json:"user,omitempty"`
+	TokenExpiry  []doctorTokenExpiry     `json:"token_expiry,omitempty"`
+	Scopes       *doctorScopes           `json:"scopes,omitempty"`
+	Project      string                  `json:"project,omitempty"`
+	Permissions  []doctorPermissionState `json:"permissions,omitempty"`
+	Commands     []doctorCommandState    `json:"commands,omitempty"`
+	Warnings     []string                `json:"warnings,omitempty"`
+}
+
+type doctorTokenExpiry struct {
+	Name      string `json:"name"`
+	ExpiresAt string `json:"expires_at"`
+	Expired   bool   `json:"expired,omitempty"`
+	Note      string `json:"note,omitempty"`
+}
+
+// doctorScopes are the scopes granted to an OAuth token and those jira-mgmt
+// needs that are missing.
+type doctorScopes struct {
+	Granted []string `json:"granted"`
+	Missing []string `json:"missing,omitempty"`
+}
+
+type doctorPermissionState struct {
+	Key     string `json:"key"`
+	Type    string `json:"type,omitempty"`
+	Granted bool   `json:"granted"`
+}
+
+type doctorCommandState struct {
+	Command  string   `json:"command"`
+	WillWork bool     `json:"will_work"`
+	Missing  []string `json:"missing,omitempty"`
+}
+
+var authDoctorOptions authLookupFlags
+
+var authDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Report user, instance, token expiry and permissions for the active project",
+	Long: `Check the resolved credentials in depth before a long agent run:
+
+  - current user and instance type/version (serverInfo)
+  - token expiry: OAuth access token expiry, or Server/DC personal access tokens
+  - OAuth scopes missing from the token (e.g. the Jira Software scopes boards and sprints need)
+  - global and project permissions from /mypermissions for the active project
+  - which jira-mgmt commands will work or fail with those permissions
+
+Examples:
+  jira-mgmt auth doctor
+  jira-mgmt auth doctor --project PROJ --format text`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAuthDoctor(cmd, authDoctorOptions)
+	},
+}
+
+func runAuthDoctor(cmd *cobra.Command, opts authLookupFlags) error {
+	out := cmd.OutOrStdout()
+
+	cfgMgr, err := config.NewConfigManager()
+	if err != nil {
+		return fmt.Errorf("config manager: %w", err)
+	}
+	cfg, err := cfgMgr.GetConfig()
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+
+	resolver := getCredentialResolver()
+	instanceURL, _ := configuredInstanceURL(resolver, opts.Instance)
+	resolved, err := resolver.Resolve(config.Source(opts.Source), instanceURL)
+	if err != nil {
+		return fmt.Errorf("resolving credentials: %w", err)
+	}
+
+	client, err := buildJiraClient(cfgMgr, cfg, resolver, resolved)
+	if err != nil {
+		return err
+	}
+
+	report := doctorReport{
+		Instance:     resolved.Credentials.InstanceURL,
+		AuthType:     resolved.Credentials.AuthType,
+		Source:       string(resolved.Source),
+		ResolvedFrom: resolved.ResolvedFrom,
+		Project:      flagProject,
+	}
+
+	user, err := client.GetMyself()
+	if err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+	report.User = user
+
+	if info, err := client.GetServerInfo(); err == nil {
+		report.Server = info
+	} else {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("serverInfo unavailable: %v", err))
+	}
+	report.InstanceType = doctorInstanceType(client, report.Server, resolved.Credentials)
+
+	report.TokenExpiry, report.Warnings = doctorTokenExpiries(client, resolved.Credentials, report.InstanceType, report.Warnings, time.Now())
+
+	if resolved.Credentials.IsOAuth() {
+		if granted, err := client.OAuthScopes(); err == nil {
+			report.Scopes = &doctorScopes{Granted: granted, Missing: jira.MissingOAuthScopes(granted)}
+			if len(report.Scopes.Missing) > 0 {
+				report.Warnings = append(report.Warnings, "OAuth token lacks scopes jira-mgmt needs: add them to the app and run 'jira-mgmt auth oauth' again")
+			}
+		} else {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("OAuth scopes unavailable: %v", err))
+		}
+	}
+
+	perms, warnings, err := doctorLookupPermissions(client, flagProject)
+	report.Warnings = append(report.Warnings, warnings...)
+	if err != nil {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("permissions unavailable: %v", err))
+	} else {
+		report.Permissions, report.Commands = doctorEvaluate(perms)
+		if flagProject == "" {
+			report.Warnings = append(report.Warnings, "no active project: project permissions reflect any project (use --project)")
+		}
+	}
+
+	if flagFormat == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printDoctorReport(out, report)
+	return nil
+}
+
+func doctorInstanceType(client *jira.Client, info *jira.ServerInfo, creds config.Credentials) jira.InstanceType {
+	if info != nil && info.DeploymentType != "" {
+		if info.DeploymentType == "Cloud" {
+			return jira.InstanceCloud
+		}
+		return jira.InstanceServer
+	}
+	if inferred := inferInstanceType(creds); inferred != "" {
+		return inferred
+	}
+	return client.GetInstanceType()
+}
+
+func doctorTokenExpiries(client *jira.Client, creds config.Credentials, instanceType jira.InstanceType, warnings []string, now time.Time) ([]doctorTokenExpiry, []string) {
+	if creds.IsOAuth() {
+		if creds.ExpiresAt == "" {
+			return nil, warnings
+		}
+		expiry := doctorTokenExpiry{Name: "oauth access token", ExpiresAt: creds.ExpiresAt}
+		if t, err := time.Parse(time.RFC3339, creds.ExpiresAt); err == nil && now.After(t) {
+			expiry.Expired = true
+			expiry.Note = "refreshed automatically on next request"
+		}
+		return []doctorTokenExpiry{expiry}, warnings
+	}
+
+	if instanceType == jira.InstanceCloud {
+		return nil, append(warnings, "Cloud API token expiry is not exposed by the REST API; check https://id.atlassian.com/manage-profile/security/api-tokens")
+	}
+
+	tokens, err := client.ListPersonalAccessTokens()
+	if err != nil {
+		return nil, append(warnings, fmt.Sprintf("personal access tokens unavailable: %v", err))
+	}
+
+	var expiries []doctorTokenExpiry
+	for _, token := range tokens {
+		entry := doctorTokenExpiry{Name: token.Name, ExpiresAt: valueOrNone(token.ExpiringAt)}
+		if t, ok := parseJiraTime(token.ExpiringAt); ok && now.After(t) {
+			entry.Expired = true
+		}
+		expiries = append(expiries, entry)
+	}
+	sort.Slice(expiries, func(i, j int) bool { return expiries[i].ExpiresAt < expiries[j].ExpiresAt })
+	if len(expiries) > 1 {
+		warnings = append(warnings, "the PAT API does not identify which token is in use; all of your tokens are listed")
+	}
+	return expiries, warnings
+}
+
+// doctorLookupPermissions reads doctorPermissions from /mypermissions, the Jira
+// Software ones separately: when Jira rejects those, the rest is still reported
+// and the failure becomes a warning.
+func doctorLookupPermissions(client *jira.Client, project string) (map[string]jira.Permission, []string, error) {
+	var core []string
+	for _, key := range doctorPermissions {
+		if !slices.Contains(doctorSoftwarePermissions, key) {
+			core = append(core, key)
+		}
+	}
+	perms, err := client.GetMyPermissions(project, core)
+	if err != nil {
+		return nil, nil, err
+	}
+	var warnings []string
+	if software, err := client.GetMyPermissions(project, doctorSoftwarePermissions); err == nil {
+		maps.Copy(perms, software)
+	} else {
+		warnings = append(warnings, fmt.Sprintf("Jira Software permissions unavailable (is Jira Software installed?): %v", err))
+	}
+	return perms, warnings, nil
+}
+
+// doctorEvaluate turns /mypermissions output into per-permission and per-command verdicts.
+func doctorEvaluate(perms map[string]jira.Permission) ([]doctorPermissionState, []doctorCommandState) {
+	permissions := make([]doctorPermissionState, 0, len(doctorPermissions))
+	for _, key := range doctorPermissions {
+		p := perms[key]
+		permissions = append(permissions, doctorPermissionState{Key: key, Type: p.Type, Granted: p.HavePermission})
+	}
+
+	commands := make([]doctorCommandState, 0, len(doctorCommandNeeds))
+	for _, need := range doctorCommandNeeds {
+		state := doctorCommandState{Command: need.Command, WillWork: true}
+		for _, key := range need.Requires {
+			if !perms[key].HavePermission {
+				state.WillWork = false
+				state.Missing = append(state.Missing, key)
+			}
+		}
+		commands = append(commands, state)
+	}
+	return permissions, commands
+}
+
+func printDoctorReport(out io.Writer, report doctorReport) {
+	fmt.Fprintln(out, "Credential Health")
+	fmt.Fprintln(out, "=================")
+	fmt.Fprintf(out, "  instance: %s\n", report.Instance)
+	fmt.Fprintf(out, "  auth type: %s (%s, from %s)\n", report.AuthType, report.Source, report.ResolvedFrom)
+	if report.User != nil {
+		fmt.Fprintf(out, "  user: %s\n", doctorUserLabel(report.User))
+	}
+	fmt.Fprintf(out, "  instance type: %s\n", valueOrNone(string(report.InstanceType)))
+	if report.Server != nil {
+		fmt.Fprintf(out, "  deployment: %s %s (build %d)\n", report.Server.DeploymentType, report.Server.Version, report.Server.BuildNumber)
+	}
+	for _, expiry := range report.TokenExpiry {
+		line := fmt.Sprintf("  token expiry: %s — %s", expiry.Name, expiry.ExpiresAt)
+		if expiry.Expired {
+			line += " (expired)"
+		}
+		if expiry.Note != "" {
+			line += " — " + expiry.Note
+		}
+		fmt.Fprintln(out, line)
+	}
+	if report.Scopes != nil {
+		if len(report.Scopes.Missing) == 0 {
+			fmt.Fprintln(out, "  oauth scopes: all granted")
+		} else {
+			fmt.Fprintf(out, "  oauth scopes: missing %s\n", strings.Join(report.Scopes.Missing, ", "))
+		}
+	}
+
+	if len(report.Permissions) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "Permissions (project %s)\n", valueOrNone(report.Project))
+		for _, p := range report.Permissions {
+			mark := "no"
+			if p.Granted {
+				mark = "yes"
+			}
+			fmt.Fprintf(out, "  %-25s %-4s %s\n", p.Key, mark, strings.ToLower(p.Type))
+		}
+
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Commands")
+		for _, c := range report.Commands {
+			if c.WillWork {
+				fmt.Fprintf(out, "  %-32s will work\n", c.Command)
+			} else {
+				fmt.Fprintf(out, "  %-32s will fail (missing %s)\n", c.Command, strings.Join(c.Missing, ", "))
+			}
+		}
+	}
+
+	if len(report.Warnings) > 0 {
+		fmt.Fprintln(out)
+		for _, w := range report.Warnings {
+			fmt.Fprintf(out, "warning: %s\n", w)
+		}
+	}
+}
+
+func doctorUserLabel(user *jira.User) string {
+	label := user.DisplayName
+	var ids []string
+	for _, id := range []string{user.EmailAddress, user.Name, user.AccountID} {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > 0 {
+		label += " (" + strings.Join(ids, ", ") + ")"
+	}
+	return label
+}
+
+// parseJiraTime parses the timestamp formats Jira REST APIs return.
+func parseJiraTime(value string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02T15:04:05.000-0700", time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func init() {
+	bindAuthLookupFlags(authDoctorCmd.Flags(), &authDoctorOptions)
+	authCmd.AddCommand(authDoctorCmd)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+func TestDoctorEvaluate_MapsMissingPermissionsToCommands(t *testing.T) {
+	perms := map[string]jira.Permission{
+		jira.PermBrowseProjects:   {Key: jira.PermBrowseProjects, Type: "PROJECT", HavePermission: true},
+		jira.PermCreateIssues:     {Key: jira.PermCreateIssues, Type: "PROJECT", HavePermission: true},
+		jira.PermTransitionIssues: {Key: jira.PermTransitionIssues, Type: "PROJECT", HavePermission: false},
+		jira.PermAddComments:      {Key: jira.PermAddComments, Type: "PROJECT", HavePermission: true},
+	}
+
+	permissions, commands := doctorEvaluate(perms)
+	if len(permissions) != len(doctorPermissions) {
+		t.Fatalf("got %d permissions, want %d", len(permissions), len(doctorPermissions))
+	}
+
+	byCommand := map[string]doctorCommandState{}
+	for _, c := range commands {
+		byCommand[c.Command] = c
+	}
+	if !byCommand["create"].WillWork {
+		t.Error("create should work")
+	}
+	if !byCommand["comment / dod"].WillWork {
+		t.Error("comment should work")
+	}
+	transition := byCommand["transition"]
+	if transition.WillWork || len(transition.Missing) != 1 || transition.Missing[0] != jira.PermTransitionIssues {
+		t.Errorf("transition = %+v, want fail on TRANSITION_ISSUES", transition)
+	}
+	if byCommand["update"].WillWork {
+		t.Error("update should fail without EDIT_ISSUES")
+	}
+	if !byCommand["watch / unwatch"].WillWork {
+		t.Error("watching yourself should work with BROWSE_PROJECTS")
+	}
+	if sprints := byCommand["sprint create/start/update/close"]; sprints.WillWork || sprints.Missing[0] != jira.PermManageSprints {
+		t.Errorf("sprint = %+v, want fail on MANAGE_SPRINTS_PERMISSION", sprints)
+	}
+	for _, name := range []string{"assign", "watch --user", "sprint move", "sprint rank", "versions create/update/release", "components create/update", "apply", "plan apply"} {
+		if c, ok := byCommand[name]; !ok || c.WillWork {
+			t.Errorf("%s = %+v, %v; want a failing verdict", name, c, ok)
+		}
+	}
+}
+
+func TestDoctorLookupPermissions_WithoutJiraSoftware(t *testing.T) {
+	var requested []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys := r.URL.Query().Get("permissions")
+		requested = append(requested, keys)
+		// Jira without Jira Software rejects the whole request for an unknown key.
+		if strings.Contains(keys, jira.PermManageSprints) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorMessages":["Unrecognized permission: MANAGE_SPRINTS_PERMISSION"]}`))
+			return
+		}
+		w.Write([]byte(`{"permissions":{"BROWSE_PROJECTS":{"key":"BROWSE_PROJECTS","type":"PROJECT","havePermission":true}}}`))
+	}))
+	defer srv.Close()
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: jira.InstanceCloud})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	perms, warnings, err := doctorLookupPermissions(client, "PROJ")
+	if err != nil {
+		t.Fatalf("doctorLookupPermissions: %v", err)
+	}
+	if !perms[jira.PermBrowseProjects].HavePermission {
+		t.Errorf("perms = %v, want the core permissions", perms)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "Jira Software") {
+		t.Errorf("warnings = %v", warnings)
+	}
+	if len(requested) != 2 || strings.Contains(requested[0], jira.PermManageSprints) {
+		t.Errorf("requests = %v, want core keys first and Jira Software keys apart", requested)
+	}
+}
+
+func TestParseJiraTime(t *testing.T) {
+	for _, value := range []string{"2026-07-01T10:00:00.000+0000", "2026-07-01T10:00:00Z", "2026-07-01"} {
+		if _, ok := parseJiraTime(value); !ok {
+			t.Errorf("parseJiraTime(%q) failed", value)
+		}
+	}
+	if _, ok := parseJiraTime("soon"); ok {
+		t.Error("parseJiraTime should reject garbage")
+	}
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/relux-works/skill-jira-management/internal/config"
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+type authOAuthFlags struct {
+	Instance     string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	Source       string
+	Timeout      time.Duration
+}
+
+var authOAuthOptions authOAuthFlags
+
+var authOAuthCmd = &cobra.Command{
+	Use:   "oauth",
+	Short: "Sign in to Jira Cloud with OAuth 2.0 (3LO) and store the tokens",
+	Long: `Sign in to Jira Cloud with an OAuth 2.0 (3LO) app using the authorization-code flow with PKCE.
+
+A local callback listener is started on the redirect URL; open the printed URL in a
+browser and approve access. The access and refresh tokens are stored in the selected
+credential backend and refreshed automatically when they expire.
+
+The app must be registered at https://developer.atlassian.com/console/myapps/ with the
+same callback URL as --redirect-url.
+
+Examples:
+  jira-mgmt auth oauth --client-id ID --client-secret SECRET
+  jira-mgmt auth oauth --client-id ID --client-secret SECRET --instance https://mycompany.atlassian.net
+  jira-mgmt auth oauth --client-id ID --redirect-url http://localhost:9000/callback`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAuthOAuth(cmd, authOAuthOptions)
+	},
+}
+
+func runAuthOAuth(cmd *cobra.Command, opts authOAuthFlags) error {
+	out := cmd.OutOrStdout()
+
+	if strings.TrimSpace(opts.ClientID) == "" {
+		return fmt.Errorf("--client-id is required")
+	}
+
+	redirect, err := url.Parse(opts.RedirectURL)
+	if err != nil || redirect.Host == "" {
+		return fmt.Errorf("invalid --redirect-url %q", opts.RedirectURL)
+	}
+
+	var transportCfg jira.Config
+	applyTransportSettings(&transportCfg, loadConfigOrDefault())
+	httpClient, err := jira.NewHTTPClient(transportCfg)
+	if err != nil {
+		return err
+	}
+
+	oauth := jira.OAuthConfig{
+		ClientID:     strings.TrimSpace(opts.ClientID),
+		ClientSecret: strings.TrimSpace(opts.ClientSecret),
+		RedirectURL:  opts.RedirectURL,
+		Scopes:       opts.Scopes,
+		HTTPClient:   httpClient,
+	}
+
+	verifier, challenge, err := jira.NewPKCE()
+	if err != nil {
+		return err
+	}
+	state, err := randomState()
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", redirect.Host)
+	if err != nil {
+		return fmt.Errorf("starting callback listener on %s: %w", redirect.Host, err)
+	}
+
+	fmt.Fprintln(out, "Open this URL in a browser and approve access:")
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "  %s\n", oauth.AuthCodeURL(state, challenge))
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Waiting for the callback on %s ...\n", opts.RedirectURL)
+
+	code, err := waitForOAuthCallback(listener, redirect.Path, state, opts.Timeout)
+	if err != nil {
+		return err
+	}
+
+	token, err := oauth.Exchange(code, verifier)
+	if err != nil {
+		return err
+	}
+
+	resources, err := oauth.AccessibleResources(token.AccessToken)
+	if err != nil {
+		return err
+	}
+	site, err := selectOAuthSite(resources, opts.Instance)
+	if err != nil {
+		return err
+	}
+
+	creds := config.Credentials{
+		InstanceURL:  site.URL,
+		APIToken:     token.AccessToken,
+		AuthType:     string(jira.AuthOAuth),
+		RefreshToken: token.RefreshToken,
+		CloudID:      site.ID,
+		ClientID:     oauth.ClientID,
+		ClientSecret: oauth.ClientSecret,
+	}
+	if expiry := token.Expiry(time.Now()); !expiry.IsZero() {
+		creds.ExpiresAt = expiry.UTC().Format(time.RFC3339)
+	}
+	if creds.RefreshToken == "" {
+		fmt.Fprintln(out, "warning: no refresh token returned (is the offline_access scope enabled?)")
+	}
+
+	resolver := getCredentialResolver()
+	result, err := resolver.SetAccess(config.Source(opts.Source), creds)
+	if err != nil {
+		return fmt.Errorf("saving credentials: %w", err)
+	}
+
+	cfgMgr, err := config.NewConfigManager()
+	if err != nil {
+		return fmt.Errorf("config manager: %w", err)
+	}
+	_ = cfgMgr.SetInstanceURL(result.Credentials.InstanceURL)
+	_ = cfgMgr.SetAuthType(result.Credentials.AuthType)
+	_ = cfgMgr.SetInstanceType(string(jira.InstanceCloud))
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "OAuth tokens stored.")
+	fmt.Fprintf(out, "  instance: %s\n", result.Credentials.InstanceURL)
+	fmt.Fprintf(out, "  cloud id: %s\n", result.Credentials.CloudID)
+	fmt.Fprintf(out, "  auth type: %s\n", result.Credentials.AuthType)
+	fmt.Fprintf(out, "  source: %s\n", result.Source)
+	if token.Scope != "" {
+		fmt.Fprintf(out, "  scopes: %s\n", token.Scope)
+	}
+	return nil
+}
+
+// waitForOAuthCallback serves a single request on the redirect path and returns the authorization code.
+func waitForOAuthCallback(listener net.Listener, path, state string, timeout time.Duration) (string, error) {
+	if path == "" {
+		path = "/"
+	}
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var res result
+		switch {
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("authorization denied: %s %s", q.Get("error"), q.Get("error_description"))
+		case q.Get("state") != state:
+			res.err = errors.New("authorization callback state mismatch")
+		case q.Get("code") == "":
+			res.err = errors.New("authorization callback did not include a code")
+		default:
+			res.code = q.Get("code")
+		}
+
+		if res.err != nil {
+			http.Error(w, res.err.Error(), http.StatusBadRequest)
+		} else {
+			io.WriteString(w, "jira-mgmt: authorization complete. You can close this window.\n")
+		}
+		select {
+		case results <- res:
+		default:
+		}
+	})
+
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = srv.Serve(listener) }()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}()
+
+	select {
+	case res := <-results:
+		return res.code, res.err
+	case <-time.After(timeout):
+		return "", fmt.Errorf("timed out after %s waiting for the OAuth callback", timeout)
+	}
+}
+
+// selectOAuthSite picks the site matching instanceURL, or the only accessible site.
+func selectOAuthSite(resources []jira.AccessibleResource, instanceURL string) (jira.AccessibleResource, error) {
+	if len(resources) == 0 {
+		return jira.AccessibleResource{}, errors.New("the OAuth token cannot access any Jira site")
+	}
+
+	want := strings.TrimSuffix(strings.TrimSpace(instanceURL), "/")
+	if want == "" {
+		if len(resources) == 1 {
+			return resources[0], nil
+		}
+	} else {
+		for _, r := range resources {
+			if strings.EqualFold(strings.TrimSuffix(r.URL, "/"), want) {
+				return r, nil
+			}
+		}
+	}
+
+	var available []string
+	for _, r := range resources {
+		available = append(available, r.URL)
+	}
+	if want == "" {
+		return jira.AccessibleResource{}, fmt.Errorf("the token can access several sites; pick one with --instance:\n  %s", strings.Join(available, "\n  "))
+	}
+	return jira.AccessibleResource{}, fmt.Errorf("site %s is not accessible with this token; available:\n  %s", want, strings.Join(available, "\n  "))
+}
+
+func randomState() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating OAuth state: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func init() {
+	fs := authOAuthCmd.Flags()
+	fs.StringVar(&authOAuthOptions.Instance, "instance", "", "Jira site URL to use when the token can access several sites")
+	fs.StringVar(&authOAuthOptions.ClientID, "client-id", "", "OAuth app client ID (required)")
+	fs.StringVar(&authOAuthOptions.ClientSecret, "client-secret", "", "OAuth app client secret")
+	fs.StringVar(&authOAuthOptions.RedirectURL, "redirect-url", "http://localhost:8765/callback", "Callback URL registered for the OAuth app")
+	fs.StringSliceVar(&authOAuthOptions.Scopes, "scope", jira.DefaultOAuthScopes, "OAuth scopes (repeatable)")
+	fs.StringVar(&authOAuthOptions.Source, "source", string(config.SourceAuto), "Credential source: auto, keychain, env_or_file")
+	fs.DurationVar(&authOAuthOptions.Timeout, "wait", 5*time.Minute, "How long to wait for the browser callback")
+
+	authCmd.AddCommand(authOAuthCmd)
+}
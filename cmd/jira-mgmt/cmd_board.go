@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/relux-works/skill-jira-management/internal/query"
+	"github.com/spf13/cobra"
+)
+
+var boardShowIssues bool
+
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Inspect agile boards",
+}
+
+var boardShowCmd = &cobra.Command{
+	Use:   "show [BOARD-ID]",
+	Short: "Show a board as columns with issue counts and WIP limits",
+	Long: `Show a board's columns, the statuses mapped to them, their issue counts and
+WIP limit violations, plus the board filter and estimation field. Scrum boards
+count the issues of their active sprints; kanban boards apply the board's sub-filter.
+
+Examples:
+  jira-mgmt board show
+  jira-mgmt board show 9 --format text
+  jira-mgmt board show 9 --issues --format text`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		boardID := flagBoard
+		if len(args) == 1 {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid board ID: %s", args[0])
+			}
+			boardID = id
+		}
+		if boardID == 0 {
+			return fmt.Errorf("no board configured: pass a board ID or use --board")
+		}
+
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+
+		view, err := loadBoardView(client, boardID, boardShowIssues)
+		if err != nil {
+			return err
+		}
+		return writeReport(cmd.OutOrStdout(), view, func(out io.Writer) { printBoardView(out, view) })
+	},
+}
+
+func init() {
+	boardShowCmd.Flags().BoolVar(&boardShowIssues, "issues", false, "List the issues in each column")
+
+	boardCmd.AddCommand(boardShowCmd)
+	rootCmd.AddCommand(boardCmd)
+}
+
+type boardView struct {
+	ID          int               `json:"id"`
+	Name        string            `json:"name"`
+	Type        string            `json:"type"`
+	FilterID    string            `json:"filter_id"`
+	FilterJQL   string            `json:"filter_jql,omitempty"`
+	Estimation  string            `json:"estimation,omitempty"`
+	Constraint  string            `json:"wip_constraint,omitempty"`
+	Approximate bool              `json:"approximate,omitempty"`
+	Columns     []boardColumnView `json:"columns"`
+}
+
+type boardColumnView struct {
+	query.ColumnCount
+	Statuses  []string         `json:"statuses"`
+	IssueList []boardIssueView `json:"issue_list,omitempty"`
+}
+
+type boardIssueView struct {
+	Key      string `json:"key"`
+	Summary  string `json:"summary"`
+	Assignee string `json:"assignee,omitempty"`
+}
+
+func loadBoardView(client *jira.Client, boardID int, withIssues bool) (*boardView, error) {
+	board, err := client.GetBoard(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("getting board: %w", err)
+	}
+	cfg, err := client.GetBoardConfiguration(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("getting board configuration: %w", err)
+	}
+
+	view := &boardView{ID: board.ID, Name: board.Name, Type: board.Type, FilterID: cfg.Filter.ID}
+	if cfg.ColumnConfig.ConstraintType != "none" {
+		view.Constraint = cfg.ColumnConfig.ConstraintType
+	}
+	if est := cfg.Estimation; est != nil {
+		view.Estimation = est.Type
+		if est.Field != nil {
+			view.Estimation = est.Field.DisplayName
+		}
+	}
+	// The filter may be private to its owner; the board still works without its JQL.
+	if filter, err := client.GetFilter(cfg.Filter.ID); err == nil {
+		view.FilterJQL = filter.JQL
+	}
+
+	statusNames := map[string]string{}
+	if statuses, err := client.ListStatuses(); err == nil {
+		for _, s := range statuses {
+			statusNames[s.ID] = s.Name
+		}
+	}
+
+	scope, err := query.BoardScopeJQL(client, cfg)
+	if err != nil {
+		return nil, err
+	}
+	counts, approximate, err := query.BoardColumnCounts(client, cfg, scope)
+	if err != nil {
+		return nil, err
+	}
+	view.Approximate = approximate
+
+	for i, column := range cfg.ColumnConfig.Columns {
+		col := boardColumnView{ColumnCount: counts[i], Statuses: []string{}}
+		for _, s := range column.Statuses {
+			name := statusNames[s.ID]
+			if name == "" {
+				name = s.ID
+			}
+			col.Statuses = append(col.Statuses, name)
+		}
+		if jql := query.ColumnJQL(scope, cfg, column); withIssues && jql != "" {
+			issues, err := client.SearchAll(jql+" ORDER BY Rank ASC", []string{"summary", "assignee"})
+			if err != nil {
+				return nil, fmt.Errorf("listing column %q: %w", column.Name, err)
+			}
+			for _, issue := range issues {
+				row := boardIssueView{Key: issue.Key, Summary: issue.Fields.Summary}
+				if issue.Fields.Assignee != nil {
+					row.Assignee = issue.Fields.Assignee.DisplayName
+				}
+				col.IssueList = append(col.IssueList, row)
+			}
+		}
+		view.Columns = append(view.Columns, col)
+	}
+	return view, nil
+}
+
+func printBoardView(out io.Writer, view *boardView) {
+	fmt.Fprintf(out, "Board %d: %s (%s)\n", view.ID, view.Name, view.Type)
+	if view.FilterJQL != "" {
+		fmt.Fprintf(out, "Filter: %s\n", view.FilterJQL)
+	} else {
+		fmt.Fprintf(out, "Filter: %s\n", view.FilterID)
+	}
+	if view.Estimation != "" {
+		fmt.Fprintf(out, "Estimation: %s\n", view.Estimation)
+	}
+	fmt.Fprintln(out)
+
+	fmt.Fprintf(out, "  %-20s %6s  %-9s %s\n", "Column", "Issues", "WIP", "Statuses")
+	for _, col := range view.Columns {
+		fmt.Fprintf(out, "  %-20s %6d  %-9s %s", col.Column, col.Issues, wipLimits(col.Min, col.Max), strings.Join(col.Statuses, ", "))
+		switch col.WIP {
+		case "over":
+			fmt.Fprintf(out, "  <- over WIP limit by %d", col.Issues-*col.Max)
+		case "under":
+			fmt.Fprintf(out, "  <- under minimum by %d", *col.Min-col.Issues)
+		}
+		fmt.Fprintln(out)
+		for _, issue := range col.IssueList {
+			line := fmt.Sprintf("      %s  %s", issue.Key, issue.Summary)
+			if issue.Assignee != "" {
+				line += " (" + issue.Assignee + ")"
+			}
+			fmt.Fprintln(out, line)
+		}
+	}
+}
+
+// wipLimits renders a column's limits as "min-max", "≤max" or "≥min".
+func wipLimits(minimum, maximum *int) string {
+	switch {
+	case minimum != nil && maximum != nil:
+		return fmt.Sprintf("%d-%d", *minimum, *maximum)
+	case maximum != nil:
+		return fmt.Sprintf("≤%d", *maximum)
+	case minimum != nil:
+		return fmt.Sprintf("≥%d", *minimum)
+	}
+	return "-"
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/relux-works/skill-jira-management/internal/routing"
+	"github.com/spf13/cobra"
+)
+
+var (
+	componentName         string
+	componentDescription  string
+	componentLead         string
+	componentAssigneeType string
+)
+
+var componentsCmd = &cobra.Command{
+	Use:   "components",
+	Short: "Manage project components",
+	Long: `List, create and update the components of --project (or the configured
+project), including the component lead and default assignee.`,
+}
+
+var componentsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List project components",
+	Long: `List the project's components with their lead and default assignee.
+
+Examples:
+  jira-mgmt components list
+  jira-mgmt components list --format text`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagProject == "" {
+			return fmt.Errorf("no project configured: use --project")
+		}
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+
+		components, err := client.ListComponents(flagProject)
+		if err != nil {
+			return fmt.Errorf("listing components: %w", err)
+		}
+		if components == nil {
+			components = []jira.Component{}
+		}
+		return writeReport(cmd.OutOrStdout(), components, func(out io.Writer) { printComponents(out, components) })
+	},
+}
+
+var componentsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a component",
+	Long: `Create a component in the project. --lead is an account ID on Cloud and a
+username on Server/DC. --assignee-type sets who new issues in the component
+are assigned to: project-default, component-lead, project-lead or unassigned.
+
+Examples:
+  jira-mgmt components create --name Backend
+  jira-mgmt components create --name Backend --lead 5b10a2844c20165700ede21g --assignee-type component-lead`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if componentName == "" {
+			return fmt.Errorf("--name is required")
+		}
+		if flagProject == "" {
+			return fmt.Errorf("no project configured: use --project")
+		}
+		assigneeType, err := parseAssigneeType(componentAssigneeType)
+		if err != nil {
+			return err
+		}
+
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+
+		req := &jira.CreateComponentRequest{
+			Project:      flagProject,
+			Name:         componentName,
+			Description:  componentDescription,
+			AssigneeType: assigneeType,
+		}
+		if componentLead != "" {
+			if client.IsCloud() {
+				req.LeadAccountID = componentLead
+			} else {
+				req.LeadUserName = componentLead
+			}
+		}
+
+		component, err := client.CreateComponent(req)
+		if err != nil {
+			return fmt.Errorf("creating component: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Created component %s (id %s)\n", component.Name, component.ID)
+		return nil
+	},
+}
+
+var componentsUpdateCmd = &cobra.Command{
+	Use:   "update <COMPONENT>",
+	Short: "Update a component",
+	Long: `Update a component, given by name or ID. Only the given flags are changed;
+--lead "" removes the lead.
+
+Examples:
+  jira-mgmt components update Backend --lead 5b10a2844c20165700ede21g
+  jira-mgmt components update Backend --assignee-type component-lead
+  jira-mgmt components update 10050 --name "Backend API" --description "REST and gRPC services"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagProject == "" {
+			return fmt.Errorf("no project configured: use --project")
+		}
+
+		req := &jira.UpdateComponentRequest{}
+		flags := cmd.Flags()
+		if flags.Changed("name") {
+			req.Name = &componentName
+		}
+		if flags.Changed("description") {
+			req.Description = &componentDescription
+		}
+		if flags.Changed("assignee-type") {
+			assigneeType, err := parseAssigneeType(componentAssigneeType)
+			if err != nil {
+				return err
+			}
+			req.AssigneeType = &assigneeType
+		}
+
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+		if flags.Changed("lead") {
+			if client.IsCloud() {
+				req.LeadAccountID = &componentLead
+			} else {
+				req.LeadUserName = &componentLead
+			}
+		}
+		if *req == (jira.UpdateComponentRequest{}) {
+			return fmt.Errorf("nothing to update: use --name, --description, --lead or --assignee-type")
+		}
+
+		component, err := client.FindComponent(flagProject, args[0])
+		if err != nil {
+			return err
+		}
+		updated, err := client.UpdateComponent(component.ID, req)
+		if err != nil {
+			return fmt.Errorf("updating component: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Updated component %s\n", updated.Name)
+		return nil
+	},
+}
+
+var componentsSuggestCmd = &cobra.Command{
+	Use:   "suggest <TEXT>",
+	Short: "Suggest components for a text from the component rules",
+	Long: `Match a text against the component rule file (config key component_rules)
+and print the suggested components, best first. create uses the same rules.
+
+Examples:
+  jira-mgmt components suggest "Login endpoint in internal/api/auth.go returns 500"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rules, err := loadComponentRules()
+		if err != nil {
+			return err
+		}
+		if rules == nil {
+			return fmt.Errorf("no component rules configured: use 'jira-mgmt config set component_rules FILE'")
+		}
+
+		suggestions := rules.Suggest(args[0])
+		if suggestions == nil {
+			suggestions = []routing.Suggestion{}
+		}
+		return writeReport(cmd.OutOrStdout(), suggestions, func(out io.Writer) {
+			if len(suggestions) == 0 {
+				fmt.Fprintln(out, "No component matched")
+			}
+			for _, s := range suggestions {
+				fmt.Fprintf(out, "%s (matched %s)\n", s.Component, strings.Join(s.Matches, ", "))
+			}
+		})
+	},
+}
+
+func init() {
+	componentsCreateCmd.Flags().StringVar(&componentName, "name", "", "Component name (required)")
+	componentsCreateCmd.Flags().StringVar(&componentDescription, "description", "", "Component description")
+	componentsCreateCmd.Flags().StringVar(&componentLead, "lead", "", "Component lead: account ID (Cloud) or username (Server/DC)")
+	componentsCreateCmd.Flags().StringVar(&componentAssigneeType, "assignee-type", "", "Default assignee: project-default, component-lead, project-lead, unassigned")
+
+	componentsUpdateCmd.Flags().StringVar(&componentName, "name", "", "New name")
+	componentsUpdateCmd.Flags().StringVar(&componentDescription, "description", "", "New description")
+	componentsUpdateCmd.Flags().StringVar(&componentLead, "lead", "", "New lead: account ID (Cloud) or username (Server/DC)")
+	componentsUpdateCmd.Flags().StringVar(&componentAssigneeType, "assignee-type", "", "Default assignee: project-default, component-lead, project-lead, unassigned")
+
+	componentsCmd.AddCommand(componentsListCmd, componentsCreateCmd, componentsUpdateCmd, componentsSuggestCmd)
+	rootCmd.AddCommand(componentsCmd)
+}
+
+// parseAssigneeType turns component-lead style names into Jira's COMPONENT_LEAD values.
+func parseAssigneeType(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	value := strings.ToUpper(strings.ReplaceAll(s, "-", "_"))
+	switch value {
+	case "PROJECT_DEFAULT", "COMPONENT_LEAD", "PROJECT_LEAD", "UNASSIGNED":
+		return value, nil
+	}
+	return "", fmt.Errorf("invalid --assignee-type %q: use project-default, component-lead, project-lead or unassigned", s)
+}
+
+// loadComponentRules loads the configured component rule file, or returns nil
+// when none is configured.
+func loadComponentRules() (*routing.Rules, error) {
+	file := loadConfigOrDefault().ComponentRules
+	if file == "" {
+		return nil, nil
+	}
+	return routing.Load(file)
+}
+
+func printComponents(out io.Writer, components []jira.Component) {
+	fmt.Fprintf(out, "%-8s %-24s %-20s %s\n", "ID", "Name", "Lead", "Default assignee")
+	for _, c := range components {
+		lead := ""
+		if c.Lead != nil {
+			lead = c.Lead.DisplayName
+		}
+		assignee := strings.ToLower(strings.ReplaceAll(c.AssigneeType, "_", "-"))
+		if c.Assignee != nil {
+			assignee += " (" + c.Assignee.DisplayName + ")"
+		}
+		fmt.Fprintf(out, "%-8s %-24s %-20s %s\n", c.ID, c.Name, valueOrNone(lead), assignee)
+	}
+}
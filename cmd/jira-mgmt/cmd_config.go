@@ -2,9 +2,13 @@ package main
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/relux-works/skill-jira-management/internal/config"
+	"github.com/relux-works/skill-jira-management/internal/query"
 	"github.com/spf13/cobra"
 )
 
@@ -22,13 +26,29 @@ Keys:
   project          — active Jira project key (e.g. PROJ)
   board            — active board ID (e.g. 42)
   locale           — content locale: en or ru
-  tls_skip_verify  — skip TLS cert verification: true/false (for corporate CAs)
+  tls_skip_verify  — skip TLS cert verification: true/false (last resort; prefer ca_cert_file)
+  ca_cert_file     — PEM CA bundle trusted in addition to the system roots
+  client_cert_file — PEM client certificate for mTLS
+  client_key_file  — PEM private key for client_cert_file
+  proxy_url        — HTTP(S) proxy URL (default: HTTPS_PROXY/HTTP_PROXY env)
+  no_proxy         — comma-separated hosts/domains/CIDRs that bypass proxy_url
+  timeout          — per-request timeout, e.g. 60s (default 30s)
+  credential_command   — shell command printing credentials JSON or a token ("" to disable)
+  credential_cache_ttl — cache credential_command output for a duration, e.g. 15m ("" to disable)
+  field_alias.<name>   — query DSL name for a field ID or name ("" to remove)
+  component_rules      — YAML file mapping paths/keywords to components for create ("" to disable)
 
 Examples:
   jira-mgmt config set project MYPROJ
   jira-mgmt config set board 42
   jira-mgmt config set locale en
-  jira-mgmt config set tls_skip_verify true`,
+  jira-mgmt config set tls_skip_verify true
+  jira-mgmt config set ca_cert_file /etc/ssl/corp-root.pem
+  jira-mgmt config set proxy_url http://proxy.corp:3128
+  jira-mgmt config set credential_command "op read op://Private/jira/credential"
+  jira-mgmt config set credential_cache_ttl 15m
+  jira-mgmt config set field_alias.sp customfield_10016
+  jira-mgmt config set component_rules /path/to/repo/.jira-components.yaml`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key := args[0]
@@ -41,6 +61,21 @@ Examples:
 
 		out := cmd.OutOrStdout()
 
+		if alias, ok := strings.CutPrefix(key, "field_alias."); ok {
+			if value != "" && query.ReservedFieldName(alias) {
+				return fmt.Errorf("field alias %q is a built-in field, preset or operation argument name: choose another name", alias)
+			}
+			if err := cfgMgr.SetFieldAlias(alias, value); err != nil {
+				return err
+			}
+			if value == "" {
+				fmt.Fprintf(out, "Field alias %s removed\n", alias)
+			} else {
+				fmt.Fprintf(out, "Field alias %s set to %s\n", alias, value)
+			}
+			return nil
+		}
+
 		switch key {
 		case "project":
 			if err := cfgMgr.SetActiveProject(value); err != nil {
@@ -71,8 +106,62 @@ Examples:
 			}
 			fmt.Fprintf(out, "TLS skip verify set to %v\n", skip)
 
+		case "ca_cert_file":
+			if err := cfgMgr.SetCACertFile(value); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "CA bundle set to %s\n", valueOrNone(value))
+
+		case "client_cert_file":
+			if err := cfgMgr.SetClientCertFile(value); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Client certificate set to %s\n", valueOrNone(value))
+
+		case "client_key_file":
+			if err := cfgMgr.SetClientKeyFile(value); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Client key set to %s\n", valueOrNone(value))
+
+		case "proxy_url":
+			if err := cfgMgr.SetProxyURL(value); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Proxy set to %s\n", valueOrNone(value))
+
+		case "no_proxy":
+			if err := cfgMgr.SetNoProxy(value); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "No-proxy list set to %s\n", valueOrNone(value))
+
+		case "timeout":
+			if err := cfgMgr.SetTimeout(value); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Timeout set to %s\n", valueOrNone(value))
+
+		case "credential_command":
+			if err := cfgMgr.SetCredentialCommand(value); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Credential command set to %s\n", valueOrNone(value))
+
+		case "credential_cache_ttl":
+			if err := cfgMgr.SetCredentialCacheTTL(value); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Credential cache TTL set to %s\n", valueOrNone(value))
+
+		case "component_rules":
+			if err := cfgMgr.SetComponentRules(value); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Component rules set to %s\n", valueOrNone(value))
+
 		default:
-			return fmt.Errorf("unknown config key %q (supported: project, board, locale, tls_skip_verify)", key)
+			return fmt.Errorf("unknown config key %q (supported: project, board, locale, tls_skip_verify, ca_cert_file, client_cert_file, client_key_file, proxy_url, no_proxy, timeout, credential_command, credential_cache_ttl, component_rules, field_alias.<name>)", key)
 		}
 
 		return nil
@@ -106,6 +195,33 @@ var configShowCmd = &cobra.Command{
 		}
 		fmt.Fprintf(out, "  locale:         %s\n", cfg.Locale)
 		fmt.Fprintf(out, "  tls skip verify: %v\n", cfg.TLSSkipVerify)
+		if cfg.CACertFile != "" {
+			fmt.Fprintf(out, "  ca cert file:   %s\n", cfg.CACertFile)
+		}
+		if cfg.ClientCertFile != "" {
+			fmt.Fprintf(out, "  client cert:    %s\n", cfg.ClientCertFile)
+			fmt.Fprintf(out, "  client key:     %s\n", valueOrNone(cfg.ClientKeyFile))
+		}
+		if cfg.ProxyURL != "" {
+			fmt.Fprintf(out, "  proxy:          %s\n", cfg.ProxyURL)
+			fmt.Fprintf(out, "  no proxy:       %s\n", valueOrNone(cfg.NoProxy))
+		}
+		if cfg.Timeout != "" {
+			fmt.Fprintf(out, "  timeout:        %s\n", cfg.Timeout)
+		}
+		if cfg.CredentialCommand != "" {
+			fmt.Fprintf(out, "  credential command: %s\n", cfg.CredentialCommand)
+			fmt.Fprintf(out, "  credential cache ttl: %s\n", valueOrNone(cfg.CredentialCacheTTL))
+		}
+		if cfg.ComponentRules != "" {
+			fmt.Fprintf(out, "  component rules: %s\n", cfg.ComponentRules)
+		}
+		if len(cfg.FieldAliases) > 0 {
+			fmt.Fprintln(out, "  field aliases:")
+			for _, alias := range slices.Sorted(maps.Keys(cfg.FieldAliases)) {
+				fmt.Fprintf(out, "    %s → %s\n", alias, cfg.FieldAliases[alias])
+			}
+		}
 
 		return nil
 	},
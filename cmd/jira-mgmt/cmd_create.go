@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"slices"
+	"strings"
 
 	"github.com/relux-works/skill-jira-management/internal/jira"
 	"github.com/spf13/cobra"
@@ -14,6 +17,8 @@ var (
 	createProjectFlag string
 	createParent      string
 	createLabels      []string
+	createComponents  []string
+	createAssignee    string
 )
 
 var createCmd = &cobra.Command{
@@ -25,7 +30,14 @@ Examples:
   jira-mgmt create --type epic --summary "Auth system" --project PROJ
   jira-mgmt create --type story --summary "Login flow" --parent PROJ-1
   jira-mgmt create --type task --summary "Write tests" --description "Unit tests for auth"
-  jira-mgmt create --type subtask --summary "Fix login" --parent PROJ-42`,
+  jira-mgmt create --type subtask --summary "Fix login" --parent PROJ-42
+  jira-mgmt create --type bug --summary "Login 500" --component Backend
+  jira-mgmt create --type task --summary "Rotate keys" --assignee alice@corp.com
+  jira-mgmt create --type bug --summary "Login 500" --description "In internal/api/auth.go" --component auto
+
+With component rules configured (config key component_rules), create suggests
+a component from the summary and description when --component is not given;
+--component auto uses the best suggestion.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := buildJiraClientFromConfig()
 		if err != nil {
@@ -56,12 +68,27 @@ Examples:
 			},
 		}
 
+		components, err := resolveCreateComponents(cmd.ErrOrStderr(), createComponents, createSummary+"\n"+createDescription)
+		if err != nil {
+			return err
+		}
+		for _, name := range components {
+			req.Fields.Components = append(req.Fields.Components, jira.ComponentRef{Name: name})
+		}
+
 		if createDescription != "" {
 			req.Fields.Description = jira.NewADFText(createDescription)
 		}
 		if createParent != "" {
 			req.Fields.Parent = &jira.IssueRef{Key: createParent}
 		}
+		if createAssignee != "" {
+			ref, _, err := resolveAssignee(client, createAssignee, project, "")
+			if err != nil {
+				return err
+			}
+			req.Fields.Assignee = ref
+		}
 
 		resp, err := client.CreateIssue(req)
 		if err != nil {
@@ -74,6 +101,56 @@ Examples:
 	},
 }
 
+// resolveCreateComponents returns the components for a new issue. "auto"
+// stands for the best rule suggestion for text; with no components given, the
+// suggestions are only printed as a hint.
+func resolveCreateComponents(stderr io.Writer, components []string, text string) ([]string, error) {
+	auto := slices.Contains(components, "auto")
+	if len(components) > 0 && !auto {
+		return components, nil
+	}
+
+	rules, err := loadComponentRules()
+	if err != nil {
+		if auto {
+			return nil, err
+		}
+		fmt.Fprintf(stderr, "Warning: %v\n", err)
+		return nil, nil
+	}
+	if rules == nil {
+		if auto {
+			return nil, fmt.Errorf("--component auto needs component rules: use 'jira-mgmt config set component_rules FILE'")
+		}
+		return nil, nil
+	}
+
+	suggestions := rules.Suggest(text)
+	if !auto {
+		if len(suggestions) > 0 {
+			best := suggestions[0]
+			fmt.Fprintf(stderr, "Suggested component: %s (matched %s); pass --component %q or --component auto to set it\n",
+				best.Component, strings.Join(best.Matches, ", "), best.Component)
+		}
+		return nil, nil
+	}
+	if len(suggestions) == 0 {
+		return nil, fmt.Errorf("--component auto: no component rule matched the summary or description")
+	}
+
+	var resolved []string
+	for _, c := range components {
+		if c == "auto" {
+			c = suggestions[0].Component
+		}
+		if !slices.Contains(resolved, c) {
+			resolved = append(resolved, c)
+		}
+	}
+	fmt.Fprintf(stderr, "Component: %s (matched %s)\n", suggestions[0].Component, strings.Join(suggestions[0].Matches, ", "))
+	return resolved, nil
+}
+
 // normalizeIssueType converts common short names to Jira issue type names.
 func normalizeIssueType(t string) string {
 	switch t {
@@ -99,6 +176,8 @@ func init() {
 	createCmd.Flags().StringVar(&createProjectFlag, "project", "", "Project key (overrides global --project)")
 	createCmd.Flags().StringVar(&createParent, "parent", "", "Parent issue key (for stories/subtasks)")
 	createCmd.Flags().StringSliceVar(&createLabels, "label", nil, "Issue labels (repeatable)")
+	createCmd.Flags().StringVar(&createAssignee, "assignee", "", "Assignee: email, display name, username or me")
+	createCmd.Flags().StringSliceVar(&createComponents, "component", nil, "Component name, or auto for the rule suggestion (repeatable)")
 
 	rootCmd.AddCommand(createCmd)
 }
@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 
-	"github.com/relux-works/skill-jira-management/internal/jira"
 	"github.com/relux-works/skill-jira-management/internal/search"
 	"github.com/spf13/cobra"
 )
@@ -27,7 +26,10 @@ Scopes:
 Examples:
   jira-mgmt grep "authentication" --format json
   jira-mgmt grep "TODO" --scope issues -i --format compact
-  jira-mgmt grep "deploy" --scope comments -C 2 --format json`,
+  jira-mgmt grep "deploy" --scope comments -C 2 --format json
+  jira-mgmt grep "timeout" --format ndjson | head -20
+
+--format ndjson streams one match per line while issues are still being fetched.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		pattern := args[0]
@@ -47,52 +49,59 @@ Examples:
 			CaseInsensitive: grepCaseInsensitive,
 			ContextLines:    grepContextLines,
 		}
-
-		// Fetch issues for the project
-		issues, err := client.ListIssues(jira.ListIssuesOptions{
-			ProjectKey: project,
-			Fields:     []string{"summary", "description", "labels"},
-		})
+		matcher, err := search.NewMatcher(pattern, opts)
 		if err != nil {
-			return fmt.Errorf("fetching issues: %w", err)
+			return err
 		}
+		searchComments := grepScope == "comments" || grepScope == "all" || grepScope == ""
 
+		out := cmd.OutOrStdout()
+		// "json" buffers matches into one array; "ndjson" and text stream them
+		// as each page of issues arrives.
 		var allMatches []search.Match
+		emit := func(matches []search.Match) error {
+			switch flagFormat {
+			case "json":
+				allMatches = append(allMatches, matches...)
+				return nil
+			case "ndjson":
+				return search.WriteNDJSON(out, matches)
+			default:
+				_, err := fmt.Fprint(out, search.PrintText(matches))
+				return err
+			}
+		}
 
-		// Search issues
-		if grepScope == "issues" || grepScope == "all" || grepScope == "" {
-			matches, err := search.GrepIssues(issues, pattern, opts)
+		jql := fmt.Sprintf("project = %s", project)
+		for issue, err := range client.SearchIter(jql, []string{"summary", "description", "labels"}) {
 			if err != nil {
+				return fmt.Errorf("fetching issues: %w", err)
+			}
+
+			if err := emit(matcher.Issue(&issue)); err != nil {
 				return err
 			}
-			allMatches = append(allMatches, matches...)
-		}
 
-		// Search comments
-		if grepScope == "comments" || grepScope == "all" || grepScope == "" {
-			for _, issue := range issues {
+			if searchComments {
 				comments, err := client.ListAllComments(issue.Key)
 				if err != nil {
 					continue
 				}
-				matches, err := search.GrepComments(comments, issue.Key, pattern, opts)
-				if err != nil {
-					continue
+				if err := emit(matcher.Comments(comments, issue.Key)); err != nil {
+					return err
 				}
-				allMatches = append(allMatches, matches...)
 			}
 		}
 
-		out := cmd.OutOrStdout()
-		// "json" -> JSON, "text"/"compact"/"llm" -> text format
 		if flagFormat == "json" {
+			if allMatches == nil {
+				allMatches = []search.Match{}
+			}
 			data, err := search.PrintJSON(allMatches)
 			if err != nil {
 				return err
 			}
 			fmt.Fprintln(out, string(data))
-		} else {
-			fmt.Fprint(out, search.PrintText(allMatches))
 		}
 
 		return nil
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/relux-works/skill-jira-management/internal/plan"
+	"github.com/spf13/cobra"
+)
+
+var planDiffExitCode bool
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Reconcile a plan file with Jira",
+	Long: `Compare a version-controlled plan (see 'jira-mgmt apply') with Jira and make
+Jira follow it. Besides creating missing issues, summary, description, status,
+assignee, priority, labels, components, parent, custom fields and links of
+issues already in the plan are brought in line with it. Only what the plan
+sets is managed.`,
+}
+
+var planDiffCmd = &cobra.Command{
+	Use:   "diff <PLAN-FILE>",
+	Short: "Show what plan apply would change",
+	Long: `Show the issues plan apply would create, the fields it would update, the
+transitions and links it would make, and drift it cannot resolve: planned
+issues that no longer exist and issues under planned parents the plan does
+not list.
+
+Examples:
+  jira-mgmt plan diff roadmap.yaml --format text
+  jira-mgmt plan diff roadmap.yaml --exit-code   # fails when Jira differs, for CI`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applier, p, err := loadPlanApplier(args[0])
+		if err != nil {
+			return err
+		}
+		d, err := applier.Diff(p)
+		if err != nil {
+			return err
+		}
+		if err := writeReport(cmd.OutOrStdout(), d, func(w io.Writer) { printPlanDiff(w, d) }); err != nil {
+			return err
+		}
+		if planDiffExitCode && !d.Empty() {
+			return fmt.Errorf("jira differs from the plan")
+		}
+		return nil
+	},
+}
+
+var planApplyCmd = &cobra.Command{
+	Use:   "apply <PLAN-FILE>",
+	Short: "Make Jira follow the plan",
+	Long: `Create missing issues, update fields, transition statuses and add links so
+that Jira matches the plan, then report the outcome. Run 'jira-mgmt plan diff'
+first to review the changes. Drift is reported, not changed.
+
+Examples:
+  jira-mgmt plan apply roadmap.yaml --format text`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applier, p, err := loadPlanApplier(args[0])
+		if err != nil {
+			return err
+		}
+		d, err := applier.Diff(p)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if len(d.Changes) == 0 {
+			return writeReport(out, map[string]any{"diff": d}, func(w io.Writer) { printPlanDiff(w, d) })
+		}
+		res, err := applier.Reconcile(p, d)
+		if err != nil {
+			return err
+		}
+		report := map[string]any{"diff": d, "result": res}
+		if err := writeReport(out, report, func(w io.Writer) { printPlanDiff(w, d); fmt.Fprintln(w); printReconcileResult(w, res) }); err != nil {
+			return err
+		}
+		if res.Failed > 0 {
+			return fmt.Errorf("%d plan change(s) failed", res.Failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	planDiffCmd.Flags().BoolVar(&planDiffExitCode, "exit-code", false, "Exit with an error when Jira differs from the plan")
+
+	planCmd.AddCommand(planDiffCmd, planApplyCmd)
+	rootCmd.AddCommand(planCmd)
+}
+
+func loadPlanApplier(path string) (*plan.Applier, *plan.Plan, error) {
+	p, err := plan.Load(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := buildJiraClientFromConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	applier, err := newPlanApplier(client, p)
+	if err != nil {
+		return nil, nil, err
+	}
+	return applier, p, nil
+}
+
+// printPlanDiff prints a diff terraform-style: + create, ~ update, ! drift.
+func printPlanDiff(out io.Writer, d *plan.Diff) {
+	if d.Empty() {
+		fmt.Fprintf(out, "No changes. Jira matches the plan for project %s.\n", d.Project)
+		return
+	}
+	for _, ch := range d.Changes {
+		mark := "~"
+		if ch.Action == plan.ActionCreate {
+			mark = "+"
+		}
+		indent := strings.Repeat("  ", ch.Depth)
+		fmt.Fprintf(out, "%s %s%s %s: %s\n", mark, indent, cmp.Or(ch.Key, "(new)"), ch.Type, ch.Summary)
+		for _, fc := range ch.Fields {
+			if ch.Action == plan.ActionCreate {
+				fmt.Fprintf(out, "    %s%s: %s\n", indent, fc.Field, fc.To)
+			} else {
+				fmt.Fprintf(out, "    %s%s: %q -> %q\n", indent, fc.Field, fc.From, fc.To)
+			}
+		}
+		if t := ch.Transition; t != nil {
+			if t.From == "" {
+				fmt.Fprintf(out, "    %sstatus: %s\n", indent, t.To)
+			} else {
+				fmt.Fprintf(out, "    %sstatus: %q -> %q (transition)\n", indent, t.From, t.To)
+			}
+		}
+		for _, l := range ch.Links {
+			fmt.Fprintf(out, "    %s+ link: %s %s\n", indent, l.Type, l.To)
+		}
+	}
+	for _, dr := range d.Drift {
+		fmt.Fprintf(out, "! %s %s (%s)\n", dr.Key, dr.Summary, dr.Reason)
+	}
+	fmt.Fprintf(out, "\nPlan: %d to create, %d to update, %d to transition, %d link(s) to add; %d drifted.\n",
+		d.Creates, d.Updates, d.Transitions, d.Links, len(d.Drift))
+}
+
+// printReconcileResult prints what plan apply did; unchanged issues are left out.
+func printReconcileResult(out io.Writer, res *plan.ReconcileResult) {
+	for _, e := range res.Apply.Issues {
+		if e.Status == plan.StatusExisting && e.Error == "" {
+			continue
+		}
+		status := "done  "
+		if e.Status == plan.StatusFailed || e.Error != "" {
+			status = "FAILED"
+		}
+		fmt.Fprintf(out, "%s %s create %s: %s", status, cmp.Or(e.Key, "(new)"), e.Type, e.Summary)
+		if e.Error != "" {
+			fmt.Fprintf(out, " (%s)", e.Error)
+		}
+		fmt.Fprintln(out)
+	}
+	for _, l := range res.Apply.Links {
+		if l.Status == plan.StatusExisting {
+			continue
+		}
+		status := "done  "
+		if l.Status == plan.StatusFailed {
+			status = "FAILED"
+		}
+		fmt.Fprintf(out, "%s %s link %s %s", status, l.From, l.Type, l.To)
+		if l.Error != "" {
+			fmt.Fprintf(out, " (%s)", l.Error)
+		}
+		fmt.Fprintln(out)
+	}
+	for _, o := range res.Updates {
+		status := "done  "
+		if o.Status == plan.StatusFailed {
+			status = "FAILED"
+		}
+		fmt.Fprintf(out, "%s %s %s %s", status, o.Key, o.Action, o.Detail)
+		if o.Error != "" {
+			fmt.Fprintf(out, " (%s)", o.Error)
+		}
+		fmt.Fprintln(out)
+	}
+}
@@ -14,6 +14,7 @@ import (
 // DSL queries via the agentquery schema.
 func buildQueryCommand() *cobra.Command {
 	var format string
+	var refreshFields bool
 
 	cmd := &cobra.Command{
 		Use:   "q '<query>'",
@@ -23,13 +24,24 @@ func buildQueryCommand() *cobra.Command {
 Operations:
   get(ISSUE-KEY) { fields }             — single issue lookup
   list(project=X, type=epic) { fields } — filtered listing
+  tree(ISSUE-KEY, depth=3) { fields }   — hierarchy with progress rollups
+  comments(ISSUE-KEY, since=7d) { author created body } — comments (or jql="..." for many issues)
   count(project=X, status=done)         — count matching issues
   summary()                             — project/board overview
+  boards(project=X) / sprints(board=N, state=active) — agile boards and sprints
+  sprint_issues(sprint=N) { fields } / backlog(board=N) { fields } — sprint contents, backlog
+  group(by="status,type", percent=true) — grouped counts or sum(<number field>)
   search(jql="...") { fields }          — JQL search
+  fields()                              — custom fields usable as fields, filters and sort keys
   schema()                              — introspect available operations, fields, presets
 
 Field presets: minimal, default, overview, full
 Batch: separate queries with semicolons.
+--format ndjson prints one JSON object per line; search() rows stream as pages arrive.
+
+Custom fields are named after the Jira field in snake_case (Story Points → story_points),
+plus aliases from 'jira-mgmt config set field_alias.<name> <field>'. The field catalog
+is cached for 24h; --refresh-fields refetches it.
 
 Examples:
   jira-mgmt q 'get(PROJ-123) { overview }' --format json
@@ -37,7 +49,9 @@ Examples:
   jira-mgmt q 'summary()' --format json
   jira-mgmt q 'search(jql="assignee = currentUser()") { default }' --format json
   jira-mgmt q 'get(PROJ-1) { minimal }; get(PROJ-2) { minimal }' --format json
-  jira-mgmt q 'schema()' --format json`,
+  jira-mgmt q 'schema()' --format json
+  jira-mgmt q 'search(jql="project = PROJ") { minimal }' --format ndjson
+  jira-mgmt q 'list(story_points=">=5", sort_story_points=desc) { key summary story_points }'`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := buildJiraClientFromConfig()
@@ -45,16 +59,35 @@ Examples:
 				return err
 			}
 
-			schema := query.NewSchema(client, flagProject, flagBoard)
-			return runQuery(cmd, schema, args[0], format)
+			opts := []query.Option{fieldCatalogOption(cmd, client, refreshFields)}
+
+			if strings.ToLower(format) == "ndjson" {
+				runner := query.NewRunner(client, flagProject, flagBoard, append(opts, query.WithStream(cmd.OutOrStdout()))...)
+				return runQueryNDJSON(cmd, runner, args[0])
+			}
+
+			runner := query.NewRunner(client, flagProject, flagBoard, opts...)
+			return runQuery(cmd, runner, args[0], format)
 		},
 	}
 
-	cmd.Flags().StringVar(&format, "format", "json", `Output format: "json", "compact", or "llm"`)
+	cmd.Flags().StringVar(&format, "format", "json", `Output format: "json", "compact", "llm", or "ndjson"`)
+	cmd.Flags().BoolVar(&refreshFields, "refresh-fields", false, "Refetch the custom field catalog instead of using the cache")
 
 	return cmd
 }
 
+// fieldCatalogOption loads the instance's field catalog for custom DSL fields.
+// A catalog that cannot be loaded only costs the custom fields, so it is a warning.
+func fieldCatalogOption(cmd *cobra.Command, client *jira.Client, refresh bool) query.Option {
+	cfg := loadConfigOrDefault()
+	fields, err := loadFieldCatalog(client, refresh)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: custom fields unavailable: %v\n", err)
+	}
+	return query.WithFieldCatalog(fields, cfg.FieldAliases)
+}
+
 // parseOutputMode converts a format flag value to an agentquery.OutputMode.
 func parseOutputMode(s string) (agentquery.OutputMode, error) {
 	switch strings.ToLower(s) {
@@ -63,17 +96,17 @@ func parseOutputMode(s string) (agentquery.OutputMode, error) {
 	case "json":
 		return agentquery.HumanReadable, nil
 	default:
-		return 0, fmt.Errorf("unknown format %q: use \"json\", \"compact\", or \"llm\"", s)
+		return 0, fmt.Errorf("unknown format %q: use \"json\", \"compact\", \"llm\", or \"ndjson\"", s)
 	}
 }
 
-// runQuery executes the query against the schema and writes output to the command's stdout.
-func runQuery(cmd *cobra.Command, schema *agentquery.Schema[jira.Issue], queryStr string, format string) error {
+// runQuery executes the query and writes output to the command's stdout.
+func runQuery(cmd *cobra.Command, runner *query.Runner, queryStr string, format string) error {
 	mode, err := parseOutputMode(format)
 	if err != nil {
 		return err
 	}
-	data, err := schema.QueryJSONWithMode(queryStr, mode)
+	data, err := runner.QueryJSONWithMode(queryStr, mode)
 	if err != nil {
 		return err
 	}
@@ -85,6 +118,16 @@ func runQuery(cmd *cobra.Command, schema *agentquery.Schema[jira.Issue], querySt
 	return err
 }
 
+// runQueryNDJSON executes the query and writes results as newline-delimited JSON.
+// Operations on a streaming schema have already written their rows by the time Query returns.
+func runQueryNDJSON(cmd *cobra.Command, runner *query.Runner, queryStr string) error {
+	result, err := runner.Query(queryStr)
+	if err != nil {
+		return err
+	}
+	return query.WriteNDJSON(cmd.OutOrStdout(), result)
+}
+
 func init() {
 	rootCmd.AddCommand(buildQueryCommand())
 }
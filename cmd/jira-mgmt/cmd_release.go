@@ -0,0 +1,351 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/relux-works/skill-jira-management/internal/release"
+	"github.com/spf13/cobra"
+)
+
+var (
+	versionName        string
+	versionDescription string
+	versionStart       string
+	versionDate        string
+	versionArchive     bool
+	versionAll         bool
+
+	notesVersion         string
+	notesRender          string
+	notesPublish         string
+	notesIssue           string
+	notesIncludeSubtasks bool
+)
+
+var versionsCmd = &cobra.Command{
+	Use:   "versions",
+	Short: "Manage project versions (fix versions)",
+	Long: `List, create, update and release versions of --project (or the configured
+project). Versions are named by name or ID; dates are 2026-03-13.`,
+}
+
+var versionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List project versions",
+	Long: `List the project's versions; archived versions only with --all.
+
+Examples:
+  jira-mgmt versions list
+  jira-mgmt versions list --all --format text`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagProject == "" {
+			return fmt.Errorf("no project configured: use --project")
+		}
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+
+		versions, err := client.ListVersions(flagProject)
+		if err != nil {
+			return fmt.Errorf("listing versions: %w", err)
+		}
+		shown := []jira.Version{}
+		for _, v := range versions {
+			if versionAll || !v.Archived {
+				shown = append(shown, v)
+			}
+		}
+		return writeReport(cmd.OutOrStdout(), shown, func(out io.Writer) { printVersions(out, shown) })
+	},
+}
+
+var versionsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a version",
+	Long: `Create a version in the project.
+
+Examples:
+  jira-mgmt versions create --name 2.3
+  jira-mgmt versions create --name 2.3 --start 2026-03-02 --date 2026-03-13 --description "Search release"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if versionName == "" {
+			return fmt.Errorf("--name is required")
+		}
+		if flagProject == "" {
+			return fmt.Errorf("no project configured: use --project")
+		}
+		if err := validateVersionDates(versionStart, versionDate); err != nil {
+			return err
+		}
+
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+		project, err := client.GetProject(flagProject)
+		if err != nil {
+			return fmt.Errorf("getting project: %w", err)
+		}
+		var projectID int
+		if _, err := fmt.Sscan(project.ID, &projectID); err != nil {
+			return fmt.Errorf("project %s has no numeric ID: %q", flagProject, project.ID)
+		}
+
+		version, err := client.CreateVersion(&jira.CreateVersionRequest{
+			Name:        versionName,
+			ProjectID:   projectID,
+			Description: versionDescription,
+			StartDate:   versionStart,
+			ReleaseDate: versionDate,
+		})
+		if err != nil {
+			return fmt.Errorf("creating version: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Created version %s (id %s)\n", version.Name, version.ID)
+		return nil
+	},
+}
+
+var versionsUpdateCmd = &cobra.Command{
+	Use:   "update <VERSION>",
+	Short: "Update a version",
+	Long: `Update a version. Only the given flags are changed.
+
+Examples:
+  jira-mgmt versions update 2.3 --date 2026-03-20
+  jira-mgmt versions update 2.3 --name 2.3.0 --description "Search release"
+  jira-mgmt versions update 2.1 --archive`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateVersionDates(versionStart, versionDate); err != nil {
+			return err
+		}
+
+		req := &jira.UpdateVersionRequest{}
+		flags := cmd.Flags()
+		if flags.Changed("name") {
+			req.Name = &versionName
+		}
+		if flags.Changed("description") {
+			req.Description = &versionDescription
+		}
+		if flags.Changed("start") {
+			req.StartDate = &versionStart
+		}
+		if flags.Changed("date") {
+			req.ReleaseDate = &versionDate
+		}
+		if flags.Changed("archive") {
+			req.Archived = &versionArchive
+		}
+		if *req == (jira.UpdateVersionRequest{}) {
+			return fmt.Errorf("nothing to update: use --name, --description, --start, --date or --archive")
+		}
+
+		client, version, err := resolveVersion(args[0])
+		if err != nil {
+			return err
+		}
+		updated, err := client.UpdateVersion(version.ID, req)
+		if err != nil {
+			return fmt.Errorf("updating version: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Updated version %s\n", updated.Name)
+		return nil
+	},
+}
+
+var versionsReleaseCmd = &cobra.Command{
+	Use:   "release <VERSION>",
+	Short: "Mark a version released",
+	Long: `Mark a version released, dated today unless --date is given.
+
+Examples:
+  jira-mgmt versions release 2.3
+  jira-mgmt versions release 2.3 --date 2026-03-13`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		date := versionDate
+		if date == "" {
+			date = time.Now().Format("2006-01-02")
+		}
+		if err := validateVersionDates("", date); err != nil {
+			return err
+		}
+
+		client, version, err := resolveVersion(args[0])
+		if err != nil {
+			return err
+		}
+		if version.Released {
+			return fmt.Errorf("version %s is already released (%s)", version.Name, version.ReleaseDate)
+		}
+		if _, err := client.ReleaseVersion(version.ID, date); err != nil {
+			return fmt.Errorf("releasing version: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Released version %s on %s\n", version.Name, date)
+		return nil
+	},
+}
+
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Release tooling",
+}
+
+var releaseNotesCmd = &cobra.Command{
+	Use:   "notes",
+	Short: "Release notes for a version, grouped by issue type",
+	Long: `Gather the issues whose fix version is --version, group them by issue type
+and render Markdown (default) or ADF JSON. Subtasks are left out unless
+--include-subtasks is set.
+
+--publish comment posts the notes as a comment on --issue; --publish
+description stores the Markdown as the version description.
+
+Examples:
+  jira-mgmt release notes --version 2.3
+  jira-mgmt release notes --version 2.3 --render adf
+  jira-mgmt release notes --version 2.3 --publish comment --issue PROJ-500
+  jira-mgmt release notes --version 2.3 --publish description`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if notesVersion == "" {
+			return fmt.Errorf("--version is required")
+		}
+		render := strings.ToLower(notesRender)
+		if render != "markdown" && render != "adf" {
+			return fmt.Errorf("invalid --render %q: use markdown or adf", notesRender)
+		}
+		switch notesPublish {
+		case "", "description":
+		case "comment":
+			if notesIssue == "" {
+				return fmt.Errorf("--publish comment requires --issue")
+			}
+		default:
+			return fmt.Errorf("invalid --publish %q: use comment or description", notesPublish)
+		}
+
+		client, version, err := resolveVersion(notesVersion)
+		if err != nil {
+			return err
+		}
+
+		jql := fmt.Sprintf("project = %s AND fixVersion = %s", flagProject, version.ID)
+		if !notesIncludeSubtasks {
+			jql += " AND issuetype not in subTaskIssueTypes()"
+		}
+		issues, err := client.SearchAll(jql+" ORDER BY key ASC", []string{"summary", "issuetype"})
+		if err != nil {
+			return fmt.Errorf("searching issues: %w", err)
+		}
+
+		doc := release.Notes(*version, issues, client.BaseURL())
+		markdown := doc.Markdown()
+
+		switch notesPublish {
+		case "comment":
+			if _, err := client.AddComment(notesIssue, doc); err != nil {
+				return fmt.Errorf("publishing notes: %w", err)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Published release notes on %s\n", notesIssue)
+		case "description":
+			if _, err := client.UpdateVersion(version.ID, &jira.UpdateVersionRequest{Description: &markdown}); err != nil {
+				return fmt.Errorf("publishing notes: %w", err)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Published release notes as the description of %s\n", version.Name)
+		}
+
+		out := cmd.OutOrStdout()
+		if render == "adf" {
+			enc := json.NewEncoder(out)
+			enc.SetIndent("", "  ")
+			return enc.Encode(doc)
+		}
+		fmt.Fprintln(out, markdown)
+		return nil
+	},
+}
+
+func init() {
+	versionsListCmd.Flags().BoolVar(&versionAll, "all", false, "Include archived versions")
+
+	versionsCreateCmd.Flags().StringVar(&versionName, "name", "", "Version name (required)")
+	versionsCreateCmd.Flags().StringVar(&versionDescription, "description", "", "Version description")
+	versionsCreateCmd.Flags().StringVar(&versionStart, "start", "", "Start date")
+	versionsCreateCmd.Flags().StringVar(&versionDate, "date", "", "Release date")
+
+	versionsUpdateCmd.Flags().StringVar(&versionName, "name", "", "New name")
+	versionsUpdateCmd.Flags().StringVar(&versionDescription, "description", "", "New description")
+	versionsUpdateCmd.Flags().StringVar(&versionStart, "start", "", "New start date")
+	versionsUpdateCmd.Flags().StringVar(&versionDate, "date", "", "New release date")
+	versionsUpdateCmd.Flags().BoolVar(&versionArchive, "archive", false, "Archive (or --archive=false to unarchive)")
+
+	versionsReleaseCmd.Flags().StringVar(&versionDate, "date", "", "Release date (default: today)")
+
+	releaseNotesCmd.Flags().StringVar(&notesVersion, "version", "", "Version name or ID (required)")
+	releaseNotesCmd.Flags().StringVar(&notesRender, "render", "markdown", "Output: markdown or adf")
+	releaseNotesCmd.Flags().StringVar(&notesPublish, "publish", "", "Also publish as a comment or the version description")
+	releaseNotesCmd.Flags().StringVar(&notesIssue, "issue", "", "Issue to comment on with --publish comment")
+	releaseNotesCmd.Flags().BoolVar(&notesIncludeSubtasks, "include-subtasks", false, "Include subtasks")
+
+	versionsCmd.AddCommand(versionsListCmd, versionsCreateCmd, versionsUpdateCmd, versionsReleaseCmd)
+	releaseCmd.AddCommand(releaseNotesCmd)
+	rootCmd.AddCommand(versionsCmd, releaseCmd)
+}
+
+// resolveVersion finds a version of the active project by name or ID.
+func resolveVersion(nameOrID string) (*jira.Client, *jira.Version, error) {
+	if flagProject == "" {
+		return nil, nil, fmt.Errorf("no project configured: use --project")
+	}
+	client, err := buildJiraClientFromConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	version, err := client.FindVersion(flagProject, nameOrID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, version, nil
+}
+
+func validateVersionDates(dates ...string) error {
+	for _, d := range dates {
+		if d == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			return fmt.Errorf("invalid date %q: use 2026-03-13", d)
+		}
+	}
+	return nil
+}
+
+func printVersions(out io.Writer, versions []jira.Version) {
+	fmt.Fprintf(out, "%-8s %-20s %-10s %-10s %s\n", "ID", "Name", "Release", "State", "Description")
+	for _, v := range versions {
+		state := "unreleased"
+		switch {
+		case v.Archived:
+			state = "archived"
+		case v.Released:
+			state = "released"
+		case v.Overdue:
+			state = "overdue"
+		}
+		fmt.Fprintf(out, "%-8s %-20s %-10s %-10s %s\n", v.ID, v.Name, valueOrNone(v.ReleaseDate), state, v.Description)
+	}
+}
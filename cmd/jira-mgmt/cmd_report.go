@@ -0,0 +1,302 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/relux-works/skill-jira-management/internal/config"
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/relux-works/skill-jira-management/internal/query"
+	"github.com/relux-works/skill-jira-management/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportSprintID    int
+	reportPointsField string
+	reportLast        int
+	reportJQL         string
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Agile reports built from issue changelogs",
+	Long: `Agile reports built by replaying issue changelogs. Output is JSON by
+default; use --format text for a table.
+
+Story points are read from --points-field, else the field aliased as
+story_points, sp or points, else the "Story Points" or "Story point estimate"
+field.`,
+}
+
+var reportSprintCmd = &cobra.Command{
+	Use:   "sprint",
+	Short: "Committed vs completed points, scope change, burndown and carry-over",
+	Long: `Report one sprint in story points: scope committed at the start, issues
+added and removed afterwards, completed points, carry-over and a daily
+burndown. Defaults to the active sprint of the board.
+
+Examples:
+  jira-mgmt report sprint
+  jira-mgmt report sprint --sprint 42 --format text
+  jira-mgmt report sprint --sprint 42 --points-field customfield_10016`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, reporter, err := buildReporter()
+		if err != nil {
+			return err
+		}
+
+		sprintID := reportSprintID
+		if sprintID == 0 {
+			if flagBoard == 0 {
+				return fmt.Errorf("--sprint is required when no board is configured")
+			}
+			active, err := client.ListSprints(flagBoard, "active")
+			if err != nil {
+				return fmt.Errorf("listing active sprints: %w", err)
+			}
+			if len(active) == 0 {
+				return fmt.Errorf("board %d has no active sprint: use --sprint", flagBoard)
+			}
+			sprintID = active[0].ID
+		}
+
+		result, err := reporter.Sprint(sprintID)
+		if err != nil {
+			return fmt.Errorf("building sprint report: %w", err)
+		}
+		return writeReport(cmd.OutOrStdout(), result, func(out io.Writer) { printSprintReport(out, result) })
+	},
+}
+
+var reportVelocityCmd = &cobra.Command{
+	Use:   "velocity",
+	Short: "Committed vs completed points across the last closed sprints",
+	Long: `Summarize the last closed sprints of the board, oldest first.
+
+Examples:
+  jira-mgmt report velocity --board 3
+  jira-mgmt report velocity --board 3 --last 10 --format text`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagBoard == 0 {
+			return fmt.Errorf("no board configured: use --board or jira-mgmt config set board <ID>")
+		}
+		if reportLast <= 0 {
+			return fmt.Errorf("--last must be positive")
+		}
+
+		_, reporter, err := buildReporter()
+		if err != nil {
+			return err
+		}
+
+		result, err := reporter.Velocity(flagBoard, reportLast)
+		if err != nil {
+			return fmt.Errorf("building velocity report: %w", err)
+		}
+		return writeReport(cmd.OutOrStdout(), result, func(out io.Writer) { printVelocityReport(out, result) })
+	},
+}
+
+var reportFlowCmd = &cobra.Command{
+	Use:   "flow",
+	Short: "Lead time, cycle time, time in status and weekly throughput",
+	Long: `Replay the status history of every issue a JQL query matches. Statuses are
+mapped to categories with each project's workflow statuses.
+
+Lead time runs from creation to done, cycle time from the first move into an
+In Progress status to done; percentiles are p50, p85 and p95 in days.
+Throughput counts issues done per ISO week. --format csv prints one row per
+issue.
+
+Examples:
+  jira-mgmt report flow --jql "project = PROJ AND resolved >= -30d"
+  jira-mgmt report flow --jql "project = PROJ AND sprint in closedSprints()" --format text
+  jira-mgmt report flow --jql "project = PROJ AND resolved >= -90d" --format csv > flow.csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if strings.TrimSpace(reportJQL) == "" {
+			return fmt.Errorf("--jql is required")
+		}
+
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+
+		result, err := report.New(client, jira.Field{}).Flow(reportJQL)
+		if err != nil {
+			return fmt.Errorf("building flow report: %w", err)
+		}
+		if flagFormat == "csv" {
+			return writeFlowCSV(cmd.OutOrStdout(), result)
+		}
+		return writeReport(cmd.OutOrStdout(), result, func(out io.Writer) { printFlowReport(out, result) })
+	},
+}
+
+func init() {
+	reportSprintCmd.Flags().IntVar(&reportSprintID, "sprint", 0, "Sprint ID (default: the board's active sprint)")
+	reportVelocityCmd.Flags().IntVar(&reportLast, "last", 6, "Number of closed sprints")
+	for _, c := range []*cobra.Command{reportSprintCmd, reportVelocityCmd} {
+		c.Flags().StringVar(&reportPointsField, "points-field", "", "Story points field ID, name or alias")
+	}
+
+	reportFlowCmd.Flags().StringVar(&reportJQL, "jql", "", "JQL selecting the issues (required)")
+
+	reportCmd.AddCommand(reportSprintCmd, reportVelocityCmd, reportFlowCmd)
+	rootCmd.AddCommand(reportCmd)
+}
+
+// buildReporter connects to Jira and resolves the story points field.
+func buildReporter() (*jira.Client, *report.Reporter, error) {
+	client, err := buildJiraClientFromConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	catalog, err := loadFieldCatalog(client, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading field catalog: %w", err)
+	}
+	points, err := report.PointsField(catalog, loadConfigOrDefault().FieldAliases, reportPointsField)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, report.New(client, points), nil
+}
+
+// loadFieldCatalog returns the instance's fields, from the cache when fresh.
+func loadFieldCatalog(client *jira.Client, refresh bool) ([]jira.Field, error) {
+	cachePath, err := config.FieldCatalogPath(client.BaseURL())
+	if err != nil {
+		cachePath = ""
+	}
+	ttl := query.DefaultFieldCatalogTTL
+	if refresh {
+		ttl = 0
+	}
+	return query.LoadFieldCatalog(client, cachePath, ttl)
+}
+
+// writeReport encodes v as indented JSON, or calls text for --format text.
+func writeReport(out io.Writer, v any, text func(io.Writer)) error {
+	if flagFormat == "text" {
+		text(out)
+		return nil
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func printSprintReport(out io.Writer, r *report.SprintReport) {
+	s := r.Sprint
+	fmt.Fprintf(out, "Sprint %d: %s (%s, %s – %s)\n", s.ID, s.Name, s.State, reportDate(s.Start), reportDate(s.End))
+	if s.Goal != "" {
+		fmt.Fprintf(out, "Goal: %s\n", s.Goal)
+	}
+	fmt.Fprintf(out, "Points field: %s\n\n", r.PointsField)
+
+	fmt.Fprintf(out, "  %-12s %6s %8s\n", "", "Issues", "Points")
+	for _, row := range []struct {
+		label string
+		total report.ScopeTotal
+	}{
+		{"Committed", r.Committed},
+		{"Added", r.Added},
+		{"Removed", r.Removed},
+		{"Completed", r.Completed},
+		{"Carry-over", r.CarryOver},
+	} {
+		fmt.Fprintf(out, "  %-12s %6d %8.1f\n", row.label, row.total.Issues, row.total.Points)
+	}
+	fmt.Fprintf(out, "  Completion: %.1f%%\n", r.CompletionRate)
+	for _, row := range []struct {
+		label string
+		keys  []string
+	}{{"Added", r.Added.Keys}, {"Removed", r.Removed.Keys}, {"Carry-over", r.CarryOver.Keys}} {
+		if len(row.keys) > 0 {
+			fmt.Fprintf(out, "  %s: %s\n", row.label, strings.Join(row.keys, ", "))
+		}
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Burndown")
+	fmt.Fprintf(out, "  %-10s %9s %6s\n", "Date", "Remaining", "Ideal")
+	for _, day := range r.Burndown {
+		fmt.Fprintf(out, "  %-10s %9.1f %6.1f\n", day.Date, day.Remaining, day.Ideal)
+	}
+	printReportWarnings(out, r.Warnings)
+}
+
+func printVelocityReport(out io.Writer, r *report.VelocityReport) {
+	fmt.Fprintf(out, "Velocity: board %d, %d closed sprint(s) (%s)\n\n", r.Board, len(r.Sprints), r.PointsField)
+	fmt.Fprintf(out, "  %-24s %-10s %9s %9s %6s\n", "Sprint", "End", "Committed", "Completed", "Rate")
+	for _, s := range r.Sprints {
+		fmt.Fprintf(out, "  %-24s %-10s %9.1f %9.1f %5.1f%%\n", s.Name, s.End, s.Committed, s.Completed, s.CompletionRate)
+	}
+	fmt.Fprintf(out, "  %-24s %-10s %9.1f %9.1f\n", "Average", "", r.AverageCommitted, r.AverageCompleted)
+	printReportWarnings(out, r.Warnings)
+}
+
+func printReportWarnings(out io.Writer, warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+	fmt.Fprintln(out)
+	for _, w := range warnings {
+		fmt.Fprintf(out, "warning: %s\n", w)
+	}
+}
+
+// reportDate trims an RFC 3339 timestamp to its date.
+func reportDate(ts string) string {
+	date, _, _ := strings.Cut(ts, "T")
+	return date
+}
+
+func printFlowReport(out io.Writer, r *report.FlowReport) {
+	fmt.Fprintf(out, "Flow: %d issue(s), %d done\n", r.Issues, r.Completed)
+	fmt.Fprintf(out, "JQL: %s\n\n", r.JQL)
+
+	fmt.Fprintf(out, "  %-24s %5s %6s %6s %6s %6s %6s\n", "Days", "Count", "Mean", "p50", "p85", "p95", "Max")
+	printDistribution(out, "Lead time", r.LeadTime)
+	printDistribution(out, "Cycle time", r.CycleTime)
+	for _, s := range r.TimeInStatus {
+		printDistribution(out, "  "+s.Status, s.Distribution)
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Throughput")
+	for _, w := range r.Throughput {
+		fmt.Fprintf(out, "  %-8s %s %4d\n", w.Week, w.Start, w.Completed)
+	}
+}
+
+func printDistribution(out io.Writer, label string, d report.Distribution) {
+	fmt.Fprintf(out, "  %-24s %5d %6.1f %6.1f %6.1f %6.1f %6.1f\n", label, d.Count, d.Mean, d.P50, d.P85, d.P95, d.Max)
+}
+
+// writeFlowCSV writes one row per issue, for spreadsheets.
+func writeFlowCSV(out io.Writer, r *report.FlowReport) error {
+	w := csv.NewWriter(out)
+	w.Write([]string{"key", "type", "status", "created", "started", "done", "lead_days", "cycle_days"})
+	for _, item := range r.Items {
+		w.Write([]string{item.Key, item.Type, item.Status, item.Created, item.Started, item.Done, formatDays(item.LeadDays), formatDays(item.CycleDays)})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func formatDays(d *float64) string {
+	if d == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*d, 'f', 1, 64)
+}
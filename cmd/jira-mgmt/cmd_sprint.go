@@ -0,0 +1,451 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sprintName      string
+	sprintGoal      string
+	sprintStart     string
+	sprintEnd       string
+	sprintDuration  string
+	sprintCarryOver string
+
+	rankBefore string
+	rankAfter  string
+)
+
+var sprintCmd = &cobra.Command{
+	Use:   "sprint",
+	Short: "Manage sprints on the configured board",
+	Long: `Create, start, update and close sprints, and move issues between sprints
+and the backlog. Sprints are created on --board (or the configured board).
+
+Dates accept 2026-01-31, 2026-01-31T10:00 or RFC 3339; --duration accepts
+days or weeks (10d, 2w) counted from the start date.`,
+}
+
+var sprintCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a future sprint",
+	Long: `Create a future sprint on the board.
+
+Examples:
+  jira-mgmt sprint create --name "Sprint 42"
+  jira-mgmt sprint create --name "Sprint 42" --start 2026-03-02 --duration 2w --goal "Ship search"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if sprintName == "" {
+			return fmt.Errorf("--name is required")
+		}
+		if flagBoard == 0 {
+			return fmt.Errorf("no board configured: use --board or jira-mgmt config set board <ID>")
+		}
+
+		start, end, err := sprintDates(sprintStart, sprintEnd, sprintDuration, time.Time{})
+		if err != nil {
+			return err
+		}
+
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+
+		req := &jira.CreateSprintRequest{Name: sprintName, OriginBoardID: flagBoard, Goal: sprintGoal}
+		if !start.IsZero() {
+			req.StartDate = &start
+		}
+		if !end.IsZero() {
+			req.EndDate = &end
+		}
+		sprint, err := client.CreateSprint(req)
+		if err != nil {
+			return fmt.Errorf("creating sprint: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Created sprint %d: %s\n", sprint.ID, sprint.Name)
+		return nil
+	},
+}
+
+var sprintStartCmd = &cobra.Command{
+	Use:   "start <SPRINT-ID>",
+	Short: "Start a future sprint",
+	Long: `Start a future sprint. The start date defaults to now and the end date to
+the sprint's planned end, or --duration (default 2w) after the start.
+
+Examples:
+  jira-mgmt sprint start 42
+  jira-mgmt sprint start 42 --duration 1w
+  jira-mgmt sprint start 42 --start 2026-03-02 --end 2026-03-13`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sprintID, err := parseSprintID(args[0])
+		if err != nil {
+			return err
+		}
+
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+
+		start, end, err := sprintDates(sprintStart, sprintEnd, sprintDuration, time.Now())
+		if err != nil {
+			return err
+		}
+		if sprintEnd == "" && sprintDuration == "" {
+			sprint, err := client.GetSprint(sprintID)
+			if err != nil {
+				return fmt.Errorf("getting sprint: %w", err)
+			}
+			if sprint.EndDate != nil && sprint.EndDate.After(start) {
+				end = *sprint.EndDate
+			} else {
+				end, _ = addDuration(start, "2w")
+			}
+		}
+
+		sprint, err := client.StartSprint(sprintID, start, end)
+		if err != nil {
+			return fmt.Errorf("starting sprint: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Started sprint %d: %s (%s - %s)\n",
+			sprint.ID, sprint.Name, start.Format("2006-01-02"), end.Format("2006-01-02"))
+		return nil
+	},
+}
+
+var sprintUpdateCmd = &cobra.Command{
+	Use:   "update <SPRINT-ID>",
+	Short: "Update a sprint's name, goal or dates",
+	Long: `Update a sprint. Only the given flags are changed.
+
+Examples:
+  jira-mgmt sprint update 42 --goal "Ship search"
+  jira-mgmt sprint update 42 --name "Sprint 42b" --end 2026-03-16`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sprintID, err := parseSprintID(args[0])
+		if err != nil {
+			return err
+		}
+
+		req := &jira.UpdateSprintRequest{Name: sprintName}
+		if cmd.Flags().Changed("goal") {
+			req.Goal = &sprintGoal
+		}
+		if sprintStart != "" {
+			start, err := parseSprintDate(sprintStart)
+			if err != nil {
+				return err
+			}
+			req.StartDate = &start
+		}
+		if sprintEnd != "" {
+			end, err := parseSprintDate(sprintEnd)
+			if err != nil {
+				return err
+			}
+			req.EndDate = &end
+		}
+		if req.Name == "" && req.Goal == nil && req.StartDate == nil && req.EndDate == nil {
+			return fmt.Errorf("nothing to update: use --name, --goal, --start or --end")
+		}
+
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+
+		sprint, err := client.UpdateSprint(sprintID, req)
+		if err != nil {
+			return fmt.Errorf("updating sprint: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Updated sprint %d: %s\n", sprint.ID, sprint.Name)
+		return nil
+	},
+}
+
+var sprintCloseCmd = &cobra.Command{
+	Use:   "close <SPRINT-ID>",
+	Short: "Close an active sprint",
+	Long: `Close an active sprint. Unfinished issues (status category not Done) go to
+the backlog by default; --carry-over next moves them to the board's next
+future sprint, or --carry-over <SPRINT-ID> to a given sprint.
+
+Examples:
+  jira-mgmt sprint close 42
+  jira-mgmt sprint close 42 --carry-over next
+  jira-mgmt sprint close 42 --carry-over 44`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sprintID, err := parseSprintID(args[0])
+		if err != nil {
+			return err
+		}
+
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+
+		sprint, err := client.GetSprint(sprintID)
+		if err != nil {
+			return fmt.Errorf("getting sprint: %w", err)
+		}
+
+		target, err := carryOverTarget(client, sprint, sprintCarryOver)
+		if err != nil {
+			return err
+		}
+
+		unfinished, err := client.ListSprintIssues(sprintID, "statusCategory != Done", []string{"status"})
+		if err != nil {
+			return fmt.Errorf("listing unfinished issues: %w", err)
+		}
+		keys := make([]string, len(unfinished))
+		for i, issue := range unfinished {
+			keys[i] = issue.Key
+		}
+
+		if _, err := client.CloseSprint(sprintID); err != nil {
+			return fmt.Errorf("closing sprint: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Closed sprint %d: %s\n", sprint.ID, sprint.Name)
+		if len(keys) == 0 {
+			return nil
+		}
+		if target == nil {
+			fmt.Fprintf(out, "%d unfinished issue(s) moved to backlog\n", len(keys))
+			return nil
+		}
+		if err := client.MoveIssuesToSprint(target.ID, keys); err != nil {
+			return fmt.Errorf("carrying over unfinished issues: %w", err)
+		}
+		fmt.Fprintf(out, "%d unfinished issue(s) moved to sprint %d: %s\n", len(keys), target.ID, target.Name)
+		return nil
+	},
+}
+
+var sprintMoveCmd = &cobra.Command{
+	Use:   "move <SPRINT-ID|backlog> <ISSUE-KEY>...",
+	Short: "Move issues into a sprint or back to the backlog",
+	Long: `Move issues into an open sprint, or remove them from their sprint.
+
+Examples:
+  jira-mgmt sprint move 42 PROJ-1 PROJ-2
+  jira-mgmt sprint move backlog PROJ-3`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, keys := args[0], args[1:]
+
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if strings.EqualFold(target, "backlog") {
+			if err := client.MoveIssuesToBacklog(keys); err != nil {
+				return fmt.Errorf("moving issues to backlog: %w", err)
+			}
+			fmt.Fprintf(out, "%s -> backlog\n", strings.Join(keys, ", "))
+			return nil
+		}
+
+		sprintID, err := parseSprintID(target)
+		if err != nil {
+			return err
+		}
+		if err := client.MoveIssuesToSprint(sprintID, keys); err != nil {
+			return fmt.Errorf("moving issues to sprint: %w", err)
+		}
+		fmt.Fprintf(out, "%s -> sprint %d\n", strings.Join(keys, ", "), sprintID)
+		return nil
+	},
+}
+
+var rankCmd = &cobra.Command{
+	Use:   "rank <ISSUE-KEY>...",
+	Short: "Rank issues before or after another issue",
+	Long: `Rank issues, in the given order, immediately before or after another issue.
+
+Examples:
+  jira-mgmt rank PROJ-5 --before PROJ-1
+  jira-mgmt rank PROJ-5 PROJ-6 --after PROJ-2`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if (rankBefore == "") == (rankAfter == "") {
+			return fmt.Errorf("exactly one of --before or --after is required")
+		}
+
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+
+		if err := client.RankIssues(args, rankBefore, rankAfter); err != nil {
+			return fmt.Errorf("ranking issues: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+		if rankBefore != "" {
+			fmt.Fprintf(out, "%s ranked before %s\n", strings.Join(args, ", "), rankBefore)
+		} else {
+			fmt.Fprintf(out, "%s ranked after %s\n", strings.Join(args, ", "), rankAfter)
+		}
+		return nil
+	},
+}
+
+func init() {
+	sprintCreateCmd.Flags().StringVar(&sprintName, "name", "", "Sprint name (required)")
+	sprintCreateCmd.Flags().StringVar(&sprintGoal, "goal", "", "Sprint goal")
+	sprintCreateCmd.Flags().StringVar(&sprintStart, "start", "", "Planned start date")
+	sprintCreateCmd.Flags().StringVar(&sprintEnd, "end", "", "Planned end date")
+	sprintCreateCmd.Flags().StringVar(&sprintDuration, "duration", "", "Length from --start, e.g. 2w or 10d")
+
+	sprintStartCmd.Flags().StringVar(&sprintStart, "start", "", "Start date (default: now)")
+	sprintStartCmd.Flags().StringVar(&sprintEnd, "end", "", "End date (default: planned end, or start + 2w)")
+	sprintStartCmd.Flags().StringVar(&sprintDuration, "duration", "", "Length from the start, e.g. 2w or 10d")
+
+	sprintUpdateCmd.Flags().StringVar(&sprintName, "name", "", "New sprint name")
+	sprintUpdateCmd.Flags().StringVar(&sprintGoal, "goal", "", "New sprint goal (empty clears it)")
+	sprintUpdateCmd.Flags().StringVar(&sprintStart, "start", "", "New start date")
+	sprintUpdateCmd.Flags().StringVar(&sprintEnd, "end", "", "New end date")
+
+	sprintCloseCmd.Flags().StringVar(&sprintCarryOver, "carry-over", "backlog", "Where unfinished issues go: backlog, next or a sprint ID")
+
+	rankCmd.Flags().StringVar(&rankBefore, "before", "", "Rank before this issue")
+	rankCmd.Flags().StringVar(&rankAfter, "after", "", "Rank after this issue")
+
+	sprintCmd.AddCommand(sprintCreateCmd, sprintStartCmd, sprintUpdateCmd, sprintCloseCmd, sprintMoveCmd)
+	rootCmd.AddCommand(sprintCmd)
+	rootCmd.AddCommand(rankCmd)
+}
+
+func parseSprintID(value string) (int, error) {
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid sprint ID: %s", value)
+	}
+	return id, nil
+}
+
+// parseSprintDate accepts a local date, a local date and time, or RFC 3339.
+func parseSprintDate(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "2006-01-02T15:04", time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, strings.TrimSpace(value), time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: use 2026-01-31, 2026-01-31T10:00 or RFC 3339", value)
+}
+
+// addDuration adds a day or week count (10d, 2w) to start.
+func addDuration(start time.Time, duration string) (time.Time, error) {
+	duration = strings.ToLower(strings.TrimSpace(duration))
+	if len(duration) >= 2 {
+		n, err := strconv.Atoi(duration[:len(duration)-1])
+		if err == nil && n > 0 {
+			switch duration[len(duration)-1] {
+			case 'd':
+				return start.AddDate(0, 0, n), nil
+			case 'w':
+				return start.AddDate(0, 0, 7*n), nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid duration %q: use days or weeks, e.g. 10d or 2w", duration)
+}
+
+// sprintDates resolves --start, --end and --duration. defaultStart is used when
+// --start is empty; a zero defaultStart leaves the dates unset.
+func sprintDates(startValue, endValue, duration string, defaultStart time.Time) (time.Time, time.Time, error) {
+	if endValue != "" && duration != "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("use either --end or --duration, not both")
+	}
+
+	start := defaultStart
+	if startValue != "" {
+		t, err := parseSprintDate(startValue)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		start = t
+	}
+
+	var end time.Time
+	switch {
+	case endValue != "":
+		t, err := parseSprintDate(endValue)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		end = t
+	case duration != "":
+		if start.IsZero() {
+			return time.Time{}, time.Time{}, fmt.Errorf("--duration requires --start")
+		}
+		t, err := addDuration(start, duration)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		end = t
+	}
+
+	if !start.IsZero() && !end.IsZero() && !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end date must be after start date")
+	}
+	return start, end, nil
+}
+
+// carryOverTarget resolves --carry-over: nil for backlog, the board's first
+// future sprint for next, or the given sprint.
+func carryOverTarget(client *jira.Client, sprint *jira.Sprint, value string) (*jira.Sprint, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "backlog":
+		return nil, nil
+	case "next":
+		boardID := sprint.OriginBoardID
+		if boardID == 0 {
+			boardID = flagBoard
+		}
+		if boardID == 0 {
+			return nil, fmt.Errorf("cannot find the next sprint: no board configured")
+		}
+		future, err := client.ListSprints(boardID, "future")
+		if err != nil {
+			return nil, fmt.Errorf("listing future sprints: %w", err)
+		}
+		for _, s := range future {
+			if s.ID != sprint.ID {
+				return &s, nil
+			}
+		}
+		return nil, fmt.Errorf("board %d has no future sprint to carry over to; create one with jira-mgmt sprint create", boardID)
+	}
+
+	id, err := parseSprintID(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --carry-over %q: use backlog, next or a sprint ID", value)
+	}
+	target, err := client.GetSprint(id)
+	if err != nil {
+		return nil, fmt.Errorf("getting carry-over sprint: %w", err)
+	}
+	return target, nil
+}
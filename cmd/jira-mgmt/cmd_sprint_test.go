@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSprintDates_Duration(t *testing.T) {
+	start, end, err := sprintDates("2026-03-02", "", "2w", time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := start.Format("2006-01-02"); got != "2026-03-02" {
+		t.Fatalf("start = %s", got)
+	}
+	if got := end.Format("2006-01-02"); got != "2026-03-16" {
+		t.Fatalf("end = %s, want 2026-03-16", got)
+	}
+}
+
+func TestSprintDates_DefaultStartAndEnd(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	start, end, err := sprintDates("", "2026-03-13", "", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !start.Equal(now) || end.Format("2006-01-02") != "2026-03-13" {
+		t.Fatalf("start = %v, end = %v", start, end)
+	}
+}
+
+func TestSprintDates_Errors(t *testing.T) {
+	cases := []struct{ start, end, duration string }{
+		{"2026-03-02", "2026-03-13", "2w"},
+		{"", "", "2w"},
+		{"2026-03-02", "", "2x"},
+		{"2026-03-13", "2026-03-02", ""},
+		{"03/02/2026", "", ""},
+	}
+	for _, c := range cases {
+		if _, _, err := sprintDates(c.start, c.end, c.duration, time.Time{}); err == nil {
+			t.Errorf("sprintDates(%q, %q, %q): expected error", c.start, c.end, c.duration)
+		}
+	}
+}
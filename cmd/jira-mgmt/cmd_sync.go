@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/relux-works/skill-jira-management/internal/query"
+	"github.com/relux-works/skill-jira-management/internal/taskboard"
+	"github.com/spf13/cobra"
+)
+
+var (
+	syncDir    string
+	syncJQL    string
+	syncPrefer string
+	syncDryRun bool
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Synchronize Jira with local tools",
+}
+
+var syncTaskBoardCmd = &cobra.Command{
+	Use:   "task-board",
+	Short: "Two-way sync between Jira and the local task board directory",
+	Long: `Mirror the issues of the active project (or of the board, with --board) into
+the local task board configured in task-board.config.json, and push local
+changes back. Epics become EPIC elements, subtasks TASK elements and other
+issues STORY elements, named <TYPE>-<KEY>_<slug> and nested under their parent.
+
+Pulled from Jira: title, description, status, assignee, dates and "Blocks"
+links. Pushed to Jira: a changed Status (as a transition to the status of that
+name, e.g. in-progress -> "In Progress") and text added to Notes (as a
+comment). Scope, acceptance criteria and the checklist stay local.
+
+Changes are detected against the last sync, recorded in .jira-sync.json in the
+board directory. When an element and its issue (by its updated field) both
+changed, the element is left alone and a conflict is reported; rerun with
+--prefer jira or --prefer local to resolve it.
+
+Examples:
+  jira-mgmt sync task-board --dry-run --format text
+  jira-mgmt sync task-board --jql "sprint in openSprints()"
+  jira-mgmt sync task-board --board 9 --prefer jira`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if syncPrefer != "" && syncPrefer != taskboard.PreferJira && syncPrefer != taskboard.PreferLocal {
+			return fmt.Errorf("invalid --prefer %q: use jira or local", syncPrefer)
+		}
+		board, err := taskboard.Open(syncDir)
+		if err != nil {
+			return err
+		}
+		client, err := buildJiraClientFromConfig()
+		if err != nil {
+			return err
+		}
+
+		jql, err := syncScopeJQL(cmd, client)
+		if err != nil {
+			return err
+		}
+		if syncJQL != "" {
+			jql = fmt.Sprintf("(%s) AND (%s)", jql, syncJQL)
+		}
+
+		syncer := &taskboard.Syncer{Client: client, Board: board, Prefer: syncPrefer, DryRun: syncDryRun}
+		if !client.IsCloud() {
+			// Server/DC does not report the epic hierarchy level.
+			catalog, err := loadFieldCatalog(client, false)
+			if err != nil {
+				return fmt.Errorf("loading field catalog: %w", err)
+			}
+			syncer.EpicNameField = jira.EpicNameFieldID(catalog)
+		}
+		issues, err := client.SearchAll(jql+" ORDER BY key", syncer.Fields())
+		if err != nil {
+			return fmt.Errorf("searching issues: %w", err)
+		}
+		res := syncer.Sync(issues)
+		if err := writeReport(cmd.OutOrStdout(), res, func(out io.Writer) { printSyncResult(out, res) }); err != nil {
+			return err
+		}
+		if res.Failed > 0 {
+			return fmt.Errorf("%d issue(s) failed to sync", res.Failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	syncTaskBoardCmd.Flags().StringVar(&syncDir, "dir", ".", "Directory to look for task-board.config.json from (and its parents)")
+	syncTaskBoardCmd.Flags().StringVar(&syncJQL, "jql", "", "Narrow the synced issues with extra JQL")
+	syncTaskBoardCmd.Flags().StringVar(&syncPrefer, "prefer", "", "Resolve conflicts: jira (overwrite local) or local (push anyway)")
+	syncTaskBoardCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Show what would change without writing the board or Jira")
+
+	syncCmd.AddCommand(syncTaskBoardCmd)
+	rootCmd.AddCommand(syncCmd)
+}
+
+// syncScopeJQL selects the board's issues when --board is given or no project
+// is active, the active project's otherwise.
+func syncScopeJQL(cmd *cobra.Command, client *jira.Client) (string, error) {
+	if flagBoard != 0 && (cmd.Flags().Changed("board") || flagProject == "") {
+		cfg, err := client.GetBoardConfiguration(flagBoard)
+		if err != nil {
+			return "", err
+		}
+		scope, err := query.BoardScopeJQL(client, cfg)
+		if err == nil && scope == "" {
+			err = fmt.Errorf("board %d has no active sprint to sync", flagBoard)
+		}
+		return scope, err
+	}
+	if flagProject == "" {
+		return "", fmt.Errorf("no project configured: use --project or --board")
+	}
+	return fmt.Sprintf("project = %q", flagProject), nil
+}
+
+// printSyncResult prints what changed; unchanged issues are left out.
+func printSyncResult(out io.Writer, res *taskboard.Result) {
+	for _, e := range res.Items {
+		if e.Action == taskboard.ActionUnchanged {
+			continue
+		}
+		fmt.Fprintf(out, "%-9s %s (%s)", e.Action, e.Key, e.ID)
+		if e.Detail != "" {
+			fmt.Fprintf(out, ": %s", e.Detail)
+		}
+		if e.Error != "" {
+			fmt.Fprintf(out, " [%s]", e.Error)
+		}
+		fmt.Fprintln(out)
+	}
+	prefix := ""
+	if res.DryRun {
+		prefix = "Dry run: "
+	}
+	fmt.Fprintf(out, "\n%s%d created, %d pulled, %d pushed, %d conflict(s), %d failed in %s\n",
+		prefix, res.Created, res.Pulled, res.Pushed, res.Conflicts, res.Failed, res.BoardDir)
+}
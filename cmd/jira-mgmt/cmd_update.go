@@ -10,24 +10,27 @@ import (
 var (
 	updateSummary     string
 	updateDescription string
+	updateAssignee    string
 )
 
 var updateCmd = &cobra.Command{
 	Use:   "update ISSUE-KEY",
-	Short: "Update issue fields (summary, description)",
+	Short: "Update issue fields (summary, description, assignee)",
 	Long: `Update fields on an existing issue.
 
 Examples:
   jira-mgmt update PROJ-123 --summary "New title"
   jira-mgmt update PROJ-123 --description "New description"
-  jira-mgmt update PROJ-123 --summary "New title" --description "New description"`,
+  jira-mgmt update PROJ-123 --summary "New title" --description "New description"
+  jira-mgmt update PROJ-123 --assignee alice@corp.com
+  jira-mgmt update PROJ-123 --assignee none`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		issueKey := args[0]
 		out := cmd.OutOrStdout()
 
-		if updateSummary == "" && updateDescription == "" {
-			return fmt.Errorf("at least one of --summary or --description is required")
+		if updateSummary == "" && updateDescription == "" && updateAssignee == "" {
+			return fmt.Errorf("at least one of --summary, --description or --assignee is required")
 		}
 
 		client, err := buildJiraClientFromConfig()
@@ -48,6 +51,14 @@ Examples:
 			}
 		}
 
+		if updateAssignee != "" {
+			ref, _, err := resolveAssignee(client, updateAssignee, "", issueKey)
+			if err != nil {
+				return err
+			}
+			fields["assignee"] = ref
+		}
+
 		req := &jira.UpdateIssueRequest{Fields: fields}
 		if err := client.UpdateIssue(issueKey, req); err != nil {
 			return fmt.Errorf("updating %s: %w", issueKey, err)
@@ -61,5 +72,6 @@ Examples:
 func init() {
 	updateCmd.Flags().StringVar(&updateSummary, "summary", "", "New issue summary/title")
 	updateCmd.Flags().StringVar(&updateDescription, "description", "", "New issue description")
+	updateCmd.Flags().StringVar(&updateAssignee, "assignee", "", "New assignee: email, display name, username, me or none")
 	rootCmd.AddCommand(updateCmd)
 }
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchUser   string
+	unwatchUser string
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch <ISSUE-KEY>...",
+	Short: "Add a watcher to issues",
+	Long: `Add yourself, or --user, as a watcher of one or more issues. --user takes an
+email, display name, Server/DC username or "me".
+
+Examples:
+  jira-mgmt watch PROJ-1
+  jira-mgmt watch PROJ-1 PROJ-2 --user alice@corp.com`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, user, err := resolveWatcher(watchUser)
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		for _, key := range args {
+			if err := client.AddWatcher(key, user.Ref()); err != nil {
+				return fmt.Errorf("watching %s: %w", key, err)
+			}
+			fmt.Fprintf(out, "%s now watches %s\n", user.DisplayName, key)
+		}
+		return nil
+	},
+}
+
+var unwatchCmd = &cobra.Command{
+	Use:   "unwatch <ISSUE-KEY>...",
+	Short: "Remove a watcher from issues",
+	Long: `Remove yourself, or --user, from the watchers of one or more issues.
+
+Examples:
+  jira-mgmt unwatch PROJ-1
+  jira-mgmt unwatch PROJ-1 --user alice@corp.com`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, user, err := resolveWatcher(unwatchUser)
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		for _, key := range args {
+			if err := client.RemoveWatcher(key, user.Ref()); err != nil {
+				return fmt.Errorf("unwatching %s: %w", key, err)
+			}
+			fmt.Fprintf(out, "%s no longer watches %s\n", user.DisplayName, key)
+		}
+		return nil
+	},
+}
+
+func init() {
+	watchCmd.Flags().StringVar(&watchUser, "user", "me", "Watcher: email, display name, username or me")
+	unwatchCmd.Flags().StringVar(&unwatchUser, "user", "me", "Watcher: email, display name, username or me")
+
+	rootCmd.AddCommand(watchCmd, unwatchCmd)
+}
+
+// resolveWatcher builds a client and resolves who among all users.
+func resolveWatcher(who string) (*jira.Client, *jira.User, error) {
+	client, err := buildJiraClientFromConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := newUserResolver(client).Resolve(who, "", "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving watcher: %w", err)
+	}
+	return client, user, nil
+}
@@ -1,24 +1,70 @@
 package main
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/relux-works/skill-jira-management/internal/config"
 	"github.com/relux-works/skill-jira-management/internal/jira"
 	"github.com/zalando/go-keyring"
+	"golang.org/x/term"
 )
 
-// getCredentialResolver returns the platform-aware credential resolver.
-// Tests can override it.
+// getCredentialResolver returns the platform-aware credential resolver, with the
+// credential helper command from config enabled when set. Tests can override it.
 var getCredentialResolver = func() *config.Resolver {
-	return config.NewResolver(
+	resolver := config.NewResolver(
 		config.Runtime{},
 		config.NewKeychainStore(
 			keyring.Set,
 			keyring.Get,
 			keyring.Delete,
 		),
-	)
+	).WithPassphrasePrompt(promptAuthPassphrase)
+	if cfgMgr, err := config.NewConfigManager(); err == nil {
+		if cfg, err := cfgMgr.GetConfig(); err == nil {
+			ttl, err := cfg.CredentialCacheDuration()
+			if err != nil && cfg.CredentialCommand != "" {
+				fmt.Fprintf(os.Stderr, "warning: %v; credential command output is not cached\n", err)
+			}
+			resolver.WithCredentialCommand(config.CredentialCommand{
+				Command:  cfg.CredentialCommand,
+				CacheTTL: ttl,
+			})
+		}
+	}
+	return resolver
+}
+
+// promptAuthPassphrase asks for the encrypted auth file passphrase. On a terminal
+// it is read without echo; piped input is read as a line. Non-interactive runs
+// without input must set JIRA_MGMT_AUTH_PASSPHRASE instead.
+func promptAuthPassphrase() (string, error) {
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, "Auth file passphrase: ")
+		pass, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		return string(pass), nil
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if line == "" {
+		if err == nil || errors.Is(err, io.EOF) {
+			return "", config.ErrPassphraseRequired
+		}
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
 }
 
 // buildJiraClientFromConfig creates a Jira client from stored config and credentials.
@@ -44,14 +90,37 @@ func buildJiraClientFromConfig() (*jira.Client, error) {
 		return nil, fmt.Errorf("loading credentials: %w (run 'jira-mgmt auth set-access' to configure)", err)
 	}
 
-	client, err := jira.NewClient(jira.Config{
-		BaseURL:            resolved.Credentials.InstanceURL,
-		Email:              resolved.Credentials.Email,
-		Token:              resolved.Credentials.APIToken,
-		InstanceType:       jira.InstanceType(cfg.InstanceType),
-		AuthType:           jira.AuthType(resolved.Credentials.AuthType),
-		InsecureSkipVerify: flagInsecure || cfg.TLSSkipVerify,
-	})
+	return buildJiraClient(cfgMgr, cfg, resolver, resolved)
+}
+
+// buildJiraClient creates a Jira client from already resolved credentials.
+func buildJiraClient(cfgMgr *config.ConfigManager, cfg config.Config, resolver *config.Resolver, resolved config.ResolvedCredentials) (*jira.Client, error) {
+	clientCfg := jira.Config{
+		BaseURL:      resolved.Credentials.InstanceURL,
+		Email:        resolved.Credentials.Email,
+		Token:        resolved.Credentials.APIToken,
+		InstanceType: jira.InstanceType(cfg.InstanceType),
+		AuthType:     jira.AuthType(resolved.Credentials.AuthType),
+	}
+	applyTransportSettings(&clientCfg, cfg)
+	if resolved.Credentials.IsOAuth() {
+		httpClient, err := jira.NewHTTPClient(clientCfg)
+		if err != nil {
+			return nil, err
+		}
+		refresh := oauthTokenRefresher(resolver, resolved, httpClient)
+		if oauthTokenExpired(resolved.Credentials, time.Now()) {
+			token, err := refresh()
+			if err != nil {
+				return nil, fmt.Errorf("refreshing OAuth token: %w (run 'jira-mgmt auth oauth' to sign in again)", err)
+			}
+			clientCfg.Token = token
+		}
+		clientCfg.CloudID = resolved.Credentials.CloudID
+		clientCfg.TokenRefresher = refresh
+	}
+
+	client, err := jira.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -67,3 +136,89 @@ func buildJiraClientFromConfig() (*jira.Client, error) {
 
 	return client, nil
 }
+
+// applyTransportSettings fills TLS, proxy and timeout settings from global flags,
+// falling back to config and then to the standard proxy env vars.
+func applyTransportSettings(clientCfg *jira.Config, cfg config.Config) {
+	clientCfg.InsecureSkipVerify = flagInsecure || cfg.TLSSkipVerify
+	clientCfg.CACertFile = firstNonEmptyValue(flagCACert, cfg.CACertFile)
+	clientCfg.ClientCertFile = firstNonEmptyValue(flagClientCert, cfg.ClientCertFile)
+	clientCfg.ClientKeyFile = firstNonEmptyValue(flagClientKey, cfg.ClientKeyFile)
+	clientCfg.ProxyURL = firstNonEmptyValue(flagProxy, cfg.ProxyURL)
+	clientCfg.NoProxy = firstNonEmptyValue(flagNoProxy, cfg.NoProxy, os.Getenv("NO_PROXY"), os.Getenv("no_proxy"))
+	clientCfg.Timeout = flagTimeout
+	if clientCfg.Timeout <= 0 {
+		clientCfg.Timeout = cfg.TimeoutDuration()
+	}
+}
+
+// loadConfigOrDefault reads the config file, falling back to defaults on any error.
+func loadConfigOrDefault() config.Config {
+	cfgMgr, err := config.NewConfigManager()
+	if err != nil {
+		return config.DefaultConfig()
+	}
+	cfg, err := cfgMgr.GetConfig()
+	if err != nil {
+		return config.DefaultConfig()
+	}
+	return cfg
+}
+
+func firstNonEmptyValue(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// oauthTokenRefresher returns a jira.Config.TokenRefresher that exchanges the stored
+// refresh token and persists the rotated tokens back to the source they came from.
+// Calls are serialized: each refresh rotates the refresh token the next one uses.
+func oauthTokenRefresher(resolver *config.Resolver, resolved config.ResolvedCredentials, httpClient *http.Client) func() (string, error) {
+	var mu sync.Mutex
+	creds := resolved.Credentials
+	return func() (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		oauth := jira.OAuthConfig{
+			ClientID:     creds.ClientID,
+			ClientSecret: creds.ClientSecret,
+			HTTPClient:   httpClient,
+		}
+		token, err := oauth.Refresh(creds.RefreshToken)
+		if err != nil {
+			return "", err
+		}
+
+		creds.APIToken = token.AccessToken
+		creds.RefreshToken = token.RefreshToken
+		if expiry := token.Expiry(time.Now()); !expiry.IsZero() {
+			creds.ExpiresAt = expiry.UTC().Format(time.RFC3339)
+		}
+
+		if resolved.ResolvedFrom != "env" && resolved.Source != config.SourceCommand {
+			if _, err := resolver.SetAccess(resolved.Source, creds); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: could not persist refreshed OAuth token: %v\n", err)
+			}
+		}
+		return token.AccessToken, nil
+	}
+}
+
+// oauthTokenExpired reports whether the stored access token is missing or about to expire.
+func oauthTokenExpired(creds config.Credentials, now time.Time) bool {
+	if creds.APIToken == "" {
+		return true
+	}
+	if creds.ExpiresAt == "" {
+		return false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, creds.ExpiresAt)
+	if err != nil {
+		return false
+	}
+	return now.Add(time.Minute).After(expiresAt)
+}
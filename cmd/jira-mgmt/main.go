@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/relux-works/skill-jira-management/internal/config"
 	"github.com/spf13/cobra"
@@ -22,6 +23,13 @@ var (
 	flagBoard    int
 	flagFormat   string
 	flagInsecure bool
+
+	flagCACert     string
+	flagClientCert string
+	flagClientKey  string
+	flagProxy      string
+	flagNoProxy    string
+	flagTimeout    time.Duration
 )
 
 func main() {
@@ -51,8 +59,14 @@ var versionCmd = &cobra.Command{
 func init() {
 	rootCmd.PersistentFlags().StringVar(&flagProject, "project", "", "Jira project key (overrides config)")
 	rootCmd.PersistentFlags().IntVar(&flagBoard, "board", 0, "Jira board ID (overrides config)")
-	rootCmd.PersistentFlags().StringVar(&flagFormat, "format", "json", "Output format: json or text")
-	rootCmd.PersistentFlags().BoolVar(&flagInsecure, "insecure", false, "Skip TLS certificate verification (for corporate CAs)")
+	rootCmd.PersistentFlags().StringVar(&flagFormat, "format", "json", "Output format: json or text (grep also accepts ndjson, report flow csv)")
+	rootCmd.PersistentFlags().BoolVar(&flagInsecure, "insecure", false, "Skip TLS certificate verification (last resort; prefer --ca-cert)")
+	rootCmd.PersistentFlags().StringVar(&flagCACert, "ca-cert", "", "PEM CA bundle to trust in addition to system roots (overrides config)")
+	rootCmd.PersistentFlags().StringVar(&flagClientCert, "client-cert", "", "PEM client certificate for mTLS (overrides config)")
+	rootCmd.PersistentFlags().StringVar(&flagClientKey, "client-key", "", "PEM client key for mTLS (overrides config)")
+	rootCmd.PersistentFlags().StringVar(&flagProxy, "proxy", "", "HTTP(S) proxy URL (overrides config and HTTPS_PROXY)")
+	rootCmd.PersistentFlags().StringVar(&flagNoProxy, "no-proxy", "", "Comma-separated hosts that bypass the proxy (overrides config and NO_PROXY)")
+	rootCmd.PersistentFlags().DurationVar(&flagTimeout, "timeout", 0, "Per-request timeout, e.g. 60s (default 30s)")
 
 	rootCmd.AddCommand(versionCmd)
 }
@@ -32,6 +32,7 @@ const (
 	SourceAuto      Source = "auto"
 	SourceKeychain  Source = "keychain"
 	SourceEnvOrFile Source = "env_or_file"
+	SourceCommand   Source = "command"
 )
 
 // Credentials holds the authentication details for a Jira instance.
@@ -39,7 +40,19 @@ type Credentials struct {
 	InstanceURL string `json:"instance_url"`
 	Email       string `json:"email,omitempty"` // Required for Basic auth (Cloud), empty for Bearer (Server/DC PAT)
 	APIToken    string `json:"api_token"`
-	AuthType    string `json:"auth_type,omitempty"` // "basic" or "bearer"
+	AuthType    string `json:"auth_type,omitempty"` // "basic", "bearer" or "oauth"
+
+	// OAuth 2.0 (3LO) only. APIToken holds the current access token.
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresAt    string `json:"expires_at,omitempty"` // RFC 3339 access token expiry
+	CloudID      string `json:"cloud_id,omitempty"`
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// IsOAuth reports whether the credentials use OAuth 2.0 (3LO).
+func (c Credentials) IsOAuth() bool {
+	return c.AuthType == "oauth"
 }
 
 // Validate checks that all required credential fields are populated.
@@ -48,6 +61,18 @@ func (c Credentials) Validate() error {
 	if normalized.InstanceURL == "" {
 		return errors.New("instance URL is required")
 	}
+	if normalized.IsOAuth() {
+		if normalized.APIToken == "" && normalized.RefreshToken == "" {
+			return errors.New("access or refresh token is required for oauth")
+		}
+		if normalized.CloudID == "" {
+			return errors.New("cloud ID is required for oauth")
+		}
+		if normalized.ClientID == "" {
+			return errors.New("client ID is required for oauth")
+		}
+		return nil
+	}
 	if normalized.APIToken == "" {
 		return errors.New("API token is required")
 	}
@@ -72,6 +97,10 @@ var ErrInstanceURLRequired = errors.New("instance URL is required")
 
 var errKeychainUnavailable = errors.New("keychain store is not configured")
 
+var errCommandUnavailable = errors.New("credential command is not configured")
+
+var errCommandReadOnly = errors.New("the command source is read-only; store the secret in your credential helper instead")
+
 // KeychainStore implements CredentialStore using the OS keychain via go-keyring.
 type KeychainStore struct {
 	keyringSet    func(service, user, password string) error
@@ -280,6 +309,9 @@ func (f *FileStore) write(cfg authFile) error {
 type Runtime struct {
 	GOOS   string
 	Getenv func(string) string
+
+	// RunCommand runs a credential helper command and returns its stdout.
+	RunCommand func(command string, env []string) ([]byte, error)
 }
 
 type ResolvedCredentials struct {
@@ -290,6 +322,7 @@ type ResolvedCredentials struct {
 	ProfileKey      string
 	KeychainService string
 	KeychainAccount string
+	Encrypted       bool // env_or_file only: credentials came from auth.enc.json
 }
 
 type SetAccessResult struct {
@@ -317,6 +350,10 @@ type Resolver struct {
 	runtime      Runtime
 	keychain     CredentialStore
 	authFilePath string
+	command      CredentialCommand
+	prompt       PassphrasePrompt
+	unlocked     string
+	commandCache map[string]credentialCacheEntry // command output cached in memory
 }
 
 // NewResolver creates a credential resolver using the default auth file path.
@@ -333,6 +370,9 @@ func NewResolverWithAuthFilePath(rt Runtime, keychain CredentialStore, authFileP
 	if rt.Getenv == nil {
 		rt.Getenv = os.Getenv
 	}
+	if rt.RunCommand == nil {
+		rt.RunCommand = runShellCommand(rt.GOOS)
+	}
 	return &Resolver{
 		runtime:      rt,
 		keychain:     keychain,
@@ -399,18 +439,25 @@ func (r *Resolver) SetAccess(source Source, creds Credentials) (SetAccessResult,
 		if err != nil {
 			return SetAccessResult{}, err
 		}
-		store := NewFileStore(path)
+		store, storePath, encrypted := r.fileStoreFor(path, true)
 		if err := store.Save(creds); err != nil {
 			return SetAccessResult{}, err
 		}
+		storedIn := "file"
+		if encrypted {
+			storedIn = "encrypted file"
+		}
 		return SetAccessResult{
 			Credentials: creds,
 			Source:      SourceEnvOrFile,
-			StoredIn:    "file",
-			ConfigPath:  path,
+			StoredIn:    storedIn,
+			ConfigPath:  storePath,
 			ProfileKey:  creds.InstanceURL,
 		}, nil
 
+	case SourceCommand:
+		return SetAccessResult{}, errCommandReadOnly
+
 	default:
 		return SetAccessResult{}, fmt.Errorf("unsupported credential source %q", source)
 	}
@@ -427,7 +474,7 @@ func (r *Resolver) Resolve(source Source, instanceURL string) (ResolvedCredentia
 	}
 
 	var lastErr error
-	for _, candidate := range preferredResolveOrder(r.runtime.GOOS) {
+	for _, candidate := range r.preferredResolveOrder() {
 		resolved, err := r.resolveFromSource(candidate, instanceURL)
 		if err == nil {
 			return resolved, nil
@@ -484,19 +531,27 @@ func (r *Resolver) resolveFromSource(source Source, instanceURL string) (Resolve
 			}, nil
 		}
 
-		store := NewFileStore(path)
+		store, storePath, encrypted := r.fileStoreFor(path, false)
 		creds, err := store.Load(instanceURL)
 		if err != nil {
 			return ResolvedCredentials{}, err
 		}
+		resolvedFrom := "file"
+		if encrypted {
+			resolvedFrom = "encrypted file"
+		}
 		return ResolvedCredentials{
 			Credentials:  creds,
 			Source:       SourceEnvOrFile,
-			ResolvedFrom: "file",
-			ConfigPath:   path,
+			ResolvedFrom: resolvedFrom,
+			ConfigPath:   storePath,
 			ProfileKey:   creds.InstanceURL,
+			Encrypted:    encrypted,
 		}, nil
 
+	case SourceCommand:
+		return r.resolveFromCommand(instanceURL)
+
 	default:
 		return ResolvedCredentials{}, fmt.Errorf("unsupported credential source %q", source)
 	}
@@ -535,6 +590,13 @@ func (r *Resolver) clearAuto(instanceURL string) (ClearAccessResult, error) {
 		result.ConfigPath = fileResult.ConfigPath
 	}
 
+	if r.credentialCommand() != "" {
+		if cacheResult, err := r.clearCommandCache(instanceURL); err == nil && cacheResult.Removed {
+			result.Removed = true
+			result.RemovedFrom = append(result.RemovedFrom, SourceCommand)
+		}
+	}
+
 	if result.Removed {
 		sort.Slice(result.RemovedFrom, func(i, j int) bool {
 			return result.RemovedFrom[i] < result.RemovedFrom[j]
@@ -585,7 +647,7 @@ func (r *Resolver) clearFromSource(source Source, instanceURL string) (ClearAcce
 		if err != nil {
 			return ClearAccessResult{}, err
 		}
-		store := NewFileStore(path)
+		store, path, _ := r.fileStoreFor(path, false)
 		err = store.Delete(instanceURL)
 		if err != nil {
 			if errors.Is(err, ErrCredentialsNotFound) {
@@ -606,16 +668,25 @@ func (r *Resolver) clearFromSource(source Source, instanceURL string) (ClearAcce
 			RemovedFrom: []Source{SourceEnvOrFile},
 		}, nil
 
+	case SourceCommand:
+		return r.clearCommandCache(instanceURL)
+
 	default:
 		return ClearAccessResult{}, fmt.Errorf("unsupported credential source %q", source)
 	}
 }
 
-func preferredResolveOrder(goos string) []Source {
-	if DefaultSourceForGOOS(goos) == SourceKeychain {
-		return []Source{SourceKeychain, SourceEnvOrFile}
+// preferredResolveOrder lists the sources auto tries. A configured credential
+// command wins over stored secrets.
+func (r *Resolver) preferredResolveOrder() []Source {
+	var order []Source
+	if r.credentialCommand() != "" {
+		order = append(order, SourceCommand)
+	}
+	if DefaultSourceForGOOS(r.runtime.GOOS) == SourceKeychain {
+		return append(order, SourceKeychain, SourceEnvOrFile)
 	}
-	return []Source{SourceEnvOrFile, SourceKeychain}
+	return append(order, SourceEnvOrFile, SourceKeychain)
 }
 
 func normalizeSource(source Source) Source {
@@ -626,6 +697,8 @@ func normalizeSource(source Source) Source {
 		return SourceKeychain
 	case string(SourceEnvOrFile):
 		return SourceEnvOrFile
+	case string(SourceCommand):
+		return SourceCommand
 	default:
 		return source
 	}
@@ -636,6 +709,9 @@ func normalizeCredentials(creds Credentials) Credentials {
 	creds.Email = strings.TrimSpace(creds.Email)
 	creds.APIToken = strings.TrimSpace(creds.APIToken)
 	creds.AuthType = normalizeAuthType(strings.TrimSpace(creds.AuthType), creds.Email)
+	creds.RefreshToken = strings.TrimSpace(creds.RefreshToken)
+	creds.CloudID = strings.TrimSpace(creds.CloudID)
+	creds.ClientID = strings.TrimSpace(creds.ClientID)
 	return creds
 }
 
@@ -708,6 +784,7 @@ func isSoftResolveError(err error) bool {
 	}
 	return errors.Is(err, ErrCredentialsNotFound) ||
 		errors.Is(err, errKeychainUnavailable) ||
+		errors.Is(err, errCommandUnavailable) ||
 		errors.Is(err, ErrInstanceURLRequired) ||
 		strings.Contains(strings.ToLower(err.Error()), "unavailable")
 }
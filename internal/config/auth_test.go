@@ -87,6 +87,21 @@ func TestCredentials_Validate(t *testing.T) {
 			creds:   Credentials{},
 			wantErr: true,
 		},
+		{
+			name:    "oauth without email",
+			creds:   Credentials{InstanceURL: "https://x.atlassian.net", AuthType: "oauth", RefreshToken: "r", CloudID: "c", ClientID: "id"},
+			wantErr: false,
+		},
+		{
+			name:    "oauth missing cloud id",
+			creds:   Credentials{InstanceURL: "https://x.atlassian.net", AuthType: "oauth", APIToken: "a", ClientID: "id"},
+			wantErr: true,
+		},
+		{
+			name:    "oauth missing tokens",
+			creds:   Credentials{InstanceURL: "https://x.atlassian.net", AuthType: "oauth", CloudID: "c", ClientID: "id"},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -390,6 +405,31 @@ func TestFileStore_SaveLoadDelete(t *testing.T) {
 	}
 }
 
+func TestFileStore_OAuthRoundTrip(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "auth.json"))
+	creds := Credentials{
+		InstanceURL:  "https://test.atlassian.net",
+		APIToken:     "access",
+		AuthType:     "oauth",
+		RefreshToken: "refresh",
+		ExpiresAt:    "2026-01-02T03:04:05Z",
+		CloudID:      "cloud-1",
+		ClientID:     "client-1",
+		ClientSecret: "secret-1",
+	}
+
+	if err := store.Save(creds); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := store.Load(creds.InstanceURL)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded != creds {
+		t.Fatalf("Load() = %+v, want %+v", loaded, creds)
+	}
+}
+
 func TestResolverResolveAutoFallsBackToFileOnWindows(t *testing.T) {
 	path := filepath.Join(t.TempDir(), "auth.json")
 	store := NewFileStore(path)
@@ -0,0 +1,322 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// EnvCredentialCommand overrides the credential_command config key.
+	EnvCredentialCommand = "JIRA_MGMT_CREDENTIAL_COMMAND"
+
+	defaultCredentialCacheFileName = "credential-cache.enc.json"
+	legacyCredentialCacheFileName  = "credential-cache.json" // plaintext, removed when seen
+	credentialCommandTimeout       = 30 * time.Second
+)
+
+// CredentialCommand configures an external credential helper, e.g. `op read ...`,
+// `pass show jira` or a vault CLI. The command runs through the platform shell with
+// JIRA_MGMT_INSTANCE_URL set and must print either a Credentials JSON object or a
+// single line containing the token.
+type CredentialCommand struct {
+	Command string
+
+	// CacheTTL caches the helper output for this long. Zero disables caching.
+	// The cache is kept on disk only encrypted with the auth file passphrase,
+	// when one is available without prompting; otherwise in memory.
+	CacheTTL time.Duration
+
+	// CachePath overrides the cache location. Defaults to os.UserCacheDir()/jira-mgmt/credential-cache.enc.json.
+	CachePath string
+}
+
+type credentialCacheEntry struct {
+	Credentials Credentials `json:"credentials"`
+	ExpiresAt   time.Time   `json:"expires_at"`
+}
+
+// credentialCacheFile holds entries keyed by credentialCacheKey.
+type credentialCacheFile struct {
+	Entries map[string]credentialCacheEntry `json:"entries,omitempty"`
+}
+
+// WithCredentialCommand enables the command source. An empty command leaves it disabled.
+func (r *Resolver) WithCredentialCommand(cmd CredentialCommand) *Resolver {
+	cmd.Command = strings.TrimSpace(cmd.Command)
+	r.command = cmd
+	return r
+}
+
+// credentialCommand returns the configured helper command, letting the env override config.
+func (r *Resolver) credentialCommand() string {
+	if envCmd := strings.TrimSpace(r.runtime.Getenv(EnvCredentialCommand)); envCmd != "" {
+		return envCmd
+	}
+	return r.command.Command
+}
+
+func (r *Resolver) resolveFromCommand(instanceURL string) (ResolvedCredentials, error) {
+	command := r.credentialCommand()
+	if command == "" {
+		return ResolvedCredentials{}, errCommandUnavailable
+	}
+
+	cachePath := r.credentialCachePath()
+	cacheKey := credentialCacheKey(command, instanceURL)
+	if cached, ok := r.readCredentialCache(cachePath, cacheKey); ok {
+		return ResolvedCredentials{
+			Credentials:  cached,
+			Source:       SourceCommand,
+			ResolvedFrom: "command cache",
+			ProfileKey:   cached.InstanceURL,
+		}, nil
+	}
+
+	env := append(os.Environ(), EnvInstanceURL+"="+instanceURL)
+	output, err := r.runtime.RunCommand(command, env)
+	if err != nil {
+		return ResolvedCredentials{}, fmt.Errorf("credential command: %w", err)
+	}
+
+	creds, err := parseCommandCredentials(output, instanceURL, r.runtime.Getenv)
+	if err != nil {
+		return ResolvedCredentials{}, fmt.Errorf("credential command: %w", err)
+	}
+	if err := creds.Validate(); err != nil {
+		return ResolvedCredentials{}, fmt.Errorf("credential command: %w", err)
+	}
+
+	if r.command.CacheTTL > 0 {
+		_ = r.writeCredentialCache(cachePath, cacheKey, creds, time.Now().Add(r.command.CacheTTL))
+	}
+
+	return ResolvedCredentials{
+		Credentials:  creds,
+		Source:       SourceCommand,
+		ResolvedFrom: "command",
+		ProfileKey:   creds.InstanceURL,
+	}, nil
+}
+
+// clearCommandCache drops the cached helper output for the instance.
+func (r *Resolver) clearCommandCache(instanceURL string) (ClearAccessResult, error) {
+	result := ClearAccessResult{Source: SourceCommand, ProfileKey: normalizeInstanceURL(instanceURL)}
+	key := credentialCacheKey(r.credentialCommand(), instanceURL)
+
+	_, inMemory := r.commandCache[key]
+	delete(r.commandCache, key)
+	result.Removed = inMemory
+
+	if path := r.credentialCachePath(); path != "" {
+		cache, err := r.readCredentialCacheFile(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			// Unreadable without the passphrase: drop the whole cache.
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return ClearAccessResult{}, fmt.Errorf("remove credential cache: %w", err)
+			}
+			result.Removed = true
+		default:
+			if _, ok := cache.Entries[key]; ok {
+				delete(cache.Entries, key)
+				if err := r.writeCredentialCacheFile(path, cache); err != nil {
+					return ClearAccessResult{}, err
+				}
+				result.Removed = true
+			}
+		}
+	}
+
+	if result.Removed {
+		result.RemovedFrom = []Source{SourceCommand}
+	}
+	return result, nil
+}
+
+// credentialCacheKey keys cached output by the helper command and the requested
+// instance URL, so changing credential_command does not serve the old output.
+// The command is hashed, as it may embed secrets.
+func credentialCacheKey(command, instanceURL string) string {
+	sum := sha256.Sum256([]byte(command))
+	return hex.EncodeToString(sum[:8]) + " " + normalizeInstanceURL(instanceURL)
+}
+
+func (r *Resolver) credentialCachePath() string {
+	if r.command.CachePath != "" {
+		return r.command.CachePath
+	}
+	path, err := CredentialCachePath()
+	if err != nil {
+		return ""
+	}
+	removeLegacyCredentialCache(path)
+	return path
+}
+
+// removeLegacyCredentialCache deletes the plaintext cache earlier versions wrote
+// next to the encrypted one.
+func removeLegacyCredentialCache(path string) {
+	_ = os.Remove(filepath.Join(filepath.Dir(path), legacyCredentialCacheFileName))
+}
+
+// cachePassphrase returns the auth file passphrase when it is known without
+// prompting: from the env, or already entered to unlock the auth file.
+func (r *Resolver) cachePassphrase() (string, error) {
+	if r.unlocked != "" {
+		return r.unlocked, nil
+	}
+	if env := r.runtime.Getenv(EnvAuthPassphrase); env != "" {
+		return env, nil
+	}
+	return "", ErrPassphraseRequired
+}
+
+func (r *Resolver) readCredentialCache(path, key string) (Credentials, bool) {
+	if r.command.CacheTTL <= 0 {
+		return Credentials{}, false
+	}
+	entry, ok := r.commandCache[key]
+	if !ok && path != "" {
+		if cache, err := r.readCredentialCacheFile(path); err == nil {
+			entry, ok = cache.Entries[key]
+		}
+	}
+	if !ok || !time.Now().Before(entry.ExpiresAt) {
+		return Credentials{}, false
+	}
+	return normalizeCredentials(entry.Credentials), true
+}
+
+// writeCredentialCache stores creds under key in memory and, when the
+// passphrase is available, in the encrypted cache file.
+func (r *Resolver) writeCredentialCache(path, key string, creds Credentials, expiresAt time.Time) error {
+	entry := credentialCacheEntry{Credentials: creds, ExpiresAt: expiresAt.UTC()}
+	if r.commandCache == nil {
+		r.commandCache = map[string]credentialCacheEntry{}
+	}
+	r.commandCache[key] = entry
+
+	if path == "" {
+		return nil
+	}
+	if _, err := r.cachePassphrase(); err != nil {
+		return nil
+	}
+	// A cache sealed with another passphrase is replaced.
+	cache, _ := r.readCredentialCacheFile(path)
+	if cache.Entries == nil {
+		cache.Entries = map[string]credentialCacheEntry{}
+	}
+
+	now := time.Now()
+	for k, e := range cache.Entries {
+		if !now.Before(e.ExpiresAt) {
+			delete(cache.Entries, k)
+		}
+	}
+	cache.Entries[key] = entry
+	return r.writeCredentialCacheFile(path, cache)
+}
+
+func (r *Resolver) readCredentialCacheFile(path string) (credentialCacheFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return credentialCacheFile{}, err
+	}
+	plaintext, err := openEnvelope(data, r.cachePassphrase)
+	if err != nil {
+		return credentialCacheFile{}, fmt.Errorf("credential cache: %w", err)
+	}
+	var cache credentialCacheFile
+	if err := json.Unmarshal(plaintext, &cache); err != nil {
+		return credentialCacheFile{}, fmt.Errorf("parsing credential cache: %w", err)
+	}
+	return cache, nil
+}
+
+func (r *Resolver) writeCredentialCacheFile(path string, cache credentialCacheFile) error {
+	if len(cache.Entries) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove credential cache: %w", err)
+		}
+		return nil
+	}
+	plaintext, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("encoding credential cache: %w", err)
+	}
+	data, err := sealEnvelope(plaintext, r.cachePassphrase)
+	if err != nil {
+		return fmt.Errorf("credential cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing credential cache: %w", err)
+	}
+	return nil
+}
+
+// parseCommandCredentials accepts a Credentials JSON object or a bare token.
+// Missing fields fall back to the instance URL being resolved and the JIRA_MGMT_* env vars.
+func parseCommandCredentials(output []byte, instanceURL string, getenv func(string) string) (Credentials, error) {
+	trimmed := bytes.TrimSpace(output)
+	if len(trimmed) == 0 {
+		return Credentials{}, errors.New("command printed nothing")
+	}
+
+	var creds Credentials
+	if trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, &creds); err != nil {
+			return Credentials{}, fmt.Errorf("parsing command output: %w", err)
+		}
+	} else {
+		// Tools like `pass show` print the secret on the first line and metadata after it.
+		line, _, _ := strings.Cut(string(trimmed), "\n")
+		creds.APIToken = strings.TrimSpace(line)
+	}
+
+	creds.InstanceURL = firstNonEmpty(creds.InstanceURL, instanceURL)
+	creds.Email = firstNonEmpty(creds.Email, getenv(EnvEmail))
+	creds.AuthType = firstNonEmpty(creds.AuthType, getenv(EnvAuthType))
+	return normalizeCredentials(creds), nil
+}
+
+// runShellCommand runs command through the platform shell and returns its stdout.
+func runShellCommand(goos string) func(command string, env []string) ([]byte, error) {
+	return func(command string, env []string) ([]byte, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), credentialCommandTimeout)
+		defer cancel()
+
+		var cmd *exec.Cmd
+		if goos == "windows" {
+			cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+		} else {
+			cmd = exec.CommandContext(ctx, "sh", "-c", command)
+		}
+		cmd.Env = env
+
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return nil, fmt.Errorf("%w: %s", err, msg)
+			}
+			return nil, err
+		}
+		return stdout.Bytes(), nil
+	}
+}
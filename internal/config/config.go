@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -33,7 +35,52 @@ type Config struct {
 	InstanceURL   string `yaml:"instance_url,omitempty"`
 	InstanceType  string `yaml:"instance_type,omitempty"`   // "cloud" or "server"
 	AuthType      string `yaml:"auth_type,omitempty"`       // "basic" or "bearer"
-	TLSSkipVerify bool   `yaml:"tls_skip_verify,omitempty"` // skip TLS certificate verification (corporate CAs)
+	TLSSkipVerify bool   `yaml:"tls_skip_verify,omitempty"` // skip TLS certificate verification (last resort; prefer ca_cert_file)
+
+	CACertFile     string `yaml:"ca_cert_file,omitempty"`     // extra PEM CA bundle for corporate CAs
+	ClientCertFile string `yaml:"client_cert_file,omitempty"` // PEM client certificate for mTLS
+	ClientKeyFile  string `yaml:"client_key_file,omitempty"`  // PEM private key for client_cert_file
+	ProxyURL       string `yaml:"proxy_url,omitempty"`        // explicit HTTP(S) proxy
+	NoProxy        string `yaml:"no_proxy,omitempty"`         // comma-separated hosts that bypass proxy_url
+	Timeout        string `yaml:"timeout,omitempty"`          // Go duration per request, e.g. "60s"
+
+	CredentialCommand  string `yaml:"credential_command,omitempty"`   // external helper printing credentials, e.g. "pass show jira"
+	CredentialCacheTTL string `yaml:"credential_cache_ttl,omitempty"` // Go duration, e.g. "15m"; empty disables caching
+
+	// FieldAliases names Jira fields in the query DSL: alias -> field ID or name,
+	// e.g. {sp: customfield_10016, team: "Team"}.
+	FieldAliases map[string]string `yaml:"field_aliases,omitempty"`
+
+	// ComponentRules is a YAML file mapping file paths and keywords to
+	// components, used by create to suggest a component.
+	ComponentRules string `yaml:"component_rules,omitempty"`
+}
+
+// TimeoutDuration parses Timeout. Invalid or empty values mean the client default.
+func (c Config) TimeoutDuration() time.Duration {
+	d, err := time.ParseDuration(strings.TrimSpace(c.Timeout))
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// CredentialCacheDuration parses CredentialCacheTTL. An empty value disables
+// caching; an invalid one (a hand-edited config) disables it too and is
+// reported, so callers can warn instead of silently running uncached.
+func (c Config) CredentialCacheDuration() (time.Duration, error) {
+	ttl := strings.TrimSpace(c.CredentialCacheTTL)
+	if ttl == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(ttl)
+	if err != nil {
+		return 0, fmt.Errorf("invalid credential_cache_ttl %q: %w", ttl, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid credential_cache_ttl %q: must not be negative", ttl)
+	}
+	return d, nil
 }
 
 // DefaultConfig returns a Config with sensible defaults.
@@ -70,6 +117,38 @@ func AuthConfigPath() (string, error) {
 	return filepath.Join(configDir, defaultAuthFileName), nil
 }
 
+// CredentialCachePath returns the cache file used by the credential command source.
+func CredentialCachePath() (string, error) {
+	baseDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("getting user cache dir: %w", err)
+	}
+	return filepath.Join(baseDir, AppName, defaultCredentialCacheFileName), nil
+}
+
+// FieldCatalogPath returns the cache file for an instance's field catalog.
+func FieldCatalogPath(instanceURL string) (string, error) {
+	return instanceCachePath("fields", instanceURL)
+}
+
+// UserCachePath returns the cache file for an instance's resolved users.
+func UserCachePath(instanceURL string) (string, error) {
+	return instanceCachePath("users", instanceURL)
+}
+
+// instanceCachePath returns a per-instance cache file named prefix-<host>.json.
+func instanceCachePath(prefix, instanceURL string) (string, error) {
+	baseDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("getting user cache dir: %w", err)
+	}
+	name := strings.NewReplacer("https://", "", "http://", "", "/", "_", ":", "_").Replace(normalizeInstanceURL(instanceURL))
+	if name == "" {
+		name = "default"
+	}
+	return filepath.Join(baseDir, AppName, prefix+"-"+name+".json"), nil
+}
+
 // InstallStatePath returns the install-state metadata path.
 func InstallStatePath() (string, error) {
 	configDir, err := ConfigDir()
@@ -228,3 +307,153 @@ func (m *ConfigManager) SetTLSSkipVerify(skip bool) error {
 	cfg.TLSSkipVerify = skip
 	return m.saveConfig(cfg)
 }
+
+// SetCredentialCommand updates the external credential helper command.
+func (m *ConfigManager) SetCredentialCommand(command string) error {
+	cfg, err := m.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.CredentialCommand = strings.TrimSpace(command)
+	return m.saveConfig(cfg)
+}
+
+// SetCredentialCacheTTL updates how long credential command output is cached.
+func (m *ConfigManager) SetCredentialCacheTTL(ttl string) error {
+	ttl = strings.TrimSpace(ttl)
+	if ttl != "" {
+		d, err := time.ParseDuration(ttl)
+		if err != nil {
+			return fmt.Errorf("invalid credential cache TTL %q: %w", ttl, err)
+		}
+		if d < 0 {
+			return fmt.Errorf("credential cache TTL must not be negative")
+		}
+	}
+
+	cfg, err := m.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.CredentialCacheTTL = ttl
+	return m.saveConfig(cfg)
+}
+
+// SetCACertFile updates the extra CA bundle path.
+func (m *ConfigManager) SetCACertFile(path string) error {
+	cfg, err := m.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.CACertFile = strings.TrimSpace(path)
+	return m.saveConfig(cfg)
+}
+
+// SetClientCertFile updates the mTLS client certificate path.
+func (m *ConfigManager) SetClientCertFile(path string) error {
+	cfg, err := m.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.ClientCertFile = strings.TrimSpace(path)
+	return m.saveConfig(cfg)
+}
+
+// SetClientKeyFile updates the mTLS client key path.
+func (m *ConfigManager) SetClientKeyFile(path string) error {
+	cfg, err := m.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.ClientKeyFile = strings.TrimSpace(path)
+	return m.saveConfig(cfg)
+}
+
+// SetProxyURL updates the explicit HTTP(S) proxy.
+func (m *ConfigManager) SetProxyURL(proxyURL string) error {
+	cfg, err := m.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.ProxyURL = strings.TrimSpace(proxyURL)
+	return m.saveConfig(cfg)
+}
+
+// SetNoProxy updates the proxy bypass list.
+func (m *ConfigManager) SetNoProxy(noProxy string) error {
+	cfg, err := m.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.NoProxy = strings.TrimSpace(noProxy)
+	return m.saveConfig(cfg)
+}
+
+// SetComponentRules updates the component rule file path.
+func (m *ConfigManager) SetComponentRules(path string) error {
+	cfg, err := m.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.ComponentRules = strings.TrimSpace(path)
+	return m.saveConfig(cfg)
+}
+
+// SetTimeout updates the per-request timeout.
+func (m *ConfigManager) SetTimeout(timeout string) error {
+	timeout = strings.TrimSpace(timeout)
+	if timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %q: %w", timeout, err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("timeout must be positive")
+		}
+	}
+
+	cfg, err := m.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.Timeout = timeout
+	return m.saveConfig(cfg)
+}
+
+// SetFieldAlias maps a query DSL alias to a Jira field ID or name. An empty field removes the alias.
+func (m *ConfigManager) SetFieldAlias(alias, field string) error {
+	alias = strings.TrimSpace(alias)
+	field = strings.TrimSpace(field)
+	if alias == "" {
+		return fmt.Errorf("field alias name is required")
+	}
+	for _, r := range alias {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_') {
+			return fmt.Errorf("invalid field alias %q: use lowercase letters, digits and underscores", alias)
+		}
+	}
+
+	cfg, err := m.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	if field == "" {
+		delete(cfg.FieldAliases, alias)
+	} else {
+		if cfg.FieldAliases == nil {
+			cfg.FieldAliases = map[string]string{}
+		}
+		cfg.FieldAliases[alias] = field
+	}
+	return m.saveConfig(cfg)
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func tempConfigPath(t *testing.T) string {
@@ -234,3 +235,96 @@ func TestNewConfigManager(t *testing.T) {
 		t.Fatal("configPath is empty")
 	}
 }
+
+func TestConfigManager_SetCredentialCacheTTL(t *testing.T) {
+	mgr := NewConfigManagerWithPath(tempConfigPath(t))
+
+	if err := mgr.SetCredentialCacheTTL("not-a-duration"); err == nil {
+		t.Fatal("expected error for invalid TTL")
+	}
+	if err := mgr.SetCredentialCommand("pass show jira"); err != nil {
+		t.Fatalf("SetCredentialCommand: %v", err)
+	}
+	if err := mgr.SetCredentialCacheTTL("15m"); err != nil {
+		t.Fatalf("SetCredentialCacheTTL: %v", err)
+	}
+
+	cfg, err := mgr.GetConfig()
+	if err != nil {
+		t.Fatalf("GetConfig: %v", err)
+	}
+	if cfg.CredentialCommand != "pass show jira" {
+		t.Errorf("CredentialCommand = %q", cfg.CredentialCommand)
+	}
+	if d, err := cfg.CredentialCacheDuration(); err != nil || d != 15*time.Minute {
+		t.Errorf("CredentialCacheDuration = %v, %v, want 15m", d, err)
+	}
+}
+
+func TestConfig_CredentialCacheDurationInvalid(t *testing.T) {
+	for _, ttl := range []string{"15", "forever", "-1m"} {
+		d, err := Config{CredentialCacheTTL: ttl}.CredentialCacheDuration()
+		if err == nil || d != 0 {
+			t.Errorf("%q: got %v, %v, want 0 and an error", ttl, d, err)
+		}
+	}
+	if d, err := (Config{}).CredentialCacheDuration(); err != nil || d != 0 {
+		t.Errorf("empty: got %v, %v, want 0 and no error", d, err)
+	}
+}
+
+func TestConfigManager_TransportSettings(t *testing.T) {
+	mgr := NewConfigManagerWithPath(tempConfigPath(t))
+
+	if err := mgr.SetTimeout("soon"); err == nil {
+		t.Fatal("expected error for invalid timeout")
+	}
+	if err := mgr.SetTimeout("90s"); err != nil {
+		t.Fatalf("SetTimeout: %v", err)
+	}
+	if err := mgr.SetCACertFile(" /etc/ssl/corp.pem "); err != nil {
+		t.Fatalf("SetCACertFile: %v", err)
+	}
+	if err := mgr.SetProxyURL("http://proxy:3128"); err != nil {
+		t.Fatalf("SetProxyURL: %v", err)
+	}
+	if err := mgr.SetNoProxy(".corp,10.0.0.0/8"); err != nil {
+		t.Fatalf("SetNoProxy: %v", err)
+	}
+
+	cfg, err := mgr.GetConfig()
+	if err != nil {
+		t.Fatalf("GetConfig: %v", err)
+	}
+	if cfg.TimeoutDuration() != 90*time.Second {
+		t.Errorf("TimeoutDuration = %v, want 90s", cfg.TimeoutDuration())
+	}
+	if cfg.CACertFile != "/etc/ssl/corp.pem" || cfg.ProxyURL != "http://proxy:3128" || cfg.NoProxy != ".corp,10.0.0.0/8" {
+		t.Errorf("cfg = %+v", cfg)
+	}
+}
+
+func TestConfigManager_SetFieldAlias(t *testing.T) {
+	mgr := NewConfigManagerWithPath(tempConfigPath(t))
+
+	if err := mgr.SetFieldAlias("Story Points", "customfield_10016"); err == nil {
+		t.Fatal("expected error for invalid alias name")
+	}
+	if err := mgr.SetFieldAlias("sp", "customfield_10016"); err != nil {
+		t.Fatalf("SetFieldAlias: %v", err)
+	}
+	if err := mgr.SetFieldAlias("team", "Team"); err != nil {
+		t.Fatalf("SetFieldAlias: %v", err)
+	}
+	if err := mgr.SetFieldAlias("team", ""); err != nil {
+		t.Fatalf("SetFieldAlias remove: %v", err)
+	}
+
+	cfg, err := mgr.GetConfig()
+	if err != nil {
+		t.Fatalf("GetConfig: %v", err)
+	}
+	if len(cfg.FieldAliases) != 1 || cfg.FieldAliases["sp"] != "customfield_10016" {
+		t.Errorf("FieldAliases = %v", cfg.FieldAliases)
+	}
+}
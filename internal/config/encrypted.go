@@ -0,0 +1,405 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// EnvAuthPassphrase unlocks the encrypted auth file without prompting.
+	EnvAuthPassphrase = "JIRA_MGMT_AUTH_PASSPHRASE"
+
+	defaultEncryptedAuthFileName = "auth.enc.json"
+
+	encryptedAuthVersion = 1
+	encryptedAuthKDF     = "pbkdf2-sha256"
+	encryptedAuthCipher  = "aes-256-gcm"
+)
+
+// pbkdf2Iterations is the work factor for new files (OWASP 2023 guidance). Tests lower it.
+var pbkdf2Iterations = 600_000
+
+// ErrPassphraseRequired is returned when the encrypted auth file cannot be unlocked.
+var ErrPassphraseRequired = errors.New("auth file is encrypted: set " + EnvAuthPassphrase + " or run interactively")
+
+// ErrWrongPassphrase is returned when decryption fails.
+var ErrWrongPassphrase = errors.New("wrong passphrase for encrypted auth file")
+
+// encryptedEnvelope is the on-disk format of auth.enc.json. The plaintext is the
+// same profiles document FileStore writes.
+type encryptedEnvelope struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Cipher     string `json:"cipher"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// EncryptedFileStore persists credentials like FileStore, sealed with AES-256-GCM
+// under a key derived from a passphrase (PBKDF2-SHA256).
+type EncryptedFileStore struct {
+	path       string
+	passphrase func() (string, error)
+}
+
+// NewEncryptedFileStore creates an encrypted credential store at path. passphrase is
+// called lazily the first time the file must be read or written.
+func NewEncryptedFileStore(path string, passphrase func() (string, error)) *EncryptedFileStore {
+	return &EncryptedFileStore{path: path, passphrase: passphrase}
+}
+
+// Path returns the encrypted auth file path.
+func (e *EncryptedFileStore) Path() string {
+	return e.path
+}
+
+// Exists reports whether the encrypted auth file is present.
+func (e *EncryptedFileStore) Exists() bool {
+	_, err := os.Stat(e.path)
+	return err == nil
+}
+
+// Save stores credentials under a profile keyed by normalized instance URL.
+func (e *EncryptedFileStore) Save(creds Credentials) error {
+	creds = normalizeCredentials(creds)
+	if err := creds.Validate(); err != nil {
+		return fmt.Errorf("invalid credentials: %w", err)
+	}
+
+	cfg, err := e.read()
+	if err != nil && !errors.Is(err, ErrCredentialsNotFound) {
+		return err
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]Credentials{}
+	}
+	cfg.Profiles[creds.InstanceURL] = creds
+	return e.write(cfg)
+}
+
+// Load reads credentials for the given instance URL.
+func (e *EncryptedFileStore) Load(instanceURL string) (Credentials, error) {
+	cfg, err := e.read()
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	key, err := selectProfileKey(cfg.Profiles, instanceURL)
+	if err != nil {
+		return Credentials{}, err
+	}
+	return normalizeCredentials(cfg.Profiles[key]), nil
+}
+
+// Delete removes credentials for the given instance URL.
+func (e *EncryptedFileStore) Delete(instanceURL string) error {
+	cfg, err := e.read()
+	if err != nil {
+		return err
+	}
+
+	key, err := selectProfileKey(cfg.Profiles, instanceURL)
+	if err != nil {
+		return err
+	}
+	delete(cfg.Profiles, key)
+
+	if len(cfg.Profiles) == 0 {
+		if err := os.Remove(e.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove encrypted auth file: %w", err)
+		}
+		return nil
+	}
+	return e.write(cfg)
+}
+
+// merge adds profiles that are not already present and reports how many were added.
+func (e *EncryptedFileStore) merge(profiles map[string]Credentials) (int, error) {
+	cfg, err := e.read()
+	if err != nil && !errors.Is(err, ErrCredentialsNotFound) {
+		return 0, err
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]Credentials{}
+	}
+
+	added := 0
+	for key, creds := range profiles {
+		if _, exists := cfg.Profiles[key]; exists {
+			continue
+		}
+		cfg.Profiles[key] = normalizeCredentials(creds)
+		added++
+	}
+	return added, e.write(cfg)
+}
+
+func (e *EncryptedFileStore) read() (authFile, error) {
+	data, err := os.ReadFile(e.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return authFile{}, ErrCredentialsNotFound
+		}
+		return authFile{}, fmt.Errorf("reading encrypted auth file: %w", err)
+	}
+
+	plaintext, err := openEnvelope(data, e.passphrase)
+	if err != nil {
+		return authFile{}, encryptedAuthFileError(err)
+	}
+
+	var cfg authFile
+	if err := json.Unmarshal(plaintext, &cfg); err != nil {
+		return authFile{}, fmt.Errorf("parsing decrypted auth file: %w", err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]Credentials{}
+	}
+	return cfg, nil
+}
+
+func (e *EncryptedFileStore) write(cfg authFile) error {
+	plaintext, err := json.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("encoding auth file: %w", err)
+	}
+	data, err := sealEnvelope(plaintext, e.passphrase)
+	if err != nil {
+		return encryptedAuthFileError(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(e.path), 0o755); err != nil {
+		return fmt.Errorf("creating auth directory: %w", err)
+	}
+	if err := os.WriteFile(e.path, data, 0o600); err != nil {
+		return fmt.Errorf("writing encrypted auth file: %w", err)
+	}
+	return nil
+}
+
+// encryptedAuthFileError names the auth file in envelope errors; the passphrase
+// errors already do.
+func encryptedAuthFileError(err error) error {
+	if errors.Is(err, ErrPassphraseRequired) || errors.Is(err, ErrWrongPassphrase) {
+		return err
+	}
+	return fmt.Errorf("encrypted auth file: %w", err)
+}
+
+// sealEnvelope encrypts plaintext into an encryptedEnvelope document.
+func sealEnvelope(plaintext []byte, passphrase func() (string, error)) ([]byte, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generating salt: %w", err)
+	}
+	aead, err := envelopeAEAD(passphrase, salt, pbkdf2Iterations)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+
+	data, err := json.MarshalIndent(encryptedEnvelope{
+		Version:    encryptedAuthVersion,
+		KDF:        encryptedAuthKDF,
+		Iterations: pbkdf2Iterations,
+		Cipher:     encryptedAuthCipher,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, nil),
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding envelope: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// openEnvelope decrypts a document written by sealEnvelope. The passphrase is
+// only asked for once the document is known to be a supported envelope.
+func openEnvelope(data []byte, passphrase func() (string, error)) ([]byte, error) {
+	var env encryptedEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("parsing envelope: %w", err)
+	}
+	if env.Version != encryptedAuthVersion || env.KDF != encryptedAuthKDF || env.Cipher != encryptedAuthCipher {
+		return nil, fmt.Errorf("unsupported envelope (version %d, %s, %s)", env.Version, env.KDF, env.Cipher)
+	}
+
+	aead, err := envelopeAEAD(passphrase, env.Salt, env.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, env.Nonce, env.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
+
+func envelopeAEAD(passphrase func() (string, error), salt []byte, iterations int) (cipher.AEAD, error) {
+	if passphrase == nil {
+		return nil, ErrPassphraseRequired
+	}
+	value, err := passphrase()
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return nil, ErrPassphraseRequired
+	}
+
+	key, err := pbkdf2.Key(sha256.New, value, salt, iterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("deriving key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// PassphrasePrompt asks the user for the auth file passphrase. Set by the CLI;
+// nil means only EnvAuthPassphrase is consulted.
+type PassphrasePrompt func() (string, error)
+
+// WithPassphrasePrompt sets the interactive fallback used when EnvAuthPassphrase is empty.
+func (r *Resolver) WithPassphrasePrompt(prompt PassphrasePrompt) *Resolver {
+	r.prompt = prompt
+	return r
+}
+
+// EncryptedAuthConfigPath returns the encrypted auth file path next to auth.json.
+func (r *Resolver) EncryptedAuthConfigPath() (string, error) {
+	path, err := r.AuthConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return encryptedPathFor(path), nil
+}
+
+func encryptedPathFor(authPath string) string {
+	if filepath.Base(authPath) == defaultAuthFileName {
+		return filepath.Join(filepath.Dir(authPath), defaultEncryptedAuthFileName)
+	}
+	return strings.TrimSuffix(authPath, filepath.Ext(authPath)) + ".enc.json"
+}
+
+// passphrase returns the passphrase from the env or the prompt, asking at most once per resolver.
+func (r *Resolver) passphrase() (string, error) {
+	if r.unlocked != "" {
+		return r.unlocked, nil
+	}
+	if env := r.runtime.Getenv(EnvAuthPassphrase); env != "" {
+		r.unlocked = env
+		return env, nil
+	}
+	if r.prompt == nil {
+		return "", ErrPassphraseRequired
+	}
+	value, err := r.prompt()
+	if err != nil {
+		return "", fmt.Errorf("reading passphrase: %w", err)
+	}
+	r.unlocked = value
+	return value, nil
+}
+
+func (r *Resolver) encryptedStore(authPath string) *EncryptedFileStore {
+	return NewEncryptedFileStore(encryptedPathFor(authPath), r.passphrase)
+}
+
+// fileStoreFor picks the env_or_file backend: the encrypted file once it exists,
+// or when a passphrase is supplied via the env for a fresh write.
+func (r *Resolver) fileStoreFor(authPath string, forWrite bool) (CredentialStore, string, bool) {
+	enc := r.encryptedStore(authPath)
+	if enc.Exists() {
+		return enc, enc.Path(), true
+	}
+	if forWrite && r.runtime.Getenv(EnvAuthPassphrase) != "" {
+		if _, err := os.Stat(authPath); errors.Is(err, os.ErrNotExist) {
+			return enc, enc.Path(), true
+		}
+	}
+	return NewFileStore(authPath), authPath, false
+}
+
+// StorageStatus describes how the env_or_file backend stores secrets on disk.
+type StorageStatus struct {
+	PlaintextPath   string
+	PlaintextExists bool
+	EncryptedPath   string
+	EncryptedExists bool
+}
+
+// FileStorageStatus reports which auth files exist.
+func (r *Resolver) FileStorageStatus() (StorageStatus, error) {
+	path, err := r.AuthConfigPath()
+	if err != nil {
+		return StorageStatus{}, err
+	}
+	status := StorageStatus{PlaintextPath: path, EncryptedPath: encryptedPathFor(path)}
+	if _, err := os.Stat(status.PlaintextPath); err == nil {
+		status.PlaintextExists = true
+	}
+	if _, err := os.Stat(status.EncryptedPath); err == nil {
+		status.EncryptedExists = true
+	}
+	return status, nil
+}
+
+// MigrateResult describes a plaintext-to-encrypted migration.
+type MigrateResult struct {
+	PlaintextPath string
+	EncryptedPath string
+	Migrated      int
+	Skipped       int
+}
+
+// MigrateToEncrypted moves every profile from auth.json into auth.enc.json and
+// removes the plaintext file. Profiles already present in the encrypted file win.
+func (r *Resolver) MigrateToEncrypted() (MigrateResult, error) {
+	path, err := r.AuthConfigPath()
+	if err != nil {
+		return MigrateResult{}, err
+	}
+	enc := r.encryptedStore(path)
+	result := MigrateResult{PlaintextPath: path, EncryptedPath: enc.Path()}
+
+	plain, err := NewFileStore(path).read()
+	if err != nil {
+		if errors.Is(err, ErrCredentialsNotFound) {
+			return result, fmt.Errorf("no plaintext auth file at %s", path)
+		}
+		return result, err
+	}
+
+	added, err := enc.merge(plain.Profiles)
+	if err != nil {
+		return result, err
+	}
+	result.Migrated = added
+	result.Skipped = len(plain.Profiles) - added
+
+	// Verify the new file decrypts before dropping the plaintext copy.
+	if _, err := enc.read(); err != nil {
+		return result, fmt.Errorf("verifying encrypted auth file: %w", err)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return result, fmt.Errorf("remove plaintext auth file: %w", err)
+	}
+	return result, nil
+}
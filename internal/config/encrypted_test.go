@@ -0,0 +1,147 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func init() {
+	// Keep key derivation cheap in tests.
+	pbkdf2Iterations = 1000
+}
+
+func staticPassphrase(p string) func() (string, error) {
+	return func() (string, error) { return p, nil }
+}
+
+func TestEncryptedFileStore_SaveLoadDelete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.enc.json")
+	store := NewEncryptedFileStore(path, staticPassphrase("correct horse"))
+	creds := validCreds()
+
+	if err := store.Save(creds); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if strings.Contains(string(raw), creds.APIToken) || strings.Contains(string(raw), creds.Email) {
+		t.Fatal("encrypted file contains plaintext secrets")
+	}
+
+	loaded, err := store.Load(creds.InstanceURL)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded != normalizeCredentials(creds) {
+		t.Fatalf("Load() = %+v, want %+v", loaded, creds)
+	}
+
+	if err := store.Delete(creds.InstanceURL); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("encrypted file should be removed with its last profile, stat err = %v", err)
+	}
+}
+
+func TestEncryptedFileStore_WrongPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.enc.json")
+	if err := NewEncryptedFileStore(path, staticPassphrase("right")).Save(validCreds()); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	_, err := NewEncryptedFileStore(path, staticPassphrase("wrong")).Load(validCreds().InstanceURL)
+	if !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("Load() error = %v, want ErrWrongPassphrase", err)
+	}
+
+	_, err = NewEncryptedFileStore(path, nil).Load(validCreds().InstanceURL)
+	if !errors.Is(err, ErrPassphraseRequired) {
+		t.Fatalf("Load() error = %v, want ErrPassphraseRequired", err)
+	}
+}
+
+func TestResolverMigrateToEncrypted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.json")
+	creds := validCreds()
+	if err := NewFileStore(path).Save(creds); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	prompts := 0
+	resolver := NewResolverWithAuthFilePath(Runtime{
+		GOOS:   "linux",
+		Getenv: func(string) string { return "" },
+	}, nil, path).WithPassphrasePrompt(func() (string, error) {
+		prompts++
+		return "secret", nil
+	})
+
+	status, err := resolver.FileStorageStatus()
+	if err != nil {
+		t.Fatalf("FileStorageStatus() error = %v", err)
+	}
+	if !status.PlaintextExists || status.EncryptedExists {
+		t.Fatalf("status before migrate = %+v", status)
+	}
+
+	result, err := resolver.MigrateToEncrypted()
+	if err != nil {
+		t.Fatalf("MigrateToEncrypted() error = %v", err)
+	}
+	if result.Migrated != 1 {
+		t.Fatalf("Migrated = %d, want 1", result.Migrated)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("plaintext auth file should be removed, stat err = %v", err)
+	}
+
+	resolved, err := resolver.Resolve(SourceAuto, creds.InstanceURL)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if !resolved.Encrypted || resolved.ResolvedFrom != "encrypted file" {
+		t.Fatalf("Resolve() = %+v, want encrypted file", resolved)
+	}
+	if resolved.Credentials.APIToken != creds.APIToken {
+		t.Fatalf("APIToken = %q, want %q", resolved.Credentials.APIToken, creds.APIToken)
+	}
+	if prompts != 1 {
+		t.Fatalf("prompted %d times, want 1", prompts)
+	}
+}
+
+func TestResolverSetAccessEncryptsWithEnvPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.json")
+	env := map[string]string{EnvAuthPassphrase: "from-env"}
+	resolver := NewResolverWithAuthFilePath(Runtime{
+		GOOS:   "linux",
+		Getenv: func(key string) string { return env[key] },
+	}, nil, path)
+
+	result, err := resolver.SetAccess(SourceAuto, validCreds())
+	if err != nil {
+		t.Fatalf("SetAccess() error = %v", err)
+	}
+	if result.StoredIn != "encrypted file" {
+		t.Fatalf("StoredIn = %q, want encrypted file", result.StoredIn)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("plaintext auth.json should not be written")
+	}
+
+	delete(env, EnvAuthPassphrase)
+	fresh := NewResolverWithAuthFilePath(Runtime{
+		GOOS:   "linux",
+		Getenv: func(key string) string { return env[key] },
+	}, nil, path)
+	if _, err := fresh.Resolve(SourceAuto, validCreds().InstanceURL); !errors.Is(err, ErrPassphraseRequired) {
+		t.Fatalf("Resolve() without passphrase error = %v, want ErrPassphraseRequired", err)
+	}
+}
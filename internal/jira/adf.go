@@ -0,0 +1,198 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// adfDocAlias has ADFDoc's layout without its JSON methods.
+type adfDocAlias ADFDoc
+
+// UnmarshalJSON decodes an ADF document (Cloud v3) or a plain string body
+// (Server/DC v2), which becomes one paragraph per line.
+func (d *ADFDoc) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*d = *NewADFParagraphs(strings.Split(s, "\n"))
+		return nil
+	}
+	return json.Unmarshal(data, (*adfDocAlias)(d))
+}
+
+// PlainText returns the document's text, one line per paragraph, heading or list item.
+func (d *ADFDoc) PlainText() string {
+	return strings.TrimRight(extractADFText(d), "\n")
+}
+
+// Markdown renders the document as Markdown. Nodes without a Markdown
+// equivalent (panels, media) fall back to their text.
+func (d *ADFDoc) Markdown() string {
+	if d == nil {
+		return ""
+	}
+	var b strings.Builder
+	writeMarkdownBlocks(&b, d.Content, "")
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func writeMarkdownBlocks(b *strings.Builder, nodes []ADFNode, indent string) {
+	for i, node := range nodes {
+		if i > 0 && indent == "" {
+			b.WriteString("\n")
+		}
+		writeMarkdownBlock(b, node, indent)
+	}
+}
+
+func writeMarkdownBlock(b *strings.Builder, node ADFNode, indent string) {
+	switch node.Type {
+	case "heading":
+		level := 1
+		var attrs struct {
+			Level int `json:"level"`
+		}
+		if json.Unmarshal(node.Attrs, &attrs) == nil && attrs.Level > 0 {
+			level = attrs.Level
+		}
+		fmt.Fprintf(b, "%s%s %s\n", indent, strings.Repeat("#", level), markdownInline(node.Content))
+	case "bulletList", "orderedList":
+		for i, item := range node.Content {
+			marker := "- "
+			if node.Type == "orderedList" {
+				marker = fmt.Sprintf("%d. ", i+1)
+			}
+			writeMarkdownListItem(b, item, indent, marker)
+		}
+	case "codeBlock":
+		var attrs struct {
+			Language string `json:"language"`
+		}
+		json.Unmarshal(node.Attrs, &attrs)
+		fmt.Fprintf(b, "%s```%s\n", indent, attrs.Language)
+		for _, line := range strings.Split(markdownText(node.Content), "\n") {
+			fmt.Fprintf(b, "%s%s\n", indent, line)
+		}
+		fmt.Fprintf(b, "%s```\n", indent)
+	case "blockquote":
+		var inner strings.Builder
+		writeMarkdownBlocks(&inner, node.Content, "")
+		for _, line := range strings.Split(strings.TrimRight(inner.String(), "\n"), "\n") {
+			fmt.Fprintf(b, "%s> %s\n", indent, line)
+		}
+	case "rule":
+		fmt.Fprintf(b, "%s---\n", indent)
+	case "table":
+		for i, row := range node.Content {
+			cells := make([]string, len(row.Content))
+			for j, cell := range row.Content {
+				cells[j] = strings.ReplaceAll(strings.TrimSpace(extractADFText(&ADFDoc{Content: cell.Content})), "\n", " ")
+			}
+			fmt.Fprintf(b, "%s| %s |\n", indent, strings.Join(cells, " | "))
+			if i == 0 {
+				fmt.Fprintf(b, "%s|%s\n", indent, strings.Repeat(" --- |", len(cells)))
+			}
+		}
+	case "paragraph":
+		fmt.Fprintf(b, "%s%s\n", indent, markdownInline(node.Content))
+	default:
+		if len(node.Content) > 0 && node.Content[0].Type != "text" {
+			writeMarkdownBlocks(b, node.Content, indent)
+			return
+		}
+		fmt.Fprintf(b, "%s%s\n", indent, markdownInline(append([]ADFNode{node}, node.Content...)))
+	}
+}
+
+func writeMarkdownListItem(b *strings.Builder, item ADFNode, indent, marker string) {
+	for i, child := range item.Content {
+		switch {
+		case child.Type == "bulletList" || child.Type == "orderedList":
+			writeMarkdownBlock(b, child, indent+"  ")
+		case i == 0:
+			fmt.Fprintf(b, "%s%s%s\n", indent, marker, markdownInline(child.Content))
+		default:
+			fmt.Fprintf(b, "%s  %s\n", indent, markdownInline(child.Content))
+		}
+	}
+}
+
+// markdownInline renders inline nodes (text with marks, mentions, links, breaks).
+func markdownInline(nodes []ADFNode) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		switch node.Type {
+		case "text":
+			b.WriteString(markdownMarks(node.Text, node.Marks))
+		case "hardBreak":
+			b.WriteString("  \n")
+		case "date":
+			b.WriteString(adfDate(node.Attrs))
+		case "mention", "emoji", "status":
+			var attrs struct {
+				Text      string `json:"text"`
+				ShortName string `json:"shortName"`
+			}
+			json.Unmarshal(node.Attrs, &attrs)
+			b.WriteString(attrs.Text + attrs.ShortName)
+		case "inlineCard":
+			var attrs struct {
+				URL string `json:"url"`
+			}
+			json.Unmarshal(node.Attrs, &attrs)
+			fmt.Fprintf(&b, "<%s>", attrs.URL)
+		default:
+			b.WriteString(markdownInline(node.Content))
+		}
+	}
+	return b.String()
+}
+
+// adfDate renders a date node, whose timestamp is milliseconds since the
+// epoch (as a string, sometimes a number), as YYYY-MM-DD in UTC.
+func adfDate(raw json.RawMessage) string {
+	var attrs struct {
+		Timestamp json.Number `json:"timestamp"`
+	}
+	if json.Unmarshal(raw, &attrs) != nil {
+		return ""
+	}
+	ms, err := strconv.ParseInt(attrs.Timestamp.String(), 10, 64)
+	if err != nil {
+		return attrs.Timestamp.String()
+	}
+	return time.UnixMilli(ms).UTC().Format(time.DateOnly)
+}
+
+func markdownMarks(text string, marks []ADFMark) string {
+	for _, mark := range marks {
+		switch mark.Type {
+		case "strong":
+			text = "**" + text + "**"
+		case "em":
+			text = "_" + text + "_"
+		case "code":
+			text = "`" + text + "`"
+		case "strike":
+			text = "~~" + text + "~~"
+		case "link":
+			var attrs struct {
+				Href string `json:"href"`
+			}
+			json.Unmarshal(mark.Attrs, &attrs)
+			text = fmt.Sprintf("[%s](%s)", text, attrs.Href)
+		}
+	}
+	return text
+}
+
+func markdownText(nodes []ADFNode) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		b.WriteString(node.Text)
+		b.WriteString(markdownText(node.Content))
+	}
+	return b.String()
+}
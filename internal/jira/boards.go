@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 // ListBoards returns all boards visible to the authenticated user.
@@ -57,9 +58,38 @@ func (c *Client) GetBoard(boardID int) (*Board, error) {
 	return &board, nil
 }
 
-// ListSprints returns all sprints for a board.
-// Uses the Agile REST API with offset-based pagination.
-func (c *Client) ListSprints(boardID int) ([]Sprint, error) {
+// GetBoardConfiguration returns a board's columns with their mapped statuses
+// and WIP limits, its filter and its estimation field.
+func (c *Client) GetBoardConfiguration(boardID int) (*BoardConfiguration, error) {
+	data, err := c.Get(agileAPIPath("board", strconv.Itoa(boardID), "configuration"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetBoardConfiguration %d: %w", boardID, err)
+	}
+
+	var cfg BoardConfiguration
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("GetBoardConfiguration %d: failed to unmarshal: %w", boardID, err)
+	}
+	return &cfg, nil
+}
+
+// GetFilter retrieves a saved filter, e.g. to read a board filter's JQL.
+func (c *Client) GetFilter(filterID string) (*Filter, error) {
+	data, err := c.Get(c.apiPathFor("filter", filterID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetFilter %s: %w", filterID, err)
+	}
+
+	var filter Filter
+	if err := json.Unmarshal(data, &filter); err != nil {
+		return nil, fmt.Errorf("GetFilter %s: failed to unmarshal: %w", filterID, err)
+	}
+	return &filter, nil
+}
+
+// ListSprints returns the sprints of a board, optionally only those in the given
+// states ("future", "active", "closed"). Uses the Agile REST API with offset-based pagination.
+func (c *Client) ListSprints(boardID int, states ...string) ([]Sprint, error) {
 	var all []Sprint
 	startAt := 0
 	maxResults := 50
@@ -68,6 +98,9 @@ func (c *Client) ListSprints(boardID int) ([]Sprint, error) {
 		q := url.Values{}
 		q.Set("startAt", strconv.Itoa(startAt))
 		q.Set("maxResults", strconv.Itoa(maxResults))
+		if len(states) > 0 {
+			q.Set("state", strings.Join(states, ","))
+		}
 
 		path := agileAPIPath("board", strconv.Itoa(boardID), "sprint")
 		data, err := c.Get(path, q)
@@ -90,3 +123,116 @@ func (c *Client) ListSprints(boardID int) ([]Sprint, error) {
 
 	return all, nil
 }
+
+// GetSprint retrieves a single sprint by ID.
+func (c *Client) GetSprint(sprintID int) (*Sprint, error) {
+	data, err := c.Get(agileAPIPath("sprint", strconv.Itoa(sprintID)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetSprint %d: %w", sprintID, err)
+	}
+
+	var sprint Sprint
+	if err := json.Unmarshal(data, &sprint); err != nil {
+		return nil, fmt.Errorf("GetSprint %d: failed to unmarshal: %w", sprintID, err)
+	}
+	return &sprint, nil
+}
+
+// ListSprintIssues returns the issues in a sprint, optionally narrowed by jql.
+// Only the given fields are requested when fields is non-empty.
+func (c *Client) ListSprintIssues(sprintID int, jql string, fields []string) ([]Issue, error) {
+	issues, err := c.listAgileIssues(agileAPIPath("sprint", strconv.Itoa(sprintID), "issue"), jql, fields, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("ListSprintIssues sprint=%d: %w", sprintID, err)
+	}
+	return issues, nil
+}
+
+// ListSprintRemovedIssueKeys returns the keys of the issues removed from a
+// sprint after it started. The Agile API lists only the issues still in a
+// sprint, so this reads the board's sprint report (a Jira Software internal
+// API available on Cloud and Server/DC).
+func (c *Client) ListSprintRemovedIssueKeys(boardID, sprintID int) ([]string, error) {
+	q := url.Values{}
+	q.Set("rapidViewId", strconv.Itoa(boardID))
+	q.Set("sprintId", strconv.Itoa(sprintID))
+
+	data, err := c.Get(greenhopperPath+"/rapid/charts/sprintreport", q)
+	if err != nil {
+		return nil, fmt.Errorf("ListSprintRemovedIssueKeys sprint=%d: %w", sprintID, err)
+	}
+
+	var report struct {
+		Contents struct {
+			PuntedIssues []struct {
+				Key string `json:"key"`
+			} `json:"puntedIssues"`
+		} `json:"contents"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("ListSprintRemovedIssueKeys sprint=%d: failed to unmarshal: %w", sprintID, err)
+	}
+	keys := make([]string, 0, len(report.Contents.PuntedIssues))
+	for _, issue := range report.Contents.PuntedIssues {
+		keys = append(keys, issue.Key)
+	}
+	return keys, nil
+}
+
+// ListBacklogIssues returns the issues in a board's backlog (not in any active or
+// future sprint), in rank order, optionally narrowed by jql.
+func (c *Client) ListBacklogIssues(boardID int, jql string, fields []string) ([]Issue, error) {
+	return c.ListBacklogIssuesLimited(boardID, jql, fields, 0, 0)
+}
+
+// ListBacklogIssuesLimited returns at most take backlog issues after skipping
+// skip, paging on the server. take <= 0 returns all remaining issues.
+func (c *Client) ListBacklogIssuesLimited(boardID int, jql string, fields []string, skip, take int) ([]Issue, error) {
+	issues, err := c.listAgileIssues(agileAPIPath("board", strconv.Itoa(boardID), "backlog"), jql, fields, skip, take)
+	if err != nil {
+		return nil, fmt.Errorf("ListBacklogIssues board=%d: %w", boardID, err)
+	}
+	return issues, nil
+}
+
+// listAgileIssues pages through an Agile API issue list from skip, stopping
+// after take issues (all of them when take <= 0).
+func (c *Client) listAgileIssues(path, jql string, fields []string, skip, take int) ([]Issue, error) {
+	var all []Issue
+	startAt := max(skip, 0)
+
+	for {
+		maxResults := 100
+		if take > 0 {
+			maxResults = min(take-len(all), maxResults)
+		}
+		q := url.Values{}
+		q.Set("startAt", strconv.Itoa(startAt))
+		q.Set("maxResults", strconv.Itoa(maxResults))
+		if jql != "" {
+			q.Set("jql", jql)
+		}
+		if len(fields) > 0 {
+			q.Set("fields", strings.Join(fields, ","))
+		}
+
+		data, err := c.Get(path, q)
+		if err != nil {
+			return nil, err
+		}
+
+		var result AgileIssueResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal: %w", err)
+		}
+
+		all = append(all, result.Issues...)
+
+		if len(result.Issues) == 0 || startAt+len(result.Issues) >= result.Total || (take > 0 && len(all) >= take) {
+			break
+		}
+		startAt += len(result.Issues)
+	}
+
+	return all, nil
+}
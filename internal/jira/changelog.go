@@ -0,0 +1,63 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ListChangelog returns an issue's full change history, oldest first.
+// Cloud pages through /issue/{key}/changelog; Server/DC has no such endpoint
+// and returns the history with the issue via expand=changelog.
+func (c *Client) ListChangelog(issueKey string) ([]ChangelogHistory, error) {
+	if c.instanceType == InstanceServer {
+		return c.listChangelogV2(issueKey)
+	}
+
+	var all []ChangelogHistory
+	startAt := 0
+	for {
+		q := url.Values{}
+		q.Set("startAt", strconv.Itoa(startAt))
+		q.Set("maxResults", "100")
+
+		data, err := c.Get(c.apiPathFor("issue", issueKey, "changelog"), q)
+		if err != nil {
+			return nil, fmt.Errorf("ListChangelog %s: %w", issueKey, err)
+		}
+
+		var page ChangelogPage
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("ListChangelog %s: failed to unmarshal: %w", issueKey, err)
+		}
+		all = append(all, page.Values...)
+
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+	return all, nil
+}
+
+func (c *Client) listChangelogV2(issueKey string) ([]ChangelogHistory, error) {
+	q := url.Values{}
+	q.Set("expand", "changelog")
+	q.Set("fields", "created")
+
+	data, err := c.Get(c.apiPathFor("issue", issueKey), q)
+	if err != nil {
+		return nil, fmt.Errorf("ListChangelog %s: %w", issueKey, err)
+	}
+
+	var resp struct {
+		Changelog struct {
+			Histories []ChangelogHistory `json:"histories"`
+		} `json:"changelog"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("ListChangelog %s: failed to unmarshal: %w", issueKey, err)
+	}
+	return resp.Changelog.Histories, nil
+}
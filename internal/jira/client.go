@@ -3,6 +3,7 @@ package jira
 import (
 	"bytes"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -12,22 +13,30 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
-	apiV3Path  = "/rest/api/3"
-	apiV2Path  = "/rest/api/2"
-	agilePath  = "/rest/agile/1.0"
-	maxRetries = 3
+	apiV3Path       = "/rest/api/3"
+	apiV2Path       = "/rest/api/2"
+	agilePath       = "/rest/agile/1.0"
+	greenhopperPath = "/rest/greenhopper/1.0"
+	maxRetries      = 3
 )
 
 // Client is the Jira REST API client (supports Cloud and Server/DC).
 type Client struct {
-	baseURL      string
-	authHeader   string
+	baseURL      string // where API requests are sent
+	siteURL      string // the instance URL users browse (differs from baseURL for OAuth)
+	cloudID      string // OAuth only
 	httpClient   *http.Client
 	instanceType InstanceType
+
+	authMu       sync.RWMutex
+	authHeader   string
+	refreshMu    sync.Mutex // serializes refreshToken calls
+	refreshToken func() (string, error)
 }
 
 // NewClient creates a new Jira API client (supports Cloud and Server/DC).
@@ -52,10 +61,22 @@ func NewClient(cfg Config) (*Client, error) {
 		}
 	}
 
+	siteURL := baseURL
+	instanceType := cfg.InstanceType
+	var cloudID string
+
 	var authHeader string
 	switch authType {
 	case AuthBearer:
 		authHeader = "Bearer " + cfg.Token
+	case AuthOAuth:
+		if cfg.CloudID == "" {
+			return nil, fmt.Errorf("jira: cloud ID is required for OAuth")
+		}
+		authHeader = "Bearer " + cfg.Token
+		baseURL = oauthAPIBaseURL + "/ex/jira/" + cfg.CloudID
+		cloudID = cfg.CloudID
+		instanceType = InstanceCloud
 	default: // AuthBasic
 		if cfg.Email == "" {
 			return nil, fmt.Errorf("jira: email is required for basic auth")
@@ -64,18 +85,19 @@ func NewClient(cfg Config) (*Client, error) {
 		authHeader = "Basic " + base64.StdEncoding.EncodeToString([]byte(creds))
 	}
 
-	httpClient := &http.Client{Timeout: 30 * time.Second}
-	if cfg.InsecureSkipVerify {
-		httpClient.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
+	httpClient, err := NewHTTPClient(cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Client{
 		baseURL:      baseURL,
+		siteURL:      siteURL,
+		cloudID:      cloudID,
 		authHeader:   authHeader,
+		refreshToken: cfg.TokenRefresher,
 		httpClient:   httpClient,
-		instanceType: cfg.InstanceType,
+		instanceType: instanceType,
 	}, nil
 }
 
@@ -136,13 +158,15 @@ func (c *Client) request(method, path string, query url.Values, body interface{}
 	}
 
 	var lastErr error
+	refreshed := false
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		req, err := http.NewRequest(method, fullURL, bodyReader)
 		if err != nil {
 			return nil, fmt.Errorf("jira: failed to create request: %w", err)
 		}
 
-		req.Header.Set("Authorization", c.authHeader)
+		auth := c.currentAuthHeader()
+		req.Header.Set("Authorization", auth)
 		req.Header.Set("Accept", "application/json")
 		if body != nil {
 			req.Header.Set("Content-Type", "application/json")
@@ -151,6 +175,9 @@ func (c *Client) request(method, path string, query url.Values, body interface{}
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
 			lastErr = fmt.Errorf("jira: request failed: %w", err)
+			if isCertificateError(err) {
+				return nil, fmt.Errorf("%w\n\nHint: the TLS certificate of %s is not trusted — set a CA bundle with --ca-cert or 'jira-mgmt config set ca_cert_file PATH'", lastErr, c.baseURL)
+			}
 			if isNetworkError(err) {
 				return nil, fmt.Errorf("%w\n\nHint: could not reach %s — check your network connection or corporate VPN", lastErr, c.baseURL)
 			}
@@ -205,6 +232,20 @@ func (c *Client) request(method, path string, query url.Values, body interface{}
 			return nil, lastErr
 		}
 
+		// Expired OAuth access token — refresh once and replay the request.
+		if resp.StatusCode == http.StatusUnauthorized && c.refreshToken != nil && !refreshed {
+			refreshed = true
+			if err := c.refreshAuth(auth); err != nil {
+				return nil, fmt.Errorf("%w (token refresh failed: %v)", parseAPIError(resp.StatusCode, respBody), err)
+			}
+			attempt--
+			if body != nil {
+				data, _ := json.Marshal(body)
+				bodyReader = bytes.NewReader(data)
+			}
+			continue
+		}
+
 		// Client errors (4xx) — don't retry, return immediately.
 		return nil, parseAPIError(resp.StatusCode, respBody)
 	}
@@ -212,6 +253,36 @@ func (c *Client) request(method, path string, query url.Values, body interface{}
 	return nil, lastErr
 }
 
+// currentAuthHeader returns the Authorization header value, safe for concurrent use.
+func (c *Client) currentAuthHeader() string {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.authHeader
+}
+
+// refreshAuth obtains a new OAuth access token and swaps it into the auth header.
+// failed is the header the rejected request was sent with: when it has changed
+// since, a concurrent request already refreshed and its token is reused.
+// Refreshes run one at a time, as each rotates the refresh token.
+func (c *Client) refreshAuth(failed string) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	if c.currentAuthHeader() != failed {
+		return nil
+	}
+	token, err := c.refreshToken()
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return errors.New("empty access token")
+	}
+	c.authMu.Lock()
+	c.authHeader = "Bearer " + token
+	c.authMu.Unlock()
+	return nil
+}
+
 // isNetworkError checks whether the error is a network-level failure
 // (DNS resolution, connection refused, timeout) where retrying won't help
 // and the user likely needs to check VPN or network connectivity.
@@ -234,6 +305,18 @@ func isNetworkError(err error) bool {
 		strings.Contains(msg, "i/o timeout")
 }
 
+// isCertificateError reports TLS verification failures, which retrying cannot fix.
+func isCertificateError(err error) bool {
+	var verifyErr *tls.CertificateVerificationError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	return errors.As(err, &verifyErr) ||
+		errors.As(err, &unknownAuthority) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr)
+}
+
 // backoff returns an exponential backoff duration for the given attempt.
 func backoff(attempt int) time.Duration {
 	d := time.Duration(1<<uint(attempt)) * time.Second
@@ -305,6 +388,7 @@ func agileAPIPath(segments ...string) string {
 }
 
 // BaseURL returns the base URL of the Jira instance.
+// For OAuth clients this is the site URL, not the api.atlassian.com gateway.
 func (c *Client) BaseURL() string {
-	return c.baseURL
+	return c.siteURL
 }
@@ -2,11 +2,18 @@ package jira
 
 import (
 	"encoding/json"
+	"encoding/pem"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 // --- Story 1: HTTP Client & Auth ---
@@ -304,6 +311,7 @@ func TestCreateIssue(t *testing.T) {
 			Summary:   "New story",
 			Description: NewADFText("Story description"),
 			Labels:    []string{"backend"},
+			Components: []ComponentRef{{Name: "Backend"}},
 		},
 	})
 	if err != nil {
@@ -321,6 +329,9 @@ func TestCreateIssue(t *testing.T) {
 	if fields["summary"] != "New story" {
 		t.Errorf("summary = %v, want 'New story'", fields["summary"])
 	}
+	if components, _ := fields["components"].([]interface{}); len(components) != 1 || components[0].(map[string]interface{})["name"] != "Backend" {
+		t.Errorf("components = %v, want [{name: Backend}]", fields["components"])
+	}
 }
 
 func TestCreateIssue_Subtask(t *testing.T) {
@@ -844,6 +855,81 @@ func TestSearchAll_Pagination(t *testing.T) {
 	}
 }
 
+func TestSearchIter_StopsFetchingWhenConsumerStops(t *testing.T) {
+	var mu sync.Mutex
+	callCount := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		callCount++
+		page := callCount
+		mu.Unlock()
+
+		json.NewEncoder(w).Encode(SearchResponse{
+			Issues:        []Issue{{Key: fmt.Sprintf("A-%d", page*2-1)}, {Key: fmt.Sprintf("A-%d", page*2)}},
+			NextPageToken: fmt.Sprintf("tok%d", page+1),
+		})
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	var keys []string
+	for issue, err := range c.SearchIter("project = A", nil) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		keys = append(keys, issue.Key)
+		if len(keys) == 3 {
+			break
+		}
+	}
+
+	if strings.Join(keys, ",") != "A-1,A-2,A-3" {
+		t.Errorf("keys = %v", keys)
+	}
+	// Page 2 is being consumed and page 3 may be prefetched; nothing beyond that.
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if callCount > 3 {
+		t.Errorf("calls = %d, want at most 3", callCount)
+	}
+}
+
+func TestSearchIter_Server_YieldsError(t *testing.T) {
+	callCount := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		if callCount == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorMessages":["bad jql"]}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"startAt": 0, "maxResults": 1, "total": 5,
+			"issues": []Issue{{Key: "A-1"}},
+		})
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: InstanceServer})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	var keys []string
+	var gotErr error
+	for issue, err := range c.SearchIter("project = A", nil) {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		keys = append(keys, issue.Key)
+	}
+	if len(keys) != 1 || gotErr == nil || !strings.Contains(gotErr.Error(), "bad jql") {
+		t.Errorf("keys = %v, err = %v", keys, gotErr)
+	}
+}
+
 // --- Story 6: Comments ---
 
 func TestNewADFText(t *testing.T) {
@@ -1360,3 +1446,1158 @@ func TestListBoards_NoProject(t *testing.T) {
 		t.Errorf("got %d boards, want 1", len(boards))
 	}
 }
+
+// --- OAuth 2.0 (3LO) ---
+
+func TestNewClient_OAuthUsesGatewayURL(t *testing.T) {
+	c, err := NewClient(Config{
+		BaseURL:  "https://test.atlassian.net",
+		Token:    "access-token",
+		AuthType: AuthOAuth,
+		CloudID:  "cloud-123",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.baseURL != "https://api.atlassian.com/ex/jira/cloud-123" {
+		t.Errorf("baseURL = %q", c.baseURL)
+	}
+	if c.BaseURL() != "https://test.atlassian.net" {
+		t.Errorf("BaseURL() = %q, want site URL", c.BaseURL())
+	}
+	if c.instanceType != InstanceCloud {
+		t.Errorf("instanceType = %q, want cloud", c.instanceType)
+	}
+	if c.authHeader != "Bearer access-token" {
+		t.Errorf("authHeader = %q", c.authHeader)
+	}
+}
+
+func TestNewClient_OAuthRequiresCloudID(t *testing.T) {
+	_, err := NewClient(Config{
+		BaseURL:  "https://test.atlassian.net",
+		Token:    "access-token",
+		AuthType: AuthOAuth,
+	})
+	if err == nil {
+		t.Fatal("expected error for missing cloud ID")
+	}
+}
+
+func TestClient_OAuthRefreshOn401(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if !strings.HasPrefix(r.URL.Path, "/ex/jira/cloud-123/") {
+			t.Errorf("path = %q, want gateway prefix", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errorMessages":["expired"]}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	prev := oauthAPIBaseURL
+	oauthAPIBaseURL = srv.URL
+	defer func() { oauthAPIBaseURL = prev }()
+
+	refreshes := 0
+	c, err := NewClient(Config{
+		BaseURL:  "https://test.atlassian.net",
+		Token:    "stale",
+		AuthType: AuthOAuth,
+		CloudID:  "cloud-123",
+		TokenRefresher: func() (string, error) {
+			refreshes++
+			return "fresh", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := c.Post("/rest/api/3/test", map[string]string{"a": "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `{"ok":true}` {
+		t.Errorf("body = %s", data)
+	}
+	if refreshes != 1 || calls != 2 {
+		t.Errorf("refreshes = %d, calls = %d; want 1 and 2", refreshes, calls)
+	}
+}
+
+func TestClient_OAuthRefreshOn401_Concurrent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errorMessages":["expired"]}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	prev := oauthAPIBaseURL
+	oauthAPIBaseURL = srv.URL
+	defer func() { oauthAPIBaseURL = prev }()
+
+	// The refresh token rotates: only the first refresh succeeds.
+	var mu sync.Mutex
+	refreshes := 0
+	c, err := NewClient(Config{
+		BaseURL:  "https://test.atlassian.net",
+		Token:    "stale",
+		AuthType: AuthOAuth,
+		CloudID:  "cloud-123",
+		TokenRefresher: func() (string, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			refreshes++
+			if refreshes > 1 {
+				return "", fmt.Errorf("invalid_grant: refresh token already used")
+			}
+			time.Sleep(10 * time.Millisecond)
+			return "fresh", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			if _, err := c.Get("/rest/api/3/test", nil); err != nil {
+				t.Errorf("Get: %v", err)
+			}
+		})
+	}
+	wg.Wait()
+	if refreshes != 1 {
+		t.Errorf("refreshes = %d, want 1", refreshes)
+	}
+}
+
+func TestOAuthConfig_ExchangeAndRefresh(t *testing.T) {
+	var grants []map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		grants = append(grants, body)
+		switch body["grant_type"] {
+		case "authorization_code":
+			w.Write([]byte(`{"access_token":"a1","refresh_token":"r1","expires_in":3600}`))
+		case "refresh_token":
+			if body["refresh_token"] != "r1" {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"error":"invalid_grant","error_description":"Unknown or invalid refresh token."}`))
+				return
+			}
+			w.Write([]byte(`{"access_token":"a2","expires_in":3600}`))
+		}
+	}))
+	defer srv.Close()
+
+	o := OAuthConfig{ClientID: "cid", ClientSecret: "secret", RedirectURL: "http://localhost/cb", TokenURL: srv.URL}
+
+	tok, err := o.Exchange("code-1", "verifier-1")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if tok.AccessToken != "a1" || tok.RefreshToken != "r1" {
+		t.Errorf("Exchange token = %+v", tok)
+	}
+	if grants[0]["code_verifier"] != "verifier-1" || grants[0]["client_secret"] != "secret" {
+		t.Errorf("exchange body = %v", grants[0])
+	}
+
+	tok, err = o.Refresh("r1")
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if tok.AccessToken != "a2" || tok.RefreshToken != "r1" {
+		t.Errorf("Refresh should keep the old refresh token when none is rotated, got %+v", tok)
+	}
+
+	_, err = o.Refresh("bogus")
+	if err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Errorf("expected invalid_grant error, got %v", err)
+	}
+}
+
+func TestOAuthConfig_AuthCodeURL(t *testing.T) {
+	o := OAuthConfig{ClientID: "cid", RedirectURL: "http://localhost:8765/callback"}
+	u := o.AuthCodeURL("st", "ch")
+	for _, want := range []string{"client_id=cid", "state=st", "code_challenge=ch", "code_challenge_method=S256", "offline_access", "audience=api.atlassian.com"} {
+		if !strings.Contains(u, want) {
+			t.Errorf("AuthCodeURL missing %q: %s", want, u)
+		}
+	}
+}
+
+// --- Credential health ---
+
+func TestGetServerInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/serverInfo" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		w.Write([]byte(`{"version":"1001.0.0","deploymentType":"Cloud","buildNumber":100275}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	info, err := c.GetServerInfo()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.DeploymentType != "Cloud" || info.BuildNumber != 100275 {
+		t.Errorf("info = %+v", info)
+	}
+}
+
+func TestGetMyPermissions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/mypermissions" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("projectKey"); got != "PROJ" {
+			t.Errorf("projectKey = %q", got)
+		}
+		if got := r.URL.Query().Get("permissions"); got != "BROWSE_PROJECTS,DELETE_ISSUES" {
+			t.Errorf("permissions = %q", got)
+		}
+		w.Write([]byte(`{"permissions":{
+			"BROWSE_PROJECTS":{"id":"10","key":"BROWSE_PROJECTS","type":"PROJECT","havePermission":true},
+			"DELETE_ISSUES":{"id":"16","key":"DELETE_ISSUES","type":"PROJECT","havePermission":false}}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	perms, err := c.GetMyPermissions("PROJ", []string{PermBrowseProjects, PermDeleteIssues})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !perms[PermBrowseProjects].HavePermission || perms[PermDeleteIssues].HavePermission {
+		t.Errorf("perms = %+v", perms)
+	}
+}
+
+func TestListPersonalAccessTokens(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/pat/latest/tokens" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		w.Write([]byte(`[{"id":1,"name":"ci","createdAt":"2026-01-01T10:00:00.000+0000","expiringAt":"2026-07-01T10:00:00.000+0000"}]`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	tokens, err := c.ListPersonalAccessTokens()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].Name != "ci" || tokens[0].ExpiringAt == "" {
+		t.Errorf("tokens = %+v", tokens)
+	}
+}
+
+// --- Transport settings ---
+
+func TestMatchesNoProxy(t *testing.T) {
+	tests := []struct {
+		host    string
+		noProxy string
+		want    bool
+	}{
+		{"jira.corp.example.com", "corp.example.com", true},
+		{"jira.corp.example.com", ".example.com", true},
+		{"example.com", ".example.com", true},
+		{"notexample.com", "example.com", false},
+		{"jira.corp.example.com:8443", "jira.corp.example.com:8443", true},
+		{"jira.corp.example.com:443", "jira.corp.example.com:8443", false},
+		{"10.1.2.3:8080", "10.0.0.0/8", true},
+		{"192.168.1.1", "10.0.0.0/8", false},
+		{"127.0.0.1", "localhost, 127.0.0.1", true},
+		{"anything", "*", true},
+		{"jira.example.com", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchesNoProxy(tt.host, tt.noProxy); got != tt.want {
+			t.Errorf("matchesNoProxy(%q, %q) = %v, want %v", tt.host, tt.noProxy, got, tt.want)
+		}
+	}
+}
+
+func TestNewHTTPClient_CustomCA(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0o600); err != nil {
+		t.Fatalf("write CA: %v", err)
+	}
+
+	// Without the CA the self-signed server is rejected.
+	plain, err := NewClient(Config{BaseURL: srv.URL, Token: "t"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := plain.Get("/test", nil); err == nil {
+		t.Fatal("expected certificate error without CA bundle")
+	}
+
+	trusted, err := NewClient(Config{BaseURL: srv.URL, Token: "t", CACertFile: caFile})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := trusted.Get("/test", nil); err != nil {
+		t.Fatalf("request with CA bundle failed: %v", err)
+	}
+}
+
+func TestNewHTTPClient_InvalidSettings(t *testing.T) {
+	if _, err := NewHTTPClient(Config{CACertFile: filepath.Join(t.TempDir(), "missing.pem")}); err == nil {
+		t.Error("expected error for missing CA bundle")
+	}
+	if _, err := NewHTTPClient(Config{ClientCertFile: "cert.pem"}); err == nil {
+		t.Error("expected error for client cert without key")
+	}
+	if _, err := NewHTTPClient(Config{ProxyURL: "://bad"}); err == nil {
+		t.Error("expected error for invalid proxy URL")
+	}
+}
+
+func TestNewHTTPClient_ProxyAndTimeout(t *testing.T) {
+	hc, err := NewHTTPClient(Config{ProxyURL: "http://proxy.corp:3128", NoProxy: ".internal", Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("NewHTTPClient: %v", err)
+	}
+	if hc.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, want 5s", hc.Timeout)
+	}
+
+	transport := hc.Transport.(*http.Transport)
+	req, _ := http.NewRequest(http.MethodGet, "https://jira.example.com/rest", nil)
+	proxy, _ := transport.Proxy(req)
+	if proxy == nil || proxy.Host != "proxy.corp:3128" {
+		t.Errorf("proxy = %v, want proxy.corp:3128", proxy)
+	}
+	req, _ = http.NewRequest(http.MethodGet, "https://jira.internal/rest", nil)
+	if proxy, _ := transport.Proxy(req); proxy != nil {
+		t.Errorf("proxy for no_proxy host = %v, want direct", proxy)
+	}
+}
+
+// --- Field catalog ---
+
+func TestListFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/field" {
+			t.Errorf("path = %s, want /rest/api/3/field", r.URL.Path)
+		}
+		w.Write([]byte(`[
+			{"id":"summary","name":"Summary","custom":false,"orderable":true,"searchable":true,"clauseNames":["summary"],"schema":{"type":"string","system":"summary"}},
+			{"id":"customfield_10016","name":"Story Points","custom":true,"orderable":true,"searchable":true,"clauseNames":["cf[10016]","Story Points"],"schema":{"type":"number","custom":"com.atlassian.jira.plugin.system.customfieldtypes:float","customId":10016}},
+			{"id":"customfield_10020","name":"Sprint","custom":true,"clauseNames":["sprint"],"schema":{"type":"array","items":"json","customId":10020}}
+		]`))
+	}))
+	defer srv.Close()
+
+	fields, err := newTestClient(t, srv.URL).ListFields()
+	if err != nil {
+		t.Fatalf("ListFields: %v", err)
+	}
+	if len(fields) != 3 {
+		t.Fatalf("got %d fields, want 3", len(fields))
+	}
+	if got := fields[0].JQLClause(); got != "summary" {
+		t.Errorf("summary clause = %q", got)
+	}
+	if got := fields[1].JQLClause(); got != "cf[10016]" {
+		t.Errorf("story points clause = %q", got)
+	}
+	if got := fields[2].Schema.TypeName(); got != "array<json>" {
+		t.Errorf("sprint type = %q", got)
+	}
+}
+
+func TestIssueFields_CustomFieldsRoundTrip(t *testing.T) {
+	var issue Issue
+	data := `{"key":"P-1","fields":{"summary":"S","customfield_10016":5,"customfield_10050":{"value":"Core"},"customfield_10060":null}}`
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if issue.Fields.Summary != "S" {
+		t.Errorf("summary = %q", issue.Fields.Summary)
+	}
+	if len(issue.Fields.CustomFields) != 2 || string(issue.Fields.CustomFields["customfield_10016"]) != "5" {
+		t.Errorf("custom fields = %v", issue.Fields.CustomFields)
+	}
+
+	out, err := json.Marshal(issue.Fields)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(out), `"customfield_10050":{"value":"Core"}`) || !strings.Contains(string(out), `"summary":"S"`) {
+		t.Errorf("marshal = %s", out)
+	}
+}
+
+// --- ADF rendering ---
+
+func TestADFDoc_UnmarshalServerString(t *testing.T) {
+	var resp CommentsResponse
+	data := `{"total":1,"comments":[{"id":"7","body":"line one\nline two"}]}`
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got := resp.Comments[0].Body.PlainText(); got != "line one\nline two" {
+		t.Errorf("PlainText = %q", got)
+	}
+}
+
+func TestADFDoc_Markdown(t *testing.T) {
+	doc := `{"type":"doc","version":1,"content":[
+		{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Plan"}]},
+		{"type":"paragraph","content":[{"type":"text","text":"Ping "},{"type":"mention","attrs":{"text":"@ann"}},{"type":"text","text":" about "},{"type":"text","text":"retries","marks":[{"type":"code"}]}]},
+		{"type":"bulletList","content":[
+			{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"one","marks":[{"type":"em"}]}]}]},
+			{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"two"}]},
+				{"type":"orderedList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"nested"}]}]}]}]}]},
+		{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"x := 1"}]},
+		{"type":"paragraph","content":[{"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://x.dev"}}]}]},
+		{"type":"paragraph","content":[{"type":"text","text":"Due "},{"type":"date","attrs":{"timestamp":"1793491200000"}},{"type":"text","text":" or "},{"type":"date","attrs":{"timestamp":1793577600000}}]}
+	]}`
+	var d ADFDoc
+	if err := json.Unmarshal([]byte(doc), &d); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	want := "## Plan\n\nPing @ann about `retries`\n\n- _one_\n- two\n  1. nested\n\n```go\nx := 1\n```\n\n[docs](https://x.dev)\n\nDue 2026-11-01 or 2026-11-02"
+	if got := d.Markdown(); got != want {
+		t.Errorf("Markdown =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// --- Agile sprints and backlog ---
+
+func TestListSprints_State(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("state"); got != "active,future" {
+			t.Errorf("state = %q", got)
+		}
+		w.Write([]byte(`{"isLast":true,"values":[{"id":1,"name":"S1","state":"active"}]}`))
+	}))
+	defer srv.Close()
+
+	sprints, err := newTestClient(t, srv.URL).ListSprints(3, "active", "future")
+	if err != nil || len(sprints) != 1 {
+		t.Fatalf("ListSprints = %v, %v", sprints, err)
+	}
+}
+
+func TestListBacklogIssues_Pagination(t *testing.T) {
+	var starts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/agile/1.0/board/3/backlog" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		start := r.URL.Query().Get("startAt")
+		starts = append(starts, start)
+		if start == "0" {
+			w.Write([]byte(`{"startAt":0,"maxResults":2,"total":3,"issues":[{"key":"B-1"},{"key":"B-2"}]}`))
+			return
+		}
+		w.Write([]byte(`{"startAt":2,"maxResults":2,"total":3,"issues":[{"key":"B-3"}]}`))
+	}))
+	defer srv.Close()
+
+	issues, err := newTestClient(t, srv.URL).ListBacklogIssues(3, "", []string{"summary"})
+	if err != nil {
+		t.Fatalf("ListBacklogIssues: %v", err)
+	}
+	if len(issues) != 3 || issues[2].Key != "B-3" {
+		t.Errorf("issues = %v", issues)
+	}
+	if len(starts) != 2 || starts[1] != "2" {
+		t.Errorf("startAt = %v", starts)
+	}
+}
+
+// --- Sprint management ---
+
+func TestStartSprint_SendsStateAndDates(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/agile/1.0/sprint/42" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"id":42,"name":"Sprint 42","state":"active"}`))
+	}))
+	defer srv.Close()
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	sprint, err := newTestClient(t, srv.URL).StartSprint(42, start, start.AddDate(0, 0, 14))
+	if err != nil {
+		t.Fatalf("StartSprint: %v", err)
+	}
+	if sprint.State != "active" {
+		t.Errorf("state = %q", sprint.State)
+	}
+	if body["state"] != "active" || body["startDate"] != "2026-03-02T09:00:00Z" || body["endDate"] != "2026-03-16T09:00:00Z" {
+		t.Errorf("body = %v", body)
+	}
+	if _, ok := body["name"]; ok {
+		t.Errorf("unset name sent: %v", body)
+	}
+}
+
+func TestMoveIssuesToSprint_Batches(t *testing.T) {
+	var batches []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/agile/1.0/sprint/7/issue" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var body struct {
+			Issues []string `json:"issues"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		batches = append(batches, len(body.Issues))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	keys := make([]string, 120)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("P-%d", i+1)
+	}
+	if err := newTestClient(t, srv.URL).MoveIssuesToSprint(7, keys); err != nil {
+		t.Fatalf("MoveIssuesToSprint: %v", err)
+	}
+	if len(batches) != 3 || batches[0] != 50 || batches[2] != 20 {
+		t.Errorf("batches = %v", batches)
+	}
+}
+
+func TestMoveIssuesToBacklog(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/agile/1.0/backlog/issue" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	if err := newTestClient(t, srv.URL).MoveIssuesToBacklog([]string{"P-1"}); err != nil {
+		t.Fatalf("MoveIssuesToBacklog: %v", err)
+	}
+}
+
+func TestRankIssues_PartialFailure(t *testing.T) {
+	var req RankRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/rest/agile/1.0/issue/rank" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		w.WriteHeader(http.StatusMultiStatus)
+		w.Write([]byte(`{"entries":[{"issueKey":"P-2","status":200},{"issueKey":"P-3","status":403,"errors":["no permission"]}]}`))
+	}))
+	defer srv.Close()
+
+	err := newTestClient(t, srv.URL).RankIssues([]string{"P-2", "P-3"}, "P-1", "")
+	if err == nil || !strings.Contains(err.Error(), "P-3: no permission") {
+		t.Fatalf("err = %v, want P-3 failure", err)
+	}
+	if req.RankBeforeIssue != "P-1" || req.RankAfterIssue != "" || len(req.Issues) != 2 {
+		t.Errorf("request = %+v", req)
+	}
+}
+
+func TestRankIssues_RequiresOneAnchor(t *testing.T) {
+	c := newTestClient(t, "http://unused")
+	if err := c.RankIssues([]string{"P-1"}, "", ""); err == nil {
+		t.Error("expected error without anchor")
+	}
+	if err := c.RankIssues([]string{"P-1"}, "P-2", "P-3"); err == nil {
+		t.Error("expected error with both anchors")
+	}
+}
+
+// --- Changelog ---
+
+func TestListChangelog_CloudPagination(t *testing.T) {
+	var starts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/P-1/changelog" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		start := r.URL.Query().Get("startAt")
+		starts = append(starts, start)
+		if start == "0" {
+			w.Write([]byte(`{"startAt":0,"maxResults":1,"total":2,"isLast":false,"values":[{"id":"1","created":"2026-03-02T10:00:00.000+0000","items":[{"field":"status","from":"1","fromString":"To Do","to":"3","toString":"In Progress"}]}]}`))
+			return
+		}
+		w.Write([]byte(`{"startAt":1,"maxResults":1,"total":2,"isLast":true,"values":[{"id":"2","created":"2026-03-03T10:00:00.000+0000","items":[{"field":"Sprint","fieldId":"customfield_10020","from":"","to":"7"}]}]}`))
+	}))
+	defer srv.Close()
+
+	histories, err := newTestClient(t, srv.URL).ListChangelog("P-1")
+	if err != nil {
+		t.Fatalf("ListChangelog: %v", err)
+	}
+	if len(histories) != 2 || histories[0].Items[0].ToString != "In Progress" || histories[1].Items[0].FieldID != "customfield_10020" {
+		t.Errorf("histories = %+v", histories)
+	}
+	if len(starts) != 2 || starts[1] != "1" {
+		t.Errorf("startAt = %v", starts)
+	}
+}
+
+func TestListChangelog_ServerExpand(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/P-1" || r.URL.Query().Get("expand") != "changelog" {
+			t.Errorf("request = %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"key":"P-1","changelog":{"startAt":0,"maxResults":1,"total":1,"histories":[{"id":"1","created":"2026-03-02T10:00:00.000+0000","items":[{"field":"status","from":"1","to":"3"}]}]}}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: InstanceServer})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	histories, err := c.ListChangelog("P-1")
+	if err != nil {
+		t.Fatalf("ListChangelog: %v", err)
+	}
+	if len(histories) != 1 || histories[0].Items[0].To != "3" {
+		t.Errorf("histories = %+v", histories)
+	}
+}
+
+// --- Board configuration ---
+
+func TestGetBoardConfiguration(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/agile/1.0/board/9/configuration" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"id":9,"name":"Team","type":"kanban","filter":{"id":"100"},
+			"subQuery":{"query":"resolution is EMPTY"},
+			"columnConfig":{"constraintType":"issueCount","columns":[
+				{"name":"To Do","statuses":[{"id":"1"}]},
+				{"name":"Doing","statuses":[{"id":"3"},{"id":"4"}],"min":1,"max":5}]},
+			"estimation":{"type":"field","field":{"fieldId":"customfield_10016","displayName":"Story Points"}}}`))
+	}))
+	defer srv.Close()
+
+	cfg, err := newTestClient(t, srv.URL).GetBoardConfiguration(9)
+	if err != nil {
+		t.Fatalf("GetBoardConfiguration: %v", err)
+	}
+	if cfg.Filter.ID != "100" || cfg.SubQuery.Query != "resolution is EMPTY" || cfg.Estimation.Field.FieldID != "customfield_10016" {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	doing := cfg.ColumnConfig.Columns[1]
+	if *doing.Min != 1 || *doing.Max != 5 || cfg.ColumnConfig.Columns[0].Max != nil {
+		t.Errorf("columns = %+v", cfg.ColumnConfig.Columns)
+	}
+	if i, ok := cfg.ColumnFor("4"); !ok || i != 1 {
+		t.Errorf("ColumnFor(4) = %d, %v", i, ok)
+	}
+	if _, ok := cfg.ColumnFor("99"); ok {
+		t.Error("ColumnFor(99) mapped an unknown status")
+	}
+}
+
+func TestGetFilter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/filter/100" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"id":"100","name":"Team board","jql":"project = TEAM ORDER BY Rank ASC"}`))
+	}))
+	defer srv.Close()
+
+	filter, err := newTestClient(t, srv.URL).GetFilter("100")
+	if err != nil {
+		t.Fatalf("GetFilter: %v", err)
+	}
+	if filter.JQL != "project = TEAM ORDER BY Rank ASC" {
+		t.Errorf("jql = %q", filter.JQL)
+	}
+}
+
+// --- Versions ---
+
+func TestFindVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/project/PROJ/versions" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Write([]byte(`[{"id":"10010","name":"2.2","released":true,"releaseDate":"2026-02-27"},
+			{"id":"10011","name":"2.3","releaseDate":"2026-03-13","overdue":true}]`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+	v, err := client.FindVersion("PROJ", "2.3")
+	if err != nil {
+		t.Fatalf("FindVersion: %v", err)
+	}
+	if v.ID != "10011" || !v.Overdue || v.Released {
+		t.Errorf("version = %+v", v)
+	}
+	if v, err := client.FindVersion("PROJ", "10010"); err != nil || v.Name != "2.2" {
+		t.Errorf("FindVersion by ID = %+v, %v", v, err)
+	}
+	if _, err := client.FindVersion("PROJ", "9.9"); err == nil {
+		t.Error("expected an error for an unknown version")
+	}
+}
+
+func TestCreateVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/3/version" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "2.3" || body["projectId"] != float64(10000) || body["releaseDate"] != "2026-03-13" {
+			t.Errorf("body = %v", body)
+		}
+		if _, ok := body["startDate"]; ok {
+			t.Errorf("empty startDate sent: %v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"10011","name":"2.3","projectId":10000,"releaseDate":"2026-03-13"}`))
+	}))
+	defer srv.Close()
+
+	v, err := newTestClient(t, srv.URL).CreateVersion(&CreateVersionRequest{Name: "2.3", ProjectID: 10000, ReleaseDate: "2026-03-13"})
+	if err != nil {
+		t.Fatalf("CreateVersion: %v", err)
+	}
+	if v.ID != "10011" {
+		t.Errorf("id = %s", v.ID)
+	}
+}
+
+func TestReleaseVersion_Server(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/rest/api/2/version/10011" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body) != 2 || body["released"] != true || body["releaseDate"] != "2026-03-13" {
+			t.Errorf("body = %v", body)
+		}
+		w.Write([]byte(`{"id":"10011","name":"2.3","released":true,"releaseDate":"2026-03-13"}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: InstanceServer})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	v, err := c.ReleaseVersion("10011", "2026-03-13")
+	if err != nil {
+		t.Fatalf("ReleaseVersion: %v", err)
+	}
+	if !v.Released {
+		t.Errorf("version = %+v", v)
+	}
+}
+
+// --- Components ---
+
+func TestListComponents(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/project/PROJ/components" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Write([]byte(`[{"id":"10050","name":"Backend","lead":{"accountId":"a1","displayName":"Ann"},
+			"assigneeType":"COMPONENT_LEAD","assignee":{"accountId":"a1","displayName":"Ann"}},
+			{"id":"10051","name":"Web","assigneeType":"PROJECT_DEFAULT"}]`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	components, err := c.ListComponents("PROJ")
+	if err != nil {
+		t.Fatalf("ListComponents: %v", err)
+	}
+	if len(components) != 2 || components[0].Lead.DisplayName != "Ann" || components[0].AssigneeType != "COMPONENT_LEAD" {
+		t.Errorf("components = %+v", components)
+	}
+	if comp, err := c.FindComponent("PROJ", "web"); err != nil || comp.ID != "10051" {
+		t.Errorf("FindComponent(web) = %+v, %v", comp, err)
+	}
+}
+
+func TestCreateComponent_Server(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/2/component" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["project"] != "PROJ" || body["name"] != "Backend" || body["leadUserName"] != "ann" || body["assigneeType"] != "COMPONENT_LEAD" {
+			t.Errorf("body = %v", body)
+		}
+		if _, ok := body["leadAccountId"]; ok {
+			t.Errorf("leadAccountId sent to Server: %v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"10050","name":"Backend"}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: InstanceServer})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	comp, err := c.CreateComponent(&CreateComponentRequest{Project: "PROJ", Name: "Backend", LeadUserName: "ann", AssigneeType: "COMPONENT_LEAD"})
+	if err != nil {
+		t.Fatalf("CreateComponent: %v", err)
+	}
+	if comp.ID != "10050" {
+		t.Errorf("id = %s", comp.ID)
+	}
+}
+
+func TestUpdateComponent_ClearLead(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/rest/api/3/component/10050" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body) != 1 || body["leadAccountId"] != "" {
+			t.Errorf("body = %v, want only an empty leadAccountId", body)
+		}
+		w.Write([]byte(`{"id":"10050","name":"Backend"}`))
+	}))
+	defer srv.Close()
+
+	empty := ""
+	if _, err := newTestClient(t, srv.URL).UpdateComponent("10050", &UpdateComponentRequest{LeadAccountID: &empty}); err != nil {
+		t.Fatalf("UpdateComponent: %v", err)
+	}
+}
+
+// --- Users ---
+
+func TestSearchUsers_Server(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/user/search" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if q := r.URL.Query(); q.Get("username") != "alice" || q.Get("query") != "" {
+			t.Errorf("query = %v, want username=alice", q)
+		}
+		w.Write([]byte(`[{"name":"alice","key":"JIRAUSER10100","displayName":"Alice Smith","emailAddress":"alice@corp.com"}]`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: InstanceServer})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	users, err := c.SearchUsers("alice", 10)
+	if err != nil {
+		t.Fatalf("SearchUsers: %v", err)
+	}
+	if len(users) != 1 {
+		t.Fatalf("users = %+v", users)
+	}
+	if ref := users[0].Ref(); ref.Name != "alice" || ref.AccountID != "" {
+		t.Errorf("ref = %+v, want name alice", ref)
+	}
+}
+
+func TestFindAssignableUsers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/user/assignable/search" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if q := r.URL.Query(); q.Get("query") != "alice" || q.Get("issueKey") != "PROJ-1" || q.Has("project") {
+			t.Errorf("query = %v", q)
+		}
+		w.Write([]byte(`[{"accountId":"a1","displayName":"Alice Smith"}]`))
+	}))
+	defer srv.Close()
+
+	users, err := newTestClient(t, srv.URL).FindAssignableUsers("", "PROJ-1", "alice")
+	if err != nil {
+		t.Fatalf("FindAssignableUsers: %v", err)
+	}
+	if len(users) != 1 || users[0].AccountID != "a1" {
+		t.Errorf("users = %+v", users)
+	}
+}
+
+func TestAssignIssue(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/rest/api/3/issue/PROJ-1/assignee" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, strings.TrimSpace(string(data)))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	if err := c.AssignIssue("PROJ-1", &UserRef{AccountID: "a1"}); err != nil {
+		t.Fatalf("AssignIssue: %v", err)
+	}
+	if err := c.AssignIssue("PROJ-1", nil); err != nil {
+		t.Fatalf("AssignIssue(nil): %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != `{"accountId":"a1"}` || bodies[1] != `{"accountId":null}` {
+		t.Errorf("bodies = %q", bodies)
+	}
+}
+
+// --- Watchers and votes ---
+
+func TestWatchers(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/PROJ-1/watchers" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		data, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.RawQuery+" "+string(data))
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"isWatching":true,"watchCount":2,"watchers":[{"accountId":"a1","displayName":"Ann"},{"accountId":"b2","displayName":"Bob"}]}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	watchers, err := c.GetWatchers("PROJ-1")
+	if err != nil {
+		t.Fatalf("GetWatchers: %v", err)
+	}
+	if watchers.WatchCount != 2 || !watchers.IsWatching || watchers.Watchers[1].DisplayName != "Bob" {
+		t.Errorf("watchers = %+v", watchers)
+	}
+	if err := c.AddWatcher("PROJ-1", &UserRef{AccountID: "c3"}); err != nil {
+		t.Fatalf("AddWatcher: %v", err)
+	}
+	if err := c.RemoveWatcher("PROJ-1", &UserRef{AccountID: "c3"}); err != nil {
+		t.Fatalf("RemoveWatcher: %v", err)
+	}
+	want := []string{"GET  ", `POST  "c3"`, "DELETE accountId=c3 "}
+	if len(requests) != len(want) {
+		t.Fatalf("requests = %q", requests)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("request %d = %q, want %q", i, requests[i], want[i])
+		}
+	}
+}
+
+func TestRemoveWatcher_Server(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/rest/api/2/issue/PROJ-1/watchers" || r.URL.Query().Get("username") != "alice" {
+			t.Errorf("%s %s?%s", r.Method, r.URL.Path, r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: InstanceServer})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if err := c.RemoveWatcher("PROJ-1", &UserRef{Name: "alice"}); err != nil {
+		t.Fatalf("RemoveWatcher: %v", err)
+	}
+}
+
+func TestVotes(t *testing.T) {
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/PROJ-1/votes" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		methods = append(methods, r.Method)
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"votes":3,"hasVoted":false,"voters":[{"accountId":"a1","displayName":"Ann"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	votes, err := c.GetVotes("PROJ-1")
+	if err != nil {
+		t.Fatalf("GetVotes: %v", err)
+	}
+	if votes.Votes != 3 || votes.HasVoted || len(votes.Voters) != 1 {
+		t.Errorf("votes = %+v", votes)
+	}
+	if err := c.AddVote("PROJ-1"); err != nil {
+		t.Fatalf("AddVote: %v", err)
+	}
+	if err := c.RemoveVote("PROJ-1"); err != nil {
+		t.Fatalf("RemoveVote: %v", err)
+	}
+	if strings.Join(methods, ",") != "GET,POST,DELETE" {
+		t.Errorf("methods = %v", methods)
+	}
+}
+
+// --- Bulk create and issue links ---
+
+func TestCreateIssuesBulk(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/3/issue/bulk" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			IssueUpdates []struct {
+				Fields map[string]any `json:"fields"`
+			} `json:"issueUpdates"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body.IssueUpdates) != 2 || body.IssueUpdates[1].Fields["summary"] != "Two" {
+			t.Errorf("body = %+v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"issues":[{"id":"10","key":"PROJ-10"}],"errors":[{"status":400,"failedElementNumber":1,"elementErrors":{"errors":{"summary":"too long"}}}]}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	resp, err := c.CreateIssuesBulk([]*CreateIssueRequest{
+		{Fields: CreateIssueFields{Project: ProjectRef{Key: "PROJ"}, IssueType: IssueTypeRef{Name: "Task"}, Summary: "One"}},
+		{Fields: CreateIssueFields{Project: ProjectRef{Key: "PROJ"}, IssueType: IssueTypeRef{Name: "Task"}, Summary: "Two"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateIssuesBulk: %v", err)
+	}
+	if len(resp.Issues) != 1 || resp.Issues[0].Key != "PROJ-10" {
+		t.Errorf("issues = %+v", resp.Issues)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].FailedElementNumber != 1 || resp.Errors[0].ElementErrors.Error() != "summary: too long" {
+		t.Errorf("errors = %+v", resp.Errors)
+	}
+}
+
+func TestCreateIssue_ServerDescriptionIsText(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Fields map[string]any `json:"fields"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Fields["description"] != "Plain text" {
+			t.Errorf("description = %#v", body.Fields["description"])
+		}
+		w.Write([]byte(`{"id":"1","key":"PROJ-1"}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: InstanceServer})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	_, err = c.CreateIssue(&CreateIssueRequest{Fields: CreateIssueFields{
+		Project: ProjectRef{Key: "PROJ"}, IssueType: IssueTypeRef{Name: "Task"}, Summary: "S",
+		Description: NewADFText("Plain text"),
+	}})
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+}
+
+func TestCreateIssueLink(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/3/issueLink" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Type         map[string]string `json:"type"`
+			InwardIssue  IssueRef          `json:"inwardIssue"`
+			OutwardIssue IssueRef          `json:"outwardIssue"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Type["name"] != "Blocks" || body.InwardIssue.Key != "PROJ-1" || body.OutwardIssue.Key != "PROJ-2" {
+			t.Errorf("body = %+v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	if err := c.CreateIssueLink("Blocks", "PROJ-1", "PROJ-2"); err != nil {
+		t.Fatalf("CreateIssueLink: %v", err)
+	}
+}
+
+func TestListIssueLinkTypes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issueLinkType" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"issueLinkTypes":[{"id":"1","name":"Blocks","inward":"is blocked by","outward":"blocks"}]}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	types, err := c.ListIssueLinkTypes()
+	if err != nil {
+		t.Fatalf("ListIssueLinkTypes: %v", err)
+	}
+	if len(types) != 1 || types[0].Inward != "is blocked by" {
+		t.Errorf("types = %+v", types)
+	}
+}
+
+func TestClient_OAuthScopes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth/token/accessible-resources" || r.Header.Get("Authorization") != "Bearer access-token" {
+			t.Errorf("unexpected request %s (%s)", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(`[{"id":"other","scopes":[]},{"id":"cloud-123","url":"https://test.atlassian.net","scopes":["read:jira-work","write:jira-work","read:jira-user","manage:jira-project"]}]`))
+	}))
+	defer srv.Close()
+
+	prev := oauthAPIBaseURL
+	oauthAPIBaseURL = srv.URL
+	defer func() { oauthAPIBaseURL = prev }()
+
+	c, err := NewClient(Config{BaseURL: "https://test.atlassian.net", Token: "access-token", AuthType: AuthOAuth, CloudID: "cloud-123"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	granted, err := c.OAuthScopes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	missing := MissingOAuthScopes(granted)
+	if len(missing) != 7 || missing[0] != "read:board-scope:jira-software" || slices.Contains(missing, "offline_access") {
+		t.Errorf("missing = %v, want the Jira Software and granular issue scopes", missing)
+	}
+}
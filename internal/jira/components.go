@@ -0,0 +1,63 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ListComponents returns all components of a project.
+func (c *Client) ListComponents(projectKey string) ([]Component, error) {
+	data, err := c.Get(c.apiPathFor("project", projectKey, "components"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("ListComponents %s: %w", projectKey, err)
+	}
+
+	var components []Component
+	if err := json.Unmarshal(data, &components); err != nil {
+		return nil, fmt.Errorf("ListComponents %s: failed to unmarshal: %w", projectKey, err)
+	}
+	return components, nil
+}
+
+// FindComponent returns the project component with the given name (case-insensitive) or ID.
+func (c *Client) FindComponent(projectKey, nameOrID string) (*Component, error) {
+	components, err := c.ListComponents(projectKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, comp := range components {
+		if comp.ID == nameOrID || strings.EqualFold(comp.Name, nameOrID) {
+			return &comp, nil
+		}
+	}
+	return nil, fmt.Errorf("FindComponent: no component %q in project %s", nameOrID, projectKey)
+}
+
+// CreateComponent creates a component in a project.
+func (c *Client) CreateComponent(req *CreateComponentRequest) (*Component, error) {
+	data, err := c.Post(c.apiPathFor("component"), req)
+	if err != nil {
+		return nil, fmt.Errorf("CreateComponent: %w", err)
+	}
+
+	var component Component
+	if err := json.Unmarshal(data, &component); err != nil {
+		return nil, fmt.Errorf("CreateComponent: failed to unmarshal: %w", err)
+	}
+	return &component, nil
+}
+
+// UpdateComponent partially updates a component.
+func (c *Client) UpdateComponent(componentID string, req *UpdateComponentRequest) (*Component, error) {
+	data, err := c.Put(c.apiPathFor("component", componentID), req)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateComponent %s: %w", componentID, err)
+	}
+
+	var component Component
+	if err := json.Unmarshal(data, &component); err != nil {
+		return nil, fmt.Errorf("UpdateComponent %s: failed to unmarshal: %w", componentID, err)
+	}
+	return &component, nil
+}
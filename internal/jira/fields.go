@@ -0,0 +1,77 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Field is an entry of the instance's field catalog, system or custom.
+type Field struct {
+	ID          string       `json:"id"`
+	Key         string       `json:"key,omitempty"`
+	Name        string       `json:"name"`
+	Custom      bool         `json:"custom"`
+	Orderable   bool         `json:"orderable,omitempty"`
+	Navigable   bool         `json:"navigable,omitempty"`
+	Searchable  bool         `json:"searchable,omitempty"`
+	ClauseNames []string     `json:"clauseNames,omitempty"`
+	Schema      *FieldSchema `json:"schema,omitempty"`
+}
+
+// FieldSchema describes the value type of a field.
+type FieldSchema struct {
+	Type     string `json:"type"`               // "number", "string", "date", "datetime", "option", "user", "array", ...
+	Items    string `json:"items,omitempty"`    // element type for arrays
+	System   string `json:"system,omitempty"`   // system field name
+	Custom   string `json:"custom,omitempty"`   // custom field type key, e.g. "com.pyxis.greenhopper.jira:gh-sprint"
+	CustomID int    `json:"customId,omitempty"` // numeric ID used in cf[ID] JQL clauses
+}
+
+// TypeName returns a compact type such as "number" or "array<option>".
+func (s *FieldSchema) TypeName() string {
+	if s == nil || s.Type == "" {
+		return "any"
+	}
+	if s.Type == "array" && s.Items != "" {
+		return "array<" + s.Items + ">"
+	}
+	return s.Type
+}
+
+// JQLClause returns the name to use for the field in JQL: cf[ID] for custom
+// fields, the first clause name otherwise.
+func (f Field) JQLClause() string {
+	if f.Schema != nil && f.Schema.CustomID > 0 {
+		return fmt.Sprintf("cf[%d]", f.Schema.CustomID)
+	}
+	if len(f.ClauseNames) > 0 {
+		return f.ClauseNames[0]
+	}
+	return f.ID
+}
+
+// EpicNameFieldID returns the ID of the Jira Software Epic Name field, which
+// only epics carry, or "" when the catalog has none.
+func EpicNameFieldID(catalog []Field) string {
+	for _, f := range catalog {
+		if f.Schema != nil && strings.HasSuffix(f.Schema.Custom, ":gh-epic-label") {
+			return f.ID
+		}
+	}
+	return ""
+}
+
+// ListFields returns the instance's field catalog, including custom fields.
+func (c *Client) ListFields() ([]Field, error) {
+	data, err := c.Get(c.apiPathFor("field"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("ListFields: %w", err)
+	}
+
+	var fields []Field
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("ListFields: failed to unmarshal: %w", err)
+	}
+	return fields, nil
+}
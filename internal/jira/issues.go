@@ -31,7 +31,7 @@ func (c *Client) GetIssue(issueKey string, fields []string) (*Issue, error) {
 // Supports Epic, Story, Task, Subtask, Bug.
 func (c *Client) CreateIssue(req *CreateIssueRequest) (*CreateIssueResponse, error) {
 	// Build the payload; merge Extra custom fields into the fields map.
-	payload := buildCreatePayload(req)
+	payload := c.buildCreatePayload(req)
 
 	data, err := c.Post(c.apiPathFor("issue"), payload)
 	if err != nil {
@@ -45,9 +45,32 @@ func (c *Client) CreateIssue(req *CreateIssueRequest) (*CreateIssueResponse, err
 	return &resp, nil
 }
 
+// CreateIssuesBulk creates up to 50 issues in one request. Elements can fail
+// independently: the response lists the created issues in request order and
+// the failed elements by index.
+func (c *Client) CreateIssuesBulk(reqs []*CreateIssueRequest) (*BulkCreateResponse, error) {
+	body := BulkCreateRequest{IssueUpdates: make([]map[string]interface{}, len(reqs))}
+	for i, req := range reqs {
+		body.IssueUpdates[i] = c.buildCreatePayload(req)
+	}
+
+	// Jira answers 201 when any element was created and 400 when none was.
+	data, err := c.Post(c.apiPathFor("issue", "bulk"), body)
+	if err != nil {
+		return nil, fmt.Errorf("CreateIssuesBulk: %w", err)
+	}
+
+	var resp BulkCreateResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("CreateIssuesBulk: failed to unmarshal response: %w", err)
+	}
+	return &resp, nil
+}
+
 // buildCreatePayload merges the typed CreateIssueFields with any Extra custom fields
-// into a single map suitable for JSON marshalling.
-func buildCreatePayload(req *CreateIssueRequest) map[string]interface{} {
+// into a single map suitable for JSON marshalling. Server/DC (API v2) takes the
+// description as plain text.
+func (c *Client) buildCreatePayload(req *CreateIssueRequest) map[string]interface{} {
 	fields := map[string]interface{}{
 		"project":   req.Fields.Project,
 		"issuetype": req.Fields.IssueType,
@@ -55,7 +78,11 @@ func buildCreatePayload(req *CreateIssueRequest) map[string]interface{} {
 	}
 
 	if req.Fields.Description != nil {
-		fields["description"] = req.Fields.Description
+		if c.instanceType == InstanceServer {
+			fields["description"] = strings.TrimRight(extractADFText(req.Fields.Description), "\n")
+		} else {
+			fields["description"] = req.Fields.Description
+		}
 	}
 	if req.Fields.Assignee != nil {
 		fields["assignee"] = req.Fields.Assignee
@@ -66,6 +93,9 @@ func buildCreatePayload(req *CreateIssueRequest) map[string]interface{} {
 	if len(req.Fields.Labels) > 0 {
 		fields["labels"] = req.Fields.Labels
 	}
+	if len(req.Fields.Components) > 0 {
+		fields["components"] = req.Fields.Components
+	}
 	if req.Fields.Parent != nil {
 		fields["parent"] = req.Fields.Parent
 	}
@@ -0,0 +1,37 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ListIssueLinkTypes returns the issue link types configured on the instance.
+func (c *Client) ListIssueLinkTypes() ([]IssueLinkType, error) {
+	data, err := c.Get(c.apiPathFor("issueLinkType"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("ListIssueLinkTypes: %w", err)
+	}
+
+	var resp struct {
+		IssueLinkTypes []IssueLinkType `json:"issueLinkTypes"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("ListIssueLinkTypes: failed to unmarshal: %w", err)
+	}
+	return resp.IssueLinkTypes, nil
+}
+
+// CreateIssueLink links two issues so that from <outward> to, e.g. with type
+// Blocks, "from blocks to". The API names the sides by how each shows up on
+// the other issue, so from goes in inwardIssue.
+func (c *Client) CreateIssueLink(typeName, from, to string) error {
+	body := map[string]interface{}{
+		"type":         map[string]string{"name": typeName},
+		"inwardIssue":  IssueRef{Key: from},
+		"outwardIssue": IssueRef{Key: to},
+	}
+	if _, err := c.Post(c.apiPathFor("issueLink"), body); err != nil {
+		return fmt.Errorf("CreateIssueLink %s -> %s: %w", from, to, err)
+	}
+	return nil
+}
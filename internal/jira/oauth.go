@@ -0,0 +1,283 @@
+package jira
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// OAuth 2.0 (3LO) endpoints for Jira Cloud.
+// See https://developer.atlassian.com/cloud/jira/platform/oauth-2-3lo-apps/
+const (
+	defaultOAuthAuthorizeURL = "https://auth.atlassian.com/authorize"
+	defaultOAuthTokenURL     = "https://auth.atlassian.com/oauth/token"
+)
+
+// oauthAPIBaseURL is the gateway OAuth requests go through. Tests override it.
+var oauthAPIBaseURL = "https://api.atlassian.com"
+
+// DefaultOAuthScopes are the scopes jira-mgmt needs for read/write access.
+// The Agile API (boards, sprints, backlog, ranking) only accepts the granular
+// Jira Software scopes, with the issue scopes its issue lists need.
+// offline_access is required to receive a refresh token.
+var DefaultOAuthScopes = []string{
+	"read:jira-work",
+	"write:jira-work",
+	"read:jira-user",
+	"manage:jira-project",
+	"read:board-scope:jira-software",
+	"read:board-scope.admin:jira-software",
+	"write:board-scope:jira-software",
+	"read:sprint:jira-software",
+	"write:sprint:jira-software",
+	"read:issue-details:jira",
+	"read:jql:jira",
+	"offline_access",
+}
+
+// OAuthConfig describes an Atlassian OAuth 2.0 (3LO) app.
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string // must match the callback URL registered for the app
+	Scopes       []string
+
+	// Endpoint overrides (tests); empty means the Atlassian defaults.
+	AuthorizeURL string
+	TokenURL     string
+	ResourcesURL string
+
+	HTTPClient *http.Client
+}
+
+// OAuthToken is the token endpoint response.
+type OAuthToken struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	TokenType    string `json:"token_type,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"` // seconds
+	Scope        string `json:"scope,omitempty"`
+}
+
+// Expiry returns the absolute expiry time of the access token relative to now.
+func (t *OAuthToken) Expiry(now time.Time) time.Time {
+	if t.ExpiresIn <= 0 {
+		return time.Time{}
+	}
+	return now.Add(time.Duration(t.ExpiresIn) * time.Second)
+}
+
+// AccessibleResource is a Jira site the OAuth token can access.
+type AccessibleResource struct {
+	ID     string   `json:"id"` // cloud ID
+	URL    string   `json:"url"`
+	Name   string   `json:"name,omitempty"`
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// NewPKCE generates a PKCE code verifier and its S256 challenge.
+func NewPKCE() (verifier, challenge string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("generating PKCE verifier: %w", err)
+	}
+	verifier = base64.RawURLEncoding.EncodeToString(buf)
+	sum := sha256.Sum256([]byte(verifier))
+	challenge = base64.RawURLEncoding.EncodeToString(sum[:])
+	return verifier, challenge, nil
+}
+
+// AuthCodeURL returns the consent URL the user must open in a browser.
+func (o OAuthConfig) AuthCodeURL(state, codeChallenge string) string {
+	scopes := o.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultOAuthScopes
+	}
+
+	q := url.Values{}
+	q.Set("audience", "api.atlassian.com")
+	q.Set("client_id", o.ClientID)
+	q.Set("scope", strings.Join(scopes, " "))
+	q.Set("redirect_uri", o.RedirectURL)
+	q.Set("state", state)
+	q.Set("response_type", "code")
+	q.Set("prompt", "consent")
+	if codeChallenge != "" {
+		q.Set("code_challenge", codeChallenge)
+		q.Set("code_challenge_method", "S256")
+	}
+	return firstNonEmptyString(o.AuthorizeURL, defaultOAuthAuthorizeURL) + "?" + q.Encode()
+}
+
+// Exchange trades an authorization code for access and refresh tokens.
+func (o OAuthConfig) Exchange(code, codeVerifier string) (*OAuthToken, error) {
+	body := map[string]string{
+		"grant_type":    "authorization_code",
+		"client_id":     o.ClientID,
+		"code":          code,
+		"redirect_uri":  o.RedirectURL,
+		"code_verifier": codeVerifier,
+	}
+	if o.ClientSecret != "" {
+		body["client_secret"] = o.ClientSecret
+	}
+	token, err := o.tokenRequest(body)
+	if err != nil {
+		return nil, fmt.Errorf("OAuth exchange: %w", err)
+	}
+	return token, nil
+}
+
+// Refresh obtains a new access token. Atlassian rotates refresh tokens,
+// so callers must persist the returned RefreshToken.
+func (o OAuthConfig) Refresh(refreshToken string) (*OAuthToken, error) {
+	if refreshToken == "" {
+		return nil, fmt.Errorf("OAuth refresh: refresh token is required")
+	}
+	body := map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     o.ClientID,
+		"refresh_token": refreshToken,
+	}
+	if o.ClientSecret != "" {
+		body["client_secret"] = o.ClientSecret
+	}
+	token, err := o.tokenRequest(body)
+	if err != nil {
+		return nil, fmt.Errorf("OAuth refresh: %w", err)
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+// AccessibleResources lists the Jira sites (and their cloud IDs) the token grants access to.
+func (o OAuthConfig) AccessibleResources(accessToken string) ([]AccessibleResource, error) {
+	endpoint := firstNonEmptyString(o.ResourcesURL, oauthAPIBaseURL+"/oauth/token/accessible-resources")
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("AccessibleResources: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	data, err := o.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("AccessibleResources: %w", err)
+	}
+
+	var resources []AccessibleResource
+	if err := json.Unmarshal(data, &resources); err != nil {
+		return nil, fmt.Errorf("AccessibleResources: failed to unmarshal: %w", err)
+	}
+	return resources, nil
+}
+
+// OAuthScopes returns the scopes granted to an OAuth client's access token for
+// its site, as listed by the accessible-resources endpoint.
+func (c *Client) OAuthScopes() ([]string, error) {
+	if c.cloudID == "" {
+		return nil, fmt.Errorf("OAuthScopes: not an OAuth client")
+	}
+	oauth := OAuthConfig{HTTPClient: c.httpClient}
+	resources, err := oauth.AccessibleResources(strings.TrimPrefix(c.currentAuthHeader(), "Bearer "))
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range resources {
+		if r.ID == c.cloudID {
+			return r.Scopes, nil
+		}
+	}
+	return nil, fmt.Errorf("OAuthScopes: site %s is not accessible with this token", c.cloudID)
+}
+
+// MissingOAuthScopes returns the DefaultOAuthScopes not in granted.
+// offline_access is left out: sites do not list it.
+func MissingOAuthScopes(granted []string) []string {
+	var missing []string
+	for _, scope := range DefaultOAuthScopes {
+		if scope != "offline_access" && !slices.Contains(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+func (o OAuthConfig) tokenRequest(body map[string]string) (*OAuthToken, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := firstNonEmptyString(o.TokenURL, defaultOAuthTokenURL)
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(string(payload)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	data, err := o.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var token OAuthToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token endpoint returned no access token")
+	}
+	return &token, nil
+}
+
+func (o OAuthConfig) do(req *http.Request) ([]byte, error) {
+	hc := o.HTTPClient
+	if hc == nil {
+		hc = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var oauthErr struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if json.Unmarshal(data, &oauthErr) == nil && oauthErr.Error != "" {
+			msg := oauthErr.Error
+			if oauthErr.ErrorDescription != "" {
+				msg += ": " + oauthErr.ErrorDescription
+			}
+			return nil, &APIError{StatusCode: resp.StatusCode, ErrorMessages: []string{msg}}
+		}
+		return nil, parseAPIError(resp.StatusCode, data)
+	}
+	return data, nil
+}
+
+func firstNonEmptyString(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Permission keys used by jira-mgmt commands.
+const (
+	PermBrowseProjects    = "BROWSE_PROJECTS"
+	PermCreateIssues      = "CREATE_ISSUES"
+	PermEditIssues        = "EDIT_ISSUES"
+	PermTransitionIssues  = "TRANSITION_ISSUES"
+	PermAddComments       = "ADD_COMMENTS"
+	PermDeleteIssues      = "DELETE_ISSUES"
+	PermAssignIssues      = "ASSIGN_ISSUES"
+	PermLinkIssues        = "LINK_ISSUES"
+	PermScheduleIssues    = "SCHEDULE_ISSUES"
+	PermManageSprints     = "MANAGE_SPRINTS_PERMISSION"
+	PermManageWatchers    = "MANAGE_WATCHERS"
+	PermAdministerProject = "ADMINISTER_PROJECTS"
+	PermUserPicker        = "USER_PICKER"
+	PermAdminister        = "ADMINISTER"
+)
+
+// ServerInfo is the response of /serverInfo.
+type ServerInfo struct {
+	BaseURL        string `json:"baseUrl,omitempty"`
+	Version        string `json:"version,omitempty"`
+	VersionNumbers []int  `json:"versionNumbers,omitempty"`
+	DeploymentType string `json:"deploymentType,omitempty"` // "Cloud", "Server" or "DataCenter"
+	BuildNumber    int    `json:"buildNumber,omitempty"`
+	ServerTitle    string `json:"serverTitle,omitempty"`
+}
+
+// Permission is a single entry of /mypermissions.
+type Permission struct {
+	ID             string `json:"id,omitempty"`
+	Key            string `json:"key"`
+	Name           string `json:"name,omitempty"`
+	Type           string `json:"type,omitempty"` // "GLOBAL" or "PROJECT"
+	Description    string `json:"description,omitempty"`
+	HavePermission bool   `json:"havePermission"`
+}
+
+// PersonalAccessToken is a Server/DC PAT owned by the current user.
+type PersonalAccessToken struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	CreatedAt      string `json:"createdAt,omitempty"`
+	ExpiringAt     string `json:"expiringAt,omitempty"`
+	LastAccessedAt string `json:"lastAccessedAt,omitempty"`
+}
+
+// GetServerInfo returns deployment type and version of the instance.
+func (c *Client) GetServerInfo() (*ServerInfo, error) {
+	data, err := c.Get(c.apiPathFor("serverInfo"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetServerInfo: %w", err)
+	}
+
+	var info ServerInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("GetServerInfo: failed to unmarshal: %w", err)
+	}
+	return &info, nil
+}
+
+// GetMyself returns the user the client is authenticated as.
+func (c *Client) GetMyself() (*User, error) {
+	data, err := c.Get(c.apiPathFor("myself"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetMyself: %w", err)
+	}
+
+	var user User
+	if err := json.Unmarshal(data, &user); err != nil {
+		return nil, fmt.Errorf("GetMyself: failed to unmarshal: %w", err)
+	}
+	return &user, nil
+}
+
+// GetMyPermissions checks the given permission keys for the current user.
+// With a project key, project permissions are evaluated for that project;
+// global permissions are returned either way.
+func (c *Client) GetMyPermissions(projectKey string, permissions []string) (map[string]Permission, error) {
+	q := url.Values{}
+	q.Set("permissions", strings.Join(permissions, ","))
+	if projectKey != "" {
+		q.Set("projectKey", projectKey)
+	}
+
+	data, err := c.Get(c.apiPathFor("mypermissions"), q)
+	if err != nil {
+		return nil, fmt.Errorf("GetMyPermissions %s: %w", projectKey, err)
+	}
+
+	var resp struct {
+		Permissions map[string]Permission `json:"permissions"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("GetMyPermissions %s: failed to unmarshal: %w", projectKey, err)
+	}
+	if resp.Permissions == nil {
+		resp.Permissions = map[string]Permission{}
+	}
+	return resp.Permissions, nil
+}
+
+// ListPersonalAccessTokens lists the current user's PATs (Server/DC 8.14+).
+// Cloud API tokens do not expose their expiry through the REST API.
+func (c *Client) ListPersonalAccessTokens() ([]PersonalAccessToken, error) {
+	data, err := c.Get("/rest/pat/latest/tokens", nil)
+	if err != nil {
+		return nil, fmt.Errorf("ListPersonalAccessTokens: %w", err)
+	}
+
+	var tokens []PersonalAccessToken
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("ListPersonalAccessTokens: failed to unmarshal: %w", err)
+	}
+	return tokens, nil
+}
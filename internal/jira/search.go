@@ -3,6 +3,7 @@ package jira
 import (
 	"encoding/json"
 	"fmt"
+	"iter"
 )
 
 // SearchJQL executes a JQL search. Uses the appropriate endpoint based on instance type:
@@ -73,60 +74,228 @@ func (c *Client) searchV2(req *SearchRequest) (*SearchResponse, error) {
 // SearchAll executes a JQL search and fetches all pages.
 func (c *Client) SearchAll(jql string, fields []string) ([]Issue, error) {
 	var allIssues []Issue
+	for issue, err := range c.SearchIter(jql, fields) {
+		if err != nil {
+			return nil, err
+		}
+		allIssues = append(allIssues, issue)
+	}
+	return allIssues, nil
+}
 
-	if c.instanceType == InstanceServer {
-		return c.searchAllV2(jql, fields)
+// SearchIter streams the issues matching jql page by page. The next page is
+// fetched while the caller consumes the current one, so at most two pages are
+// held in memory. Iteration stops after yielding the first error.
+func (c *Client) SearchIter(jql string, fields []string) iter.Seq2[Issue, error] {
+	return func(yield func(Issue, error) bool) {
+		for page, err := range c.SearchPages(jql, fields) {
+			if err != nil {
+				yield(Issue{}, err)
+				return
+			}
+			for _, issue := range page {
+				if !yield(issue, nil) {
+					return
+				}
+			}
+		}
 	}
-	return c.searchAllV3(jql, fields, allIssues)
 }
 
-func (c *Client) searchAllV3(jql string, fields []string, allIssues []Issue) ([]Issue, error) {
-	nextPageToken := ""
+// SearchPages is SearchIter yielding whole result pages, for callers that
+// batch per-issue requests over a page.
+func (c *Client) SearchPages(jql string, fields []string) iter.Seq2[[]Issue, error] {
+	return func(yield func([]Issue, error) bool) {
+		pages := make(chan searchPage)
+		done := make(chan struct{})
+		defer close(done)
+
+		go prefetchPages(c.searchPager(jql, fields), pages, done)
+
+		for page := range pages {
+			if page.err != nil {
+				yield(nil, page.err)
+				return
+			}
+			if !yield(page.issues, nil) {
+				return
+			}
+		}
+	}
+}
+
+type searchPage struct {
+	issues []Issue
+	err    error
+}
+
+// prefetchPages fetches pages until the last one, an error, or done is closed.
+// The channel is unbuffered: each fetch overlaps with consumption of the previous page.
+func prefetchPages(next func() ([]Issue, bool, error), pages chan<- searchPage, done <-chan struct{}) {
+	defer close(pages)
 	for {
-		req := &SearchRequest{
+		issues, more, err := next()
+		select {
+		case pages <- searchPage{issues: issues, err: err}:
+		case <-done:
+			return
+		}
+		if err != nil || !more {
+			return
+		}
+	}
+}
+
+// searchPager returns a function fetching successive result pages, using
+// cursor pagination on Cloud and offset pagination on Server/DC.
+func (c *Client) searchPager(jql string, fields []string) func() (issues []Issue, more bool, err error) {
+	if c.instanceType == InstanceServer {
+		startAt := 0
+		return func() ([]Issue, bool, error) {
+			resp, err := c.searchV2(&SearchRequest{
+				JQL:        jql,
+				MaxResults: 100,
+				Fields:     fields,
+				StartAt:    startAt,
+			})
+			if err != nil {
+				return nil, false, err
+			}
+			startAt += len(resp.Issues)
+			return resp.Issues, !resp.IsLast && len(resp.Issues) > 0, nil
+		}
+	}
+
+	nextPageToken := ""
+	return func() ([]Issue, bool, error) {
+		resp, err := c.searchV3(&SearchRequest{
 			JQL:           jql,
 			MaxResults:    100,
 			Fields:        fields,
 			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		nextPageToken = resp.NextPageToken
+		return resp.Issues, !resp.IsLast && nextPageToken != "", nil
+	}
+}
+
+// SearchLimited executes a JQL search, skips the first skip matches and returns at
+// most take issues, fetching only the pages needed. A take of 0 or less means no limit.
+// Server/DC pages by offset, so skipped issues are never transferred; Cloud only
+// supports cursors, so skipped issues are fetched and dropped.
+func (c *Client) SearchLimited(jql string, fields []string, skip, take int) ([]Issue, error) {
+	if skip < 0 {
+		skip = 0
+	}
+	if take <= 0 {
+		all, err := c.SearchAll(jql, fields)
+		if err != nil {
+			return nil, err
+		}
+		if skip >= len(all) {
+			return []Issue{}, nil
 		}
+		return all[skip:], nil
+	}
 
-		resp, err := c.searchV3(req)
+	if c.instanceType == InstanceServer {
+		return c.searchLimitedV2(jql, fields, skip, take)
+	}
+	return c.searchLimitedV3(jql, fields, skip, take)
+}
+
+func (c *Client) searchLimitedV3(jql string, fields []string, skip, take int) ([]Issue, error) {
+	want := skip + take
+	var issues []Issue
+	nextPageToken := ""
+	for len(issues) < want {
+		resp, err := c.searchV3(&SearchRequest{
+			JQL:           jql,
+			MaxResults:    min(want-len(issues), 100),
+			Fields:        fields,
+			NextPageToken: nextPageToken,
+		})
 		if err != nil {
 			return nil, err
 		}
 
-		allIssues = append(allIssues, resp.Issues...)
+		issues = append(issues, resp.Issues...)
 
-		if resp.IsLast || resp.NextPageToken == "" {
+		if resp.IsLast || resp.NextPageToken == "" || len(resp.Issues) == 0 {
 			break
 		}
 		nextPageToken = resp.NextPageToken
 	}
-	return allIssues, nil
+
+	if skip >= len(issues) {
+		return []Issue{}, nil
+	}
+	return issues[skip:min(want, len(issues))], nil
 }
 
-func (c *Client) searchAllV2(jql string, fields []string) ([]Issue, error) {
-	var allIssues []Issue
-	startAt := 0
-	for {
-		req := &SearchRequest{
+func (c *Client) searchLimitedV2(jql string, fields []string, skip, take int) ([]Issue, error) {
+	issues := []Issue{}
+	startAt := skip
+	for len(issues) < take {
+		resp, err := c.searchV2(&SearchRequest{
 			JQL:        jql,
-			MaxResults: 100,
+			MaxResults: min(take-len(issues), 100),
 			Fields:     fields,
 			StartAt:    startAt,
-		}
-
-		resp, err := c.searchV2(req)
+		})
 		if err != nil {
 			return nil, err
 		}
 
-		allIssues = append(allIssues, resp.Issues...)
+		issues = append(issues, resp.Issues...)
 
 		if resp.IsLast || len(resp.Issues) == 0 {
 			break
 		}
 		startAt += len(resp.Issues)
 	}
-	return allIssues, nil
+
+	if len(issues) > take {
+		issues = issues[:take]
+	}
+	return issues, nil
+}
+
+// CountIssues returns the number of issues matching jql without fetching them.
+// Cloud uses POST /rest/api/3/search/approximate-count, whose result may lag
+// recent changes, so approximate is true there; Server/DC reads the exact total
+// of a maxResults=0 search.
+func (c *Client) CountIssues(jql string) (count int, approximate bool, err error) {
+	if c.instanceType == InstanceServer {
+		data, err := c.Post(c.apiPathFor("search"), map[string]interface{}{
+			"jql":        jql,
+			"maxResults": 0,
+			"fields":     []string{"key"},
+		})
+		if err != nil {
+			return 0, false, fmt.Errorf("CountIssues: %w", err)
+		}
+		var resp struct {
+			Total int `json:"total"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return 0, false, fmt.Errorf("CountIssues: failed to unmarshal: %w", err)
+		}
+		return resp.Total, false, nil
+	}
+
+	data, err := c.Post(apiPath("search", "approximate-count"), map[string]string{"jql": jql})
+	if err != nil {
+		return 0, false, fmt.Errorf("CountIssues: %w", err)
+	}
+	var resp struct {
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return 0, false, fmt.Errorf("CountIssues: failed to unmarshal: %w", err)
+	}
+	return resp.Count, true, nil
 }
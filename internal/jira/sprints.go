@@ -0,0 +1,153 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxAgileIssueBatch is the most issues the Agile API moves or ranks per request.
+const maxAgileIssueBatch = 50
+
+// CreateSprintRequest is the request body for creating a sprint.
+type CreateSprintRequest struct {
+	Name          string     `json:"name"`
+	OriginBoardID int        `json:"originBoardId"`
+	StartDate     *time.Time `json:"startDate,omitempty"`
+	EndDate       *time.Time `json:"endDate,omitempty"`
+	Goal          string     `json:"goal,omitempty"`
+}
+
+// UpdateSprintRequest is a partial sprint update; unset fields are left unchanged.
+// Setting State to "active" starts a sprint (StartDate and EndDate required),
+// "closed" completes it.
+type UpdateSprintRequest struct {
+	Name      string     `json:"name,omitempty"`
+	State     string     `json:"state,omitempty"`
+	StartDate *time.Time `json:"startDate,omitempty"`
+	EndDate   *time.Time `json:"endDate,omitempty"`
+	Goal      *string    `json:"goal,omitempty"`
+}
+
+// RankRequest ranks issues before or after another issue.
+type RankRequest struct {
+	Issues          []string `json:"issues"`
+	RankBeforeIssue string   `json:"rankBeforeIssue,omitempty"`
+	RankAfterIssue  string   `json:"rankAfterIssue,omitempty"`
+}
+
+// rankResponse is the multi-status body the rank endpoint returns on partial failure.
+type rankResponse struct {
+	Entries []struct {
+		IssueKey string   `json:"issueKey"`
+		Status   int      `json:"status"`
+		Errors   []string `json:"errors"`
+	} `json:"entries"`
+}
+
+// CreateSprint creates a future sprint on a board.
+func (c *Client) CreateSprint(req *CreateSprintRequest) (*Sprint, error) {
+	data, err := c.Post(agileAPIPath("sprint"), req)
+	if err != nil {
+		return nil, fmt.Errorf("CreateSprint: %w", err)
+	}
+
+	var sprint Sprint
+	if err := json.Unmarshal(data, &sprint); err != nil {
+		return nil, fmt.Errorf("CreateSprint: failed to unmarshal: %w", err)
+	}
+	return &sprint, nil
+}
+
+// UpdateSprint partially updates a sprint.
+func (c *Client) UpdateSprint(sprintID int, req *UpdateSprintRequest) (*Sprint, error) {
+	data, err := c.Post(agileAPIPath("sprint", strconv.Itoa(sprintID)), req)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateSprint %d: %w", sprintID, err)
+	}
+
+	var sprint Sprint
+	if err := json.Unmarshal(data, &sprint); err != nil {
+		return nil, fmt.Errorf("UpdateSprint %d: failed to unmarshal: %w", sprintID, err)
+	}
+	return &sprint, nil
+}
+
+// StartSprint activates a future sprint for the given dates.
+func (c *Client) StartSprint(sprintID int, start, end time.Time) (*Sprint, error) {
+	return c.UpdateSprint(sprintID, &UpdateSprintRequest{State: "active", StartDate: &start, EndDate: &end})
+}
+
+// CloseSprint completes an active sprint. Jira leaves its unfinished issues in the
+// backlog; see MoveIssuesToSprint to carry them over.
+func (c *Client) CloseSprint(sprintID int) (*Sprint, error) {
+	return c.UpdateSprint(sprintID, &UpdateSprintRequest{State: "closed"})
+}
+
+// MoveIssuesToSprint moves issues into an open sprint, in batches of 50.
+func (c *Client) MoveIssuesToSprint(sprintID int, issueKeys []string) error {
+	path := agileAPIPath("sprint", strconv.Itoa(sprintID), "issue")
+	for batch := range slices.Chunk(issueKeys, maxAgileIssueBatch) {
+		if _, err := c.Post(path, map[string][]string{"issues": batch}); err != nil {
+			return fmt.Errorf("MoveIssuesToSprint %d: %w", sprintID, err)
+		}
+	}
+	return nil
+}
+
+// MoveIssuesToBacklog removes issues from their sprints, in batches of 50.
+func (c *Client) MoveIssuesToBacklog(issueKeys []string) error {
+	for batch := range slices.Chunk(issueKeys, maxAgileIssueBatch) {
+		if _, err := c.Post(agileAPIPath("backlog", "issue"), map[string][]string{"issues": batch}); err != nil {
+			return fmt.Errorf("MoveIssuesToBacklog: %w", err)
+		}
+	}
+	return nil
+}
+
+// RankIssues ranks issues, in the given order, immediately before or after
+// another issue; exactly one of before and after must be set.
+func (c *Client) RankIssues(issueKeys []string, before, after string) error {
+	if (before == "") == (after == "") {
+		return fmt.Errorf("RankIssues: exactly one of before or after is required")
+	}
+
+	anchorBefore, anchorAfter := before, after
+	for batch := range slices.Chunk(issueKeys, maxAgileIssueBatch) {
+		req := RankRequest{Issues: batch, RankBeforeIssue: anchorBefore, RankAfterIssue: anchorAfter}
+		data, err := c.Put(agileAPIPath("issue", "rank"), &req)
+		if err != nil {
+			return fmt.Errorf("RankIssues: %w", err)
+		}
+		if err := rankErrors(data); err != nil {
+			return fmt.Errorf("RankIssues: %w", err)
+		}
+		// Later batches follow the last ranked issue to keep the overall order.
+		anchorBefore, anchorAfter = "", batch[len(batch)-1]
+	}
+	return nil
+}
+
+// rankErrors reports per-issue failures from a 207 Multi-Status rank response.
+func rankErrors(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	var resp rankResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil
+	}
+	var failed []string
+	for _, entry := range resp.Entries {
+		if entry.Status >= 300 {
+			failed = append(failed, fmt.Sprintf("%s: %s", entry.IssueKey, strings.Join(entry.Errors, "; ")))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("ranking failed for %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package jira
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -21,6 +22,7 @@ type AuthType string
 const (
 	AuthBasic  AuthType = "basic"  // Cloud: email + API token → Basic base64(email:token)
 	AuthBearer AuthType = "bearer" // Server/DC: Personal Access Token → Bearer <token>
+	AuthOAuth  AuthType = "oauth"  // Cloud: OAuth 2.0 (3LO) access token → Bearer <token> via api.atlassian.com
 )
 
 // Config holds the configuration needed to connect to a Jira instance.
@@ -30,7 +32,19 @@ type Config struct {
 	Token              string       // API token (Basic) or PAT (Bearer)
 	InstanceType       InstanceType // "cloud" or "server" — auto-detected if empty
 	AuthType           AuthType     // "basic" or "bearer" — inferred from Email presence if empty
-	InsecureSkipVerify bool         // Skip TLS certificate verification (corporate CAs)
+	InsecureSkipVerify bool         // Skip TLS certificate verification (last resort; prefer CACertFile)
+
+	// Transport settings.
+	CACertFile     string        // PEM bundle trusted in addition to the system roots
+	ClientCertFile string        // PEM client certificate for mTLS
+	ClientKeyFile  string        // PEM private key for ClientCertFile
+	ProxyURL       string        // explicit HTTP(S) proxy; empty uses HTTPS_PROXY/HTTP_PROXY from the env
+	NoProxy        string        // comma-separated hosts/domains/CIDRs that bypass ProxyURL
+	Timeout        time.Duration // per-request timeout; 0 means 30s
+
+	// OAuth 2.0 (3LO) only.
+	CloudID        string                 // Atlassian cloud ID; requests go through api.atlassian.com/ex/jira/{cloudid}
+	TokenRefresher func() (string, error) // Returns a fresh access token; called once when a request gets 401
 }
 
 // --- Error Types ---
@@ -81,6 +95,7 @@ type ADFMark struct {
 // User represents a Jira user.
 type User struct {
 	AccountID   string `json:"accountId,omitempty"`
+	Name        string `json:"name,omitempty"` // Server/DC username
 	DisplayName string `json:"displayName,omitempty"`
 	EmailAddress string `json:"emailAddress,omitempty"`
 	Active       bool   `json:"active,omitempty"`
@@ -108,6 +123,10 @@ type IssueFields struct {
 	Assignee    *User     `json:"assignee,omitempty"`
 	Reporter    *User     `json:"reporter,omitempty"`
 	Labels      []string  `json:"labels,omitempty"`
+	Components  []Component `json:"components,omitempty"`
+	Watches     *Watchers `json:"watches,omitempty"`
+	Votes       *Votes    `json:"votes,omitempty"`
+	IssueLinks  []IssueLink `json:"issuelinks,omitempty"`
 	Parent      *Issue    `json:"parent,omitempty"`
 	Subtasks    []Issue   `json:"subtasks,omitempty"`
 	Created     string    `json:"created,omitempty"`
@@ -115,6 +134,72 @@ type IssueFields struct {
 
 	// Custom fields are stored here for flexible access.
 	CustomFields map[string]json.RawMessage `json:"-"`
+	// SystemFields keeps every other field raw, including those without a
+	// typed field above (duedate, environment, ...).
+	SystemFields map[string]json.RawMessage `json:"-"`
+}
+
+// issueFieldsAlias has IssueFields' layout without its JSON methods.
+type issueFieldsAlias IssueFields
+
+// UnmarshalJSON decodes the standard fields and keeps every customfield_* value
+// raw in CustomFields and every other value raw in SystemFields.
+func (f *IssueFields) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*issueFieldsAlias)(f)); err != nil {
+		return err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	f.CustomFields, f.SystemFields = nil, nil
+	for name, raw := range all {
+		if string(raw) == "null" {
+			continue
+		}
+		target := &f.SystemFields
+		if strings.HasPrefix(name, "customfield_") {
+			target = &f.CustomFields
+		}
+		if *target == nil {
+			*target = make(map[string]json.RawMessage)
+		}
+		(*target)[name] = raw
+	}
+	return nil
+}
+
+// Raw returns the raw JSON value of a field by ID, custom or system, or nil
+// when the issue does not have it.
+func (f *IssueFields) Raw(id string) json.RawMessage {
+	if strings.HasPrefix(id, "customfield_") {
+		return f.CustomFields[id]
+	}
+	return f.SystemFields[id]
+}
+
+// MarshalJSON encodes the standard fields followed by CustomFields and the
+// SystemFields the standard fields do not already cover.
+func (f IssueFields) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(issueFieldsAlias(f))
+	if err != nil || len(f.CustomFields) == 0 && len(f.SystemFields) == 0 {
+		return data, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for name, raw := range f.SystemFields {
+		if _, ok := all[name]; !ok {
+			all[name] = raw
+		}
+	}
+	for name, raw := range f.CustomFields {
+		all[name] = raw
+	}
+	return json.Marshal(all)
 }
 
 // DescriptionText returns the description as plain text.
@@ -168,9 +253,24 @@ func extractNodeText(node ADFNode, buf []byte) []byte {
 
 // IssueType represents a Jira issue type (Epic, Story, Task, Subtask, Bug).
 type IssueType struct {
-	ID      string `json:"id,omitempty"`
-	Name    string `json:"name,omitempty"`
-	Subtask bool   `json:"subtask,omitempty"`
+	ID             string `json:"id,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Subtask        bool   `json:"subtask,omitempty"`
+	HierarchyLevel int    `json:"hierarchyLevel,omitempty"` // Cloud: 1 epic, 0 standard, -1 subtask
+}
+
+// IsEpic reports whether the issue is an epic, whatever the instance language
+// calls the type. Cloud reports the epic hierarchy level; on Server/DC only
+// epics carry the Epic Name field (epicNameField, from EpicNameFieldID, when
+// fetched). With neither, the English type name decides.
+func (i *Issue) IsEpic(epicNameField string) bool {
+	switch {
+	case i.Fields.IssueType.HierarchyLevel == 1:
+		return true
+	case epicNameField != "":
+		return i.Fields.Raw(epicNameField) != nil
+	}
+	return strings.EqualFold(i.Fields.IssueType.Name, "Epic")
 }
 
 // Priority represents an issue priority.
@@ -195,6 +295,7 @@ type CreateIssueFields struct {
 	Assignee    *UserRef     `json:"assignee,omitempty"`
 	Priority    *PriorityRef `json:"priority,omitempty"`
 	Labels      []string     `json:"labels,omitempty"`
+	Components  []ComponentRef `json:"components,omitempty"`
 	Parent      *IssueRef    `json:"parent,omitempty"`
 
 	// Extra allows setting custom fields.
@@ -213,9 +314,10 @@ type IssueTypeRef struct {
 	ID   string `json:"id,omitempty"`
 }
 
-// UserRef identifies a user by account ID.
+// UserRef identifies a user: by account ID on Cloud, by username on Server/DC.
 type UserRef struct {
-	AccountID string `json:"accountId"`
+	AccountID string `json:"accountId,omitempty"`
+	Name      string `json:"name,omitempty"`
 }
 
 // PriorityRef identifies a priority by name or ID.
@@ -237,6 +339,26 @@ type CreateIssueResponse struct {
 	Self string `json:"self"`
 }
 
+// BulkCreateRequest is the request body for creating issues in bulk.
+type BulkCreateRequest struct {
+	IssueUpdates []map[string]interface{} `json:"issueUpdates"`
+}
+
+// BulkCreateResponse lists the issues a bulk create made, in request order,
+// and the elements that failed.
+type BulkCreateResponse struct {
+	Issues []CreateIssueResponse `json:"issues"`
+	Errors []BulkCreateError     `json:"errors"`
+}
+
+// BulkCreateError is a failed element of a bulk create; FailedElementNumber
+// is its index in the request.
+type BulkCreateError struct {
+	Status              int      `json:"status"`
+	ElementErrors       APIError `json:"elementErrors"`
+	FailedElementNumber int      `json:"failedElementNumber"`
+}
+
 // UpdateIssueRequest is the request body for updating an issue.
 type UpdateIssueRequest struct {
 	Fields map[string]interface{} `json:"fields,omitempty"`
@@ -266,12 +388,92 @@ type ProjectSearchResult struct {
 
 // Board represents a Jira Agile board.
 type Board struct {
-	ID   int    `json:"id"`
-	Name string `json:"name,omitempty"`
-	Type string `json:"type,omitempty"` // "scrum" or "kanban"
+	ID       int            `json:"id"`
+	Name     string         `json:"name,omitempty"`
+	Type     string         `json:"type,omitempty"` // "scrum" or "kanban"
+	Self     string         `json:"self,omitempty"`
+	Location *BoardLocation `json:"location,omitempty"`
+}
+
+// BoardLocation is the project (or user) a board belongs to.
+type BoardLocation struct {
+	ProjectID   int    `json:"projectId,omitempty"`
+	ProjectKey  string `json:"projectKey,omitempty"`
+	ProjectName string `json:"projectName,omitempty"`
+	Name        string `json:"name,omitempty"`
+}
+
+// BoardConfiguration is a board's filter, column layout and estimation settings.
+type BoardConfiguration struct {
+	ID           int               `json:"id"`
+	Name         string            `json:"name,omitempty"`
+	Type         string            `json:"type,omitempty"` // "scrum" or "kanban"
+	Filter       BoardFilterRef    `json:"filter"`
+	SubQuery     *BoardSubQuery    `json:"subQuery,omitempty"` // kanban only
+	ColumnConfig BoardColumnConfig `json:"columnConfig"`
+	Estimation   *BoardEstimation  `json:"estimation,omitempty"`
+}
+
+// BoardFilterRef points to the saved filter that selects a board's issues.
+type BoardFilterRef struct {
+	ID   string `json:"id"`
 	Self string `json:"self,omitempty"`
 }
 
+// BoardSubQuery is the kanban sub-filter that hides old done issues.
+type BoardSubQuery struct {
+	Query string `json:"query"`
+}
+
+// BoardColumnConfig lists a board's columns and how WIP limits count issues:
+// "none", "issueCount" or "issueCountExclSubs".
+type BoardColumnConfig struct {
+	Columns        []BoardColumn `json:"columns"`
+	ConstraintType string        `json:"constraintType,omitempty"`
+}
+
+// BoardColumn is a board column, the statuses mapped to it and its WIP limits.
+type BoardColumn struct {
+	Name     string              `json:"name"`
+	Statuses []BoardColumnStatus `json:"statuses"`
+	Min      *int                `json:"min,omitempty"`
+	Max      *int                `json:"max,omitempty"`
+}
+
+// BoardColumnStatus is a status mapped to a column.
+type BoardColumnStatus struct {
+	ID   string `json:"id"`
+	Self string `json:"self,omitempty"`
+}
+
+// BoardEstimation is the statistic a board estimates with.
+type BoardEstimation struct {
+	Type  string `json:"type"` // "field", "issueCount" or "none"
+	Field *struct {
+		FieldID     string `json:"fieldId"`
+		DisplayName string `json:"displayName"`
+	} `json:"field,omitempty"`
+}
+
+// ColumnFor returns the index of the column a status is mapped to.
+func (c *BoardConfiguration) ColumnFor(statusID string) (int, bool) {
+	for i, column := range c.ColumnConfig.Columns {
+		for _, status := range column.Statuses {
+			if status.ID == statusID {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Filter is a saved JQL filter.
+type Filter struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	JQL  string `json:"jql"`
+}
+
 // BoardSearchResult is the paginated response from board listing.
 type BoardSearchResult struct {
 	MaxResults int     `json:"maxResults"`
@@ -290,6 +492,7 @@ type Sprint struct {
 	State         string     `json:"state,omitempty"` // "future", "active", "closed"
 	StartDate     *time.Time `json:"startDate,omitempty"`
 	EndDate       *time.Time `json:"endDate,omitempty"`
+	CompleteDate  *time.Time `json:"completeDate,omitempty"` // set once closed
 	OriginBoardID int        `json:"originBoardId,omitempty"`
 	Goal          string     `json:"goal,omitempty"`
 	Self          string     `json:"self,omitempty"`
@@ -303,6 +506,14 @@ type SprintSearchResult struct {
 	Values     []Sprint `json:"values"`
 }
 
+// AgileIssueResult is the paginated issue list of the Agile sprint and backlog endpoints.
+type AgileIssueResult struct {
+	StartAt    int     `json:"startAt"`
+	MaxResults int     `json:"maxResults"`
+	Total      int     `json:"total"`
+	Issues     []Issue `json:"issues"`
+}
+
 // --- Transition ---
 
 // Transition represents a workflow transition.
@@ -436,3 +647,149 @@ type ListIssuesOptions struct {
 	Fields     []string
 	MaxResults int
 }
+
+// --- Changelog ---
+
+// ChangelogHistory is one change event: every field changed by one edit.
+type ChangelogHistory struct {
+	ID      string          `json:"id"`
+	Author  *User           `json:"author,omitempty"`
+	Created string          `json:"created"`
+	Items   []ChangelogItem `json:"items"`
+}
+
+// ChangelogItem is a single field change. From and To hold IDs (status ID,
+// sprint IDs as "12, 13"), FromString and ToString the display values.
+type ChangelogItem struct {
+	Field      string `json:"field"`
+	FieldType  string `json:"fieldtype,omitempty"`
+	FieldID    string `json:"fieldId,omitempty"` // Cloud only
+	From       string `json:"from"`
+	FromString string `json:"fromString"`
+	To         string `json:"to"`
+	ToString   string `json:"toString"`
+}
+
+// ChangelogPage is a page of the Cloud changelog endpoint.
+type ChangelogPage struct {
+	StartAt    int                `json:"startAt"`
+	MaxResults int                `json:"maxResults"`
+	Total      int                `json:"total"`
+	IsLast     bool               `json:"isLast"`
+	Values     []ChangelogHistory `json:"values"`
+}
+
+// --- Versions ---
+
+// Version is a project version (fix version / release).
+// Dates are plain dates, e.g. "2026-03-13".
+type Version struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	ProjectID   int    `json:"projectId,omitempty"`
+	StartDate   string `json:"startDate,omitempty"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	Released    bool   `json:"released"`
+	Archived    bool   `json:"archived"`
+	Overdue     bool   `json:"overdue,omitempty"`
+	Self        string `json:"self,omitempty"`
+}
+
+// CreateVersionRequest is the request body for creating a version.
+type CreateVersionRequest struct {
+	Name        string `json:"name"`
+	ProjectID   int    `json:"projectId"`
+	Description string `json:"description,omitempty"`
+	StartDate   string `json:"startDate,omitempty"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+}
+
+// UpdateVersionRequest is a partial version update; nil fields are left unchanged.
+type UpdateVersionRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	StartDate   *string `json:"startDate,omitempty"`
+	ReleaseDate *string `json:"releaseDate,omitempty"`
+	Released    *bool   `json:"released,omitempty"`
+	Archived    *bool   `json:"archived,omitempty"`
+}
+
+// --- Components ---
+
+// Component is a project component.
+type Component struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	Lead         *User  `json:"lead,omitempty"`
+	AssigneeType string `json:"assigneeType,omitempty"` // PROJECT_DEFAULT, COMPONENT_LEAD, PROJECT_LEAD or UNASSIGNED
+	Assignee     *User  `json:"assignee,omitempty"`     // the default assignee AssigneeType resolves to
+	Project      string `json:"project,omitempty"`
+	Self         string `json:"self,omitempty"`
+}
+
+// ComponentRef identifies a component by name or ID.
+type ComponentRef struct {
+	Name string `json:"name,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+// CreateComponentRequest is the request body for creating a component.
+// The lead is an account ID on Cloud and a username on Server/DC.
+type CreateComponentRequest struct {
+	Project       string `json:"project"`
+	Name          string `json:"name"`
+	Description   string `json:"description,omitempty"`
+	LeadAccountID string `json:"leadAccountId,omitempty"`
+	LeadUserName  string `json:"leadUserName,omitempty"`
+	AssigneeType  string `json:"assigneeType,omitempty"`
+}
+
+// UpdateComponentRequest is the request body for updating a component; nil fields are left unchanged.
+type UpdateComponentRequest struct {
+	Name          *string `json:"name,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	LeadAccountID *string `json:"leadAccountId,omitempty"`
+	LeadUserName  *string `json:"leadUserName,omitempty"`
+	AssigneeType  *string `json:"assigneeType,omitempty"`
+}
+
+// --- Watchers and votes ---
+
+// Watchers is an issue's watch state. As the "watches" issue field it has no
+// list of watchers; GetWatchers fills Watchers.
+type Watchers struct {
+	Self       string `json:"self,omitempty"`
+	IsWatching bool   `json:"isWatching"`
+	WatchCount int    `json:"watchCount"`
+	Watchers   []User `json:"watchers,omitempty"`
+}
+
+// Votes is an issue's vote state. As the "votes" issue field it has no list
+// of voters; GetVotes fills Voters when the caller may view them.
+type Votes struct {
+	Self     string `json:"self,omitempty"`
+	Votes    int    `json:"votes"`
+	HasVoted bool   `json:"hasVoted"`
+	Voters   []User `json:"voters,omitempty"`
+}
+
+// --- Issue links ---
+
+// IssueLinkType is a kind of issue link, e.g. Blocks: "blocks" / "is blocked by".
+type IssueLinkType struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Inward  string `json:"inward,omitempty"`
+	Outward string `json:"outward,omitempty"`
+}
+
+// IssueLink is a link as listed on an issue: OutwardIssue is set when this
+// issue <outward> it ("blocks"), InwardIssue when this issue <inward> it.
+type IssueLink struct {
+	ID           string        `json:"id,omitempty"`
+	Type         IssueLinkType `json:"type"`
+	InwardIssue  *Issue        `json:"inwardIssue,omitempty"`
+	OutwardIssue *Issue        `json:"outwardIssue,omitempty"`
+}
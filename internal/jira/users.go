@@ -0,0 +1,88 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// SearchUsers finds active users matching query: display name or email on
+// Cloud, plus username on Server/DC.
+func (c *Client) SearchUsers(query string, maxResults int) ([]User, error) {
+	q := c.userQuery(query, maxResults)
+	data, err := c.Get(c.apiPathFor("user", "search"), q)
+	if err != nil {
+		return nil, fmt.Errorf("SearchUsers: %w", err)
+	}
+
+	var users []User
+	if err := json.Unmarshal(data, &users); err != nil {
+		return nil, fmt.Errorf("SearchUsers: failed to unmarshal: %w", err)
+	}
+	return users, nil
+}
+
+// FindAssignableUsers finds users matching query who can be assigned issues in
+// a project, or a specific issue when issueKey is set.
+func (c *Client) FindAssignableUsers(projectKey, issueKey, query string) ([]User, error) {
+	q := c.userQuery(query, 50)
+	if projectKey != "" {
+		q.Set("project", projectKey)
+	}
+	if issueKey != "" {
+		q.Set("issueKey", issueKey)
+	}
+
+	data, err := c.Get(c.apiPathFor("user", "assignable", "search"), q)
+	if err != nil {
+		return nil, fmt.Errorf("FindAssignableUsers: %w", err)
+	}
+
+	var users []User
+	if err := json.Unmarshal(data, &users); err != nil {
+		return nil, fmt.Errorf("FindAssignableUsers: failed to unmarshal: %w", err)
+	}
+	return users, nil
+}
+
+// userQuery builds user search parameters: Server/DC searches by "username",
+// Cloud by "query".
+func (c *Client) userQuery(query string, maxResults int) url.Values {
+	q := url.Values{}
+	if c.instanceType == InstanceServer {
+		q.Set("username", query)
+	} else {
+		q.Set("query", query)
+	}
+	if maxResults > 0 {
+		q.Set("maxResults", strconv.Itoa(maxResults))
+	}
+	return q
+}
+
+// AssignIssue assigns an issue to user, or unassigns it when user is nil.
+func (c *Client) AssignIssue(issueKey string, user *UserRef) error {
+	var body any = user
+	if user == nil {
+		key := "accountId"
+		if c.instanceType == InstanceServer {
+			key = "name"
+		}
+		body = map[string]any{key: nil}
+	}
+
+	if _, err := c.Put(c.apiPathFor("issue", issueKey, "assignee"), body); err != nil {
+		return fmt.Errorf("AssignIssue %s: %w", issueKey, err)
+	}
+	return nil
+}
+
+// Ref returns the reference Jira expects for u in issue fields: the account ID
+// on Cloud, the username on Server/DC.
+func (u *User) Ref() *UserRef {
+	if u.AccountID != "" {
+		return &UserRef{AccountID: u.AccountID}
+	}
+	return &UserRef{Name: u.Name}
+}
@@ -0,0 +1,69 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ListVersions returns all versions of a project.
+func (c *Client) ListVersions(projectKey string) ([]Version, error) {
+	data, err := c.Get(c.apiPathFor("project", projectKey, "versions"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("ListVersions %s: %w", projectKey, err)
+	}
+
+	var versions []Version
+	if err := json.Unmarshal(data, &versions); err != nil {
+		return nil, fmt.Errorf("ListVersions %s: failed to unmarshal: %w", projectKey, err)
+	}
+	return versions, nil
+}
+
+// FindVersion returns the project version with the given name (case-insensitive) or ID.
+func (c *Client) FindVersion(projectKey, nameOrID string) (*Version, error) {
+	versions, err := c.ListVersions(projectKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v.ID == nameOrID || strings.EqualFold(v.Name, nameOrID) {
+			return &v, nil
+		}
+	}
+	return nil, fmt.Errorf("FindVersion: no version %q in project %s", nameOrID, projectKey)
+}
+
+// CreateVersion creates a version in a project.
+func (c *Client) CreateVersion(req *CreateVersionRequest) (*Version, error) {
+	data, err := c.Post(c.apiPathFor("version"), req)
+	if err != nil {
+		return nil, fmt.Errorf("CreateVersion: %w", err)
+	}
+
+	var version Version
+	if err := json.Unmarshal(data, &version); err != nil {
+		return nil, fmt.Errorf("CreateVersion: failed to unmarshal: %w", err)
+	}
+	return &version, nil
+}
+
+// UpdateVersion partially updates a version.
+func (c *Client) UpdateVersion(versionID string, req *UpdateVersionRequest) (*Version, error) {
+	data, err := c.Put(c.apiPathFor("version", versionID), req)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateVersion %s: %w", versionID, err)
+	}
+
+	var version Version
+	if err := json.Unmarshal(data, &version); err != nil {
+		return nil, fmt.Errorf("UpdateVersion %s: failed to unmarshal: %w", versionID, err)
+	}
+	return &version, nil
+}
+
+// ReleaseVersion marks a version released on releaseDate ("2026-03-13").
+func (c *Client) ReleaseVersion(versionID, releaseDate string) (*Version, error) {
+	released := true
+	return c.UpdateVersion(versionID, &UpdateVersionRequest{Released: &released, ReleaseDate: &releaseDate})
+}
@@ -0,0 +1,79 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// GetWatchers returns the watchers of an issue.
+func (c *Client) GetWatchers(issueKey string) (*Watchers, error) {
+	data, err := c.Get(c.apiPathFor("issue", issueKey, "watchers"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetWatchers %s: %w", issueKey, err)
+	}
+
+	var watchers Watchers
+	if err := json.Unmarshal(data, &watchers); err != nil {
+		return nil, fmt.Errorf("GetWatchers %s: failed to unmarshal: %w", issueKey, err)
+	}
+	return &watchers, nil
+}
+
+// AddWatcher adds user as a watcher of an issue.
+func (c *Client) AddWatcher(issueKey string, user *UserRef) error {
+	// The body is the bare account ID (Cloud) or username (Server/DC) as a JSON string.
+	id := user.AccountID
+	if id == "" {
+		id = user.Name
+	}
+	if _, err := c.Post(c.apiPathFor("issue", issueKey, "watchers"), id); err != nil {
+		return fmt.Errorf("AddWatcher %s: %w", issueKey, err)
+	}
+	return nil
+}
+
+// RemoveWatcher removes user from the watchers of an issue.
+func (c *Client) RemoveWatcher(issueKey string, user *UserRef) error {
+	q := url.Values{}
+	if user.AccountID != "" {
+		q.Set("accountId", user.AccountID)
+	} else {
+		q.Set("username", user.Name)
+	}
+	if _, err := c.request(http.MethodDelete, c.apiPathFor("issue", issueKey, "watchers"), q, nil); err != nil {
+		return fmt.Errorf("RemoveWatcher %s: %w", issueKey, err)
+	}
+	return nil
+}
+
+// GetVotes returns the votes on an issue.
+func (c *Client) GetVotes(issueKey string) (*Votes, error) {
+	data, err := c.Get(c.apiPathFor("issue", issueKey, "votes"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetVotes %s: %w", issueKey, err)
+	}
+
+	var votes Votes
+	if err := json.Unmarshal(data, &votes); err != nil {
+		return nil, fmt.Errorf("GetVotes %s: failed to unmarshal: %w", issueKey, err)
+	}
+	return &votes, nil
+}
+
+// AddVote votes for an issue as the authenticated user.
+func (c *Client) AddVote(issueKey string) error {
+	if _, err := c.Post(c.apiPathFor("issue", issueKey, "votes"), nil); err != nil {
+		return fmt.Errorf("AddVote %s: %w", issueKey, err)
+	}
+	return nil
+}
+
+// RemoveVote withdraws the authenticated user's vote on an issue.
+func (c *Client) RemoveVote(issueKey string) error {
+	if _, err := c.Delete(c.apiPathFor("issue", issueKey, "votes")); err != nil {
+		return fmt.Errorf("RemoveVote %s: %w", issueKey, err)
+	}
+	return nil
+}
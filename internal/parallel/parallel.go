@@ -0,0 +1,32 @@
+// Package parallel runs independent Jira requests concurrently, a bounded
+// number at a time, so fan-outs over issues or queries do not flood the API.
+package parallel
+
+import "sync"
+
+// Limit is how many calls run at once.
+const Limit = 8
+
+// Map calls fn for every index in [0, n), at most Limit at a time, and returns
+// the results in index order. Every call runs; the error returned is that of
+// the lowest failing index.
+func Map[T any](n int, fn func(i int) (T, error)) ([]T, error) {
+	results := make([]T, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, Limit)
+	for i := range n {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = fn(i)
+		})
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,52 @@
+package parallel
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMap(t *testing.T) {
+	var running, peak atomic.Int32
+	got, err := Map(50, func(i int) (int, error) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return i * i, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, v := range got {
+		if v != i*i {
+			t.Fatalf("results[%d] = %d, want %d", i, v, i*i)
+		}
+	}
+	if p := peak.Load(); p > Limit {
+		t.Errorf("peak concurrency = %d, want at most %d", p, Limit)
+	}
+}
+
+func TestMap_ReturnsLowestIndexError(t *testing.T) {
+	var calls atomic.Int32
+	_, err := Map(10, func(i int) (int, error) {
+		calls.Add(1)
+		if i == 3 || i == 7 {
+			return 0, errors.New("failed " + string(rune('0'+i)))
+		}
+		return i, nil
+	})
+	if err == nil || err.Error() != "failed 3" {
+		t.Errorf("err = %v, want failed 3", err)
+	}
+	if calls.Load() != 10 {
+		t.Errorf("calls = %d, want 10", calls.Load())
+	}
+}
@@ -0,0 +1,450 @@
+package plan
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// bulkSize is the most issues Jira creates in one bulk request.
+const bulkSize = 50
+
+// Applier creates the issues of a plan that have no key yet and reconciles
+// existing ones with the plan.
+type Applier struct {
+	Client *jira.Client
+	// Project is used when the plan does not name one.
+	Project string
+	// TypeName maps plan type names such as "story" to Jira issue type names.
+	TypeName func(string) string
+	// ResolveUser resolves an assignee within the project.
+	ResolveUser func(who, project string) (*jira.UserRef, error)
+	// FieldID maps a custom field name from the plan's fields to its ID.
+	FieldID func(name string) (string, error)
+	// EpicLinkField is the Epic Link custom field. When set (Server/DC),
+	// children of epics are attached through it instead of parent.
+	EpicLinkField string
+	// PostDoD posts the definition of done of a created issue.
+	PostDoD func(key, text string) error
+	// DryRun reports what would be created without changing anything.
+	DryRun bool
+}
+
+// Result is the outcome of applying a plan, in plan order.
+type Result struct {
+	Project string      `json:"project"`
+	DryRun  bool        `json:"dry_run,omitempty"`
+	Issues  []Entry     `json:"issues"`
+	Links   []LinkEntry `json:"links,omitempty"`
+	Failed  int         `json:"failed"`
+}
+
+// Entry is the outcome for one item: created, existing (had a key), planned
+// (dry run) or failed.
+type Entry struct {
+	Key     string `json:"key,omitempty"`
+	Type    string `json:"type"`
+	Summary string `json:"summary"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Depth   int    `json:"depth"`
+}
+
+// LinkEntry is the outcome for one planned link: created, existing, planned or failed.
+type LinkEntry struct {
+	From   string `json:"from"`
+	Type   string `json:"type"`
+	To     string `json:"to"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Entry, link and outcome statuses.
+const (
+	StatusCreated  = "created"
+	StatusUpdated  = "updated"
+	StatusExisting = "existing"
+	StatusPlanned  = "planned"
+	StatusFailed   = "failed"
+)
+
+// Apply creates the missing issues level by level, so parents exist before
+// their children, then adds links, moves new issues to their planned status
+// and posts DoD comments. Keys are saved to
+// the plan file after every batch; an interrupted run resumes where it
+// stopped. Failures of single items are reported in the result; children of
+// a failed item are not created.
+func (a *Applier) Apply(p *Plan) (*Result, error) {
+	project := cmp.Or(p.Project, a.Project)
+	if project == "" {
+		return nil, fmt.Errorf("plan has no project: add 'project: KEY' or pass --project")
+	}
+
+	entries := map[*Item]*Entry{}
+	for _, level := range p.Levels() {
+		var (
+			todo []*Item
+			reqs []*jira.CreateIssueRequest
+		)
+		for _, it := range level {
+			e := &Entry{Key: it.Key, Type: a.typeName(it.Type), Summary: it.Summary}
+			entries[it] = e
+			switch {
+			case it.Key != "":
+				e.Status = StatusExisting
+			case it.Parent != nil && entries[it.Parent].Status == StatusFailed:
+				e.Status, e.Error = StatusFailed, "parent was not created"
+			case a.DryRun:
+				e.Status = StatusPlanned
+			default:
+				req, err := a.request(project, it)
+				if err != nil {
+					e.Status, e.Error = StatusFailed, err.Error()
+					continue
+				}
+				todo = append(todo, it)
+				reqs = append(reqs, req)
+			}
+		}
+		if len(reqs) == 0 {
+			continue
+		}
+
+		keys, errs := a.create(reqs)
+		for i, it := range todo {
+			e := entries[it]
+			if errs[i] != nil {
+				e.Status, e.Error = StatusFailed, errs[i].Error()
+				continue
+			}
+			p.SetKey(it, keys[i])
+			e.Key, e.Status = keys[i], StatusCreated
+		}
+		if p.Path() != "" {
+			if err := p.Save(); err != nil {
+				return nil, fmt.Errorf("%w (created so far: %s)", err, strings.Join(createdKeys(p, entries), ", "))
+			}
+		}
+	}
+
+	res := &Result{Project: project, DryRun: a.DryRun}
+	for _, it := range p.Items() {
+		e := entries[it]
+		e.Depth = depth(it)
+		res.Issues = append(res.Issues, *e)
+		if e.Status == StatusFailed {
+			res.Failed++
+		}
+	}
+
+	links, err := a.link(p, entries)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range links {
+		if l.Status == StatusFailed {
+			res.Failed++
+		}
+	}
+	res.Links = links
+
+	if a.DryRun {
+		return res, nil
+	}
+	for i, it := range p.Items() {
+		if entries[it].Status != StatusCreated {
+			continue
+		}
+		if it.Status != "" {
+			if err := a.transitionCreated(it.Key, it.Status); err != nil {
+				res.Issues[i].Error = err.Error()
+				res.Failed++
+			}
+		}
+		if it.DoD != "" && a.PostDoD != nil {
+			if err := a.PostDoD(it.Key, it.DoD); err != nil {
+				res.Issues[i].Error = fmt.Sprintf("posting DoD: %v", err)
+				res.Failed++
+			}
+		}
+	}
+	return res, nil
+}
+
+// transitionCreated moves a new issue to status unless it starts there.
+func (a *Applier) transitionCreated(key, status string) error {
+	issue, err := a.Client.GetIssue(key, []string{"status"})
+	if err != nil {
+		return err
+	}
+	if issue.Fields.Status != nil && strings.EqualFold(issue.Fields.Status.Name, status) {
+		return nil
+	}
+	return a.transition(key, status)
+}
+
+// transition moves an issue to status through the transition leading there.
+func (a *Applier) transition(key, status string) error {
+	transitions, err := a.Client.GetTransitions(key)
+	if err != nil {
+		return err
+	}
+	var available []string
+	for _, t := range transitions {
+		if strings.EqualFold(t.To.Name, status) {
+			return a.Client.DoTransition(key, t.ID, nil)
+		}
+		available = append(available, t.To.Name)
+	}
+	return fmt.Errorf("no transition to %q from the current status (available: %s)", status, strings.Join(available, ", "))
+}
+
+func (a *Applier) typeName(t string) string {
+	if a.TypeName != nil {
+		return a.TypeName(t)
+	}
+	return t
+}
+
+// request builds the create request of an item whose parent has a key.
+func (a *Applier) request(project string, it *Item) (*jira.CreateIssueRequest, error) {
+	req := &jira.CreateIssueRequest{
+		Fields: jira.CreateIssueFields{
+			Project:   jira.ProjectRef{Key: project},
+			IssueType: jira.IssueTypeRef{Name: a.typeName(it.Type)},
+			Summary:   it.Summary,
+			Labels:    it.Labels,
+		},
+	}
+	for _, name := range it.Components {
+		req.Fields.Components = append(req.Fields.Components, jira.ComponentRef{Name: name})
+	}
+	if it.Description != "" {
+		req.Fields.Description = jira.NewADFParagraphs(paragraphs(it.Description))
+	}
+	if it.Priority != "" {
+		req.Fields.Priority = &jira.PriorityRef{Name: it.Priority}
+	}
+	if it.Assignee != "" && a.ResolveUser != nil {
+		ref, err := a.ResolveUser(it.Assignee, project)
+		if err != nil {
+			return nil, err
+		}
+		req.Fields.Assignee = ref
+	}
+
+	extra := map[string]interface{}{}
+	if parent := it.Parent; parent != nil {
+		if a.EpicLinkField != "" && strings.EqualFold(a.typeName(parent.Type), "Epic") {
+			extra[a.EpicLinkField] = parent.Key
+		} else {
+			req.Fields.Parent = &jira.IssueRef{Key: parent.Key}
+		}
+	}
+	for name, v := range it.Fields {
+		id := name
+		if !strings.HasPrefix(name, "customfield_") && a.FieldID != nil {
+			var err error
+			if id, err = a.FieldID(name); err != nil {
+				return nil, err
+			}
+		}
+		extra[id] = v
+	}
+	if len(extra) > 0 {
+		req.Fields.Extra = extra
+	}
+	return req, nil
+}
+
+// create creates issues, in bulk on Cloud and one by one elsewhere, and
+// returns the key or error of each request.
+func (a *Applier) create(reqs []*jira.CreateIssueRequest) ([]string, []error) {
+	keys := make([]string, len(reqs))
+	errs := make([]error, len(reqs))
+
+	if !a.Client.IsCloud() {
+		for i, req := range reqs {
+			resp, err := a.Client.CreateIssue(req)
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			keys[i] = resp.Key
+		}
+		return keys, errs
+	}
+
+	offset := 0
+	for chunk := range slices.Chunk(reqs, bulkSize) {
+		resp, err := a.Client.CreateIssuesBulk(chunk)
+		if err != nil {
+			for i := range chunk {
+				errs[offset+i] = err
+			}
+			offset += len(chunk)
+			continue
+		}
+
+		failed := map[int]error{}
+		for _, e := range resp.Errors {
+			failed[e.FailedElementNumber] = &e.ElementErrors
+		}
+		created := resp.Issues
+		for i := range chunk {
+			switch err, ok := failed[i]; {
+			case ok:
+				errs[offset+i] = err
+			case len(created) == 0:
+				errs[offset+i] = fmt.Errorf("missing from the bulk create response")
+			default:
+				keys[offset+i] = created[0].Key
+				created = created[1:]
+			}
+		}
+		offset += len(chunk)
+	}
+	return keys, errs
+}
+
+// link creates the planned links that do not exist yet. A link type can be
+// named by its name ("Blocks") or either description ("blocks", "is blocked
+// by"); an inward description links in the other direction.
+func (a *Applier) link(p *Plan, entries map[*Item]*Entry) ([]LinkEntry, error) {
+	var linked []*Item
+	for _, it := range p.Items() {
+		if len(it.Links) > 0 && entries[it].Status != StatusFailed {
+			linked = append(linked, it)
+		}
+	}
+	if len(linked) == 0 {
+		return nil, nil
+	}
+
+	types, err := a.Client.ListIssueLinkTypes()
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string][]jira.IssueLink{}
+
+	var out []LinkEntry
+	for _, it := range linked {
+		for _, l := range it.Links {
+			from, to := cmp.Or(it.Key, it.Summary), l.To
+			if target := p.Lookup(l.To); target != nil {
+				to = cmp.Or(target.Key, target.Summary)
+			}
+			e := LinkEntry{From: from, Type: l.Type, To: to}
+
+			lt, inward := matchLinkType(types, l.Type)
+			switch {
+			case lt == nil:
+				e.Status, e.Error = StatusFailed, fmt.Sprintf("unknown link type %q", l.Type)
+			case a.DryRun && (it.Key == "" || !isIssueKey(to)):
+				e.Status = StatusPlanned
+			case !isIssueKey(to):
+				e.Status, e.Error = StatusFailed, fmt.Sprintf("%s was not created", to)
+			default:
+				// Existing links are read from the item's own issue, which
+				// lists inward links too: one read per item, whichever way
+				// its links point.
+				links, ok := existing[from]
+				if !ok {
+					issue, err := a.Client.GetIssue(from, []string{"issuelinks"})
+					if err != nil {
+						e.Status, e.Error = StatusFailed, err.Error()
+						break
+					}
+					links = issue.Fields.IssueLinks
+					existing[from] = links
+				}
+				outFrom, outTo := from, to
+				if inward {
+					outFrom, outTo = to, from
+				}
+				switch {
+				case hasLink(links, lt.Name, to, inward):
+					e.Status = StatusExisting
+				case a.DryRun:
+					e.Status = StatusPlanned
+				default:
+					if err := a.Client.CreateIssueLink(lt.Name, outFrom, outTo); err != nil {
+						e.Status, e.Error = StatusFailed, err.Error()
+						break
+					}
+					e.Status = StatusCreated
+				}
+			}
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func matchLinkType(types []jira.IssueLinkType, s string) (*jira.IssueLinkType, bool) {
+	for i, t := range types {
+		if strings.EqualFold(t.Name, s) || strings.EqualFold(t.Outward, s) {
+			return &types[i], false
+		}
+	}
+	for i, t := range types {
+		if strings.EqualFold(t.Inward, s) {
+			return &types[i], true
+		}
+	}
+	return nil, false
+}
+
+// hasLink reports whether links, as listed on an issue, include one of type
+// name to the issue key: outward ("blocks key") or, if inward, inward ("is
+// blocked by key").
+func hasLink(links []jira.IssueLink, name, key string, inward bool) bool {
+	for _, l := range links {
+		other := l.OutwardIssue
+		if inward {
+			other = l.InwardIssue
+		}
+		if strings.EqualFold(l.Type.Name, name) && other != nil && strings.EqualFold(other.Key, key) {
+			return true
+		}
+	}
+	return false
+}
+
+var issueKeyRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*-\d+$`)
+
+func isIssueKey(s string) bool {
+	return issueKeyRe.MatchString(s)
+}
+
+func paragraphs(s string) []string {
+	var out []string
+	for _, para := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n\n") {
+		if para = strings.TrimSpace(para); para != "" {
+			out = append(out, para)
+		}
+	}
+	return out
+}
+
+func depth(it *Item) int {
+	d := 0
+	for p := it.Parent; p != nil; p = p.Parent {
+		d++
+	}
+	return d
+}
+
+func createdKeys(p *Plan, entries map[*Item]*Entry) []string {
+	var keys []string
+	for _, it := range p.Items() {
+		if e := entries[it]; e != nil && e.Status == StatusCreated {
+			keys = append(keys, e.Key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,288 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// fakeJira creates issues with sequential keys and records links.
+type fakeJira struct {
+	mu        sync.Mutex
+	next      int
+	created   []map[string]any // fields of created issues, in order
+	bulk      int
+	links     []string // "from type to"
+	linkReads []string // issues whose links were read
+	fail      string   // summary that fails to create
+}
+
+func (f *fakeJira) handler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		path := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/rest/api/3/"), "/rest/api/2/")
+
+		switch {
+		case r.Method == http.MethodPost && path == "issue/bulk":
+			f.bulk++
+			var body jira.BulkCreateRequest
+			json.NewDecoder(r.Body).Decode(&body)
+			var resp jira.BulkCreateResponse
+			for i, u := range body.IssueUpdates {
+				fields := u["fields"].(map[string]any)
+				if fields["summary"] == f.fail {
+					resp.Errors = append(resp.Errors, jira.BulkCreateError{Status: 400, FailedElementNumber: i,
+						ElementErrors: jira.APIError{Errors: map[string]string{"summary": "rejected"}}})
+					continue
+				}
+				resp.Issues = append(resp.Issues, jira.CreateIssueResponse{Key: f.add(fields)})
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(resp)
+		case r.Method == http.MethodPost && path == "issue":
+			var body struct {
+				Fields map[string]any `json:"fields"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(jira.CreateIssueResponse{Key: f.add(body.Fields)})
+		case path == "issueLinkType":
+			w.Write([]byte(`{"issueLinkTypes":[{"name":"Blocks","inward":"is blocked by","outward":"blocks"}]}`))
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "issue/"):
+			key := strings.TrimPrefix(path, "issue/")
+			if r.URL.Query().Get("fields") == "issuelinks" {
+				f.linkReads = append(f.linkReads, key)
+			}
+			var links []jira.IssueLink
+			for _, l := range f.links {
+				parts := strings.Fields(l)
+				switch key {
+				case parts[0]:
+					links = append(links, jira.IssueLink{Type: jira.IssueLinkType{Name: parts[1]}, OutwardIssue: &jira.Issue{Key: parts[2]}})
+				case parts[2]:
+					links = append(links, jira.IssueLink{Type: jira.IssueLinkType{Name: parts[1]}, InwardIssue: &jira.Issue{Key: parts[0]}})
+				}
+			}
+			json.NewEncoder(w).Encode(jira.Issue{Key: key, Fields: jira.IssueFields{IssueLinks: links}})
+		case r.Method == http.MethodPost && path == "issueLink":
+			var body struct {
+				Type         map[string]string `json:"type"`
+				InwardIssue  jira.IssueRef     `json:"inwardIssue"`
+				OutwardIssue jira.IssueRef     `json:"outwardIssue"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			f.links = append(f.links, body.InwardIssue.Key+" "+body.Type["name"]+" "+body.OutwardIssue.Key)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func (f *fakeJira) add(fields map[string]any) string {
+	f.next++
+	f.created = append(f.created, fields)
+	return fmt.Sprintf("PROJ-%d", f.next)
+}
+
+func newApplier(t *testing.T, f *fakeJira, instance jira.InstanceType) *Applier {
+	srv := httptest.NewServer(f.handler(t))
+	t.Cleanup(srv.Close)
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: instance})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return &Applier{Client: client, TypeName: strings.ToUpper}
+}
+
+const applyPlan = `project: PROJ
+issues:
+  - type: epic
+    summary: Auth
+    children:
+      - id: login
+        summary: Login
+        dod: Tests pass
+        links: [{type: is blocked by, to: signup}]
+        children:
+          - summary: Handler
+      - id: signup
+        summary: Signup
+  - summary: Docs
+    links: [{type: blocks, to: PROJ-99}]
+`
+
+func writePlan(t *testing.T, src string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "plan.yaml")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestApply_CreatesLevelsLinksAndWritesKeys(t *testing.T) {
+	f := &fakeJira{}
+	a := newApplier(t, f, jira.InstanceCloud)
+	var dods []string
+	a.PostDoD = func(key, text string) error {
+		dods = append(dods, key+": "+text)
+		return nil
+	}
+
+	path := writePlan(t, applyPlan)
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	res, err := a.Apply(p)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if res.Failed != 0 {
+		t.Fatalf("failed = %d: %+v %+v", res.Failed, res.Issues, res.Links)
+	}
+
+	// Level 0: Auth, Docs; level 1: Login, Signup; level 2: Handler.
+	if f.bulk != 3 || len(f.created) != 5 {
+		t.Fatalf("bulk requests = %d, created = %d", f.bulk, len(f.created))
+	}
+	if f.created[2]["summary"] != "Login" || f.created[2]["parent"].(map[string]any)["key"] != "PROJ-1" {
+		t.Errorf("login fields = %v", f.created[2])
+	}
+	if f.created[4]["parent"].(map[string]any)["key"] != "PROJ-3" || f.created[4]["issuetype"].(map[string]any)["name"] != "SUBTASK" {
+		t.Errorf("handler fields = %v", f.created[4])
+	}
+
+	// "Login is blocked by Signup" is created as "Signup blocks Login".
+	if strings.Join(f.links, ";") != "PROJ-4 Blocks PROJ-3;PROJ-2 Blocks PROJ-99" {
+		t.Errorf("links = %v", f.links)
+	}
+	if len(dods) != 1 || dods[0] != "PROJ-3: Tests pass" {
+		t.Errorf("dods = %v", dods)
+	}
+
+	saved, err := Load(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	var keys []string
+	for _, it := range saved.Items() {
+		keys = append(keys, it.Key)
+	}
+	if strings.Join(keys, ",") != "PROJ-1,PROJ-3,PROJ-5,PROJ-4,PROJ-2" {
+		t.Errorf("saved keys = %v", keys)
+	}
+
+	// Applying again creates nothing. Existing links are read from the
+	// items' own issues, also for Login's inward link.
+	f.linkReads = nil
+	res, err = a.Apply(saved)
+	if err != nil {
+		t.Fatalf("second Apply: %v", err)
+	}
+	if len(f.created) != 5 || len(f.links) != 2 || len(dods) != 1 {
+		t.Errorf("second apply changed Jira: created %d, links %v, dods %v", len(f.created), f.links, dods)
+	}
+	for _, e := range res.Issues {
+		if e.Status != StatusExisting {
+			t.Errorf("%s status = %s", e.Summary, e.Status)
+		}
+	}
+	for _, l := range res.Links {
+		if l.Status != StatusExisting {
+			t.Errorf("link %+v", l)
+		}
+	}
+	if strings.Join(f.linkReads, ",") != "PROJ-3,PROJ-2" {
+		t.Errorf("link reads = %v", f.linkReads)
+	}
+}
+
+func TestApply_FailedParentSkipsChildren(t *testing.T) {
+	f := &fakeJira{fail: "Auth"}
+	a := newApplier(t, f, jira.InstanceCloud)
+
+	p, err := Parse([]byte(applyPlan), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	res, err := a.Apply(p)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if len(f.created) != 1 || f.created[0]["summary"] != "Docs" {
+		t.Errorf("created = %v", f.created)
+	}
+	statuses := map[string]string{}
+	for _, e := range res.Issues {
+		statuses[e.Summary] = e.Status + " " + e.Error
+	}
+	if statuses["Auth"] != "failed summary: rejected" || statuses["Handler"] != "failed parent was not created" || statuses["Docs"] != "created " {
+		t.Errorf("statuses = %v", statuses)
+	}
+	if res.Failed != 4 {
+		t.Errorf("failed = %d, want 4", res.Failed)
+	}
+	if len(res.Links) != 1 || res.Links[0].From != "PROJ-1" {
+		t.Errorf("links = %+v, want only the link of Docs", res.Links)
+	}
+}
+
+func TestApply_ServerEpicLinkAndSingleCreates(t *testing.T) {
+	f := &fakeJira{}
+	a := newApplier(t, f, jira.InstanceServer)
+	a.EpicLinkField = "customfield_10014"
+
+	p, err := Parse([]byte("project: PROJ\nissues:\n  - type: epic\n    summary: Auth\n    description: Sign in\n    children:\n      - summary: Login\n"), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if _, err := a.Apply(p); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if f.bulk != 0 || len(f.created) != 2 {
+		t.Fatalf("bulk = %d, created = %d", f.bulk, len(f.created))
+	}
+	if f.created[0]["description"] != "Sign in" {
+		t.Errorf("description = %#v", f.created[0]["description"])
+	}
+	if f.created[1]["customfield_10014"] != "PROJ-1" || f.created[1]["parent"] != nil {
+		t.Errorf("story fields = %v", f.created[1])
+	}
+}
+
+func TestApply_DryRun(t *testing.T) {
+	f := &fakeJira{}
+	a := newApplier(t, f, jira.InstanceCloud)
+	a.DryRun = true
+
+	path := writePlan(t, applyPlan)
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	res, err := a.Apply(p)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if len(f.created) != 0 || len(f.links) != 0 {
+		t.Errorf("dry run changed Jira: %v %v", f.created, f.links)
+	}
+	if len(res.Issues) != 5 || res.Issues[0].Status != StatusPlanned || res.Issues[2].Depth != 2 {
+		t.Errorf("issues = %+v", res.Issues)
+	}
+	if data, _ := os.ReadFile(path); string(data) != applyPlan {
+		t.Error("dry run rewrote the plan file")
+	}
+}
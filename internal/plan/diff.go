@@ -0,0 +1,445 @@
+package plan
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/relux-works/skill-jira-management/internal/parallel"
+)
+
+// Diff is what applying a plan would change in Jira, plus drift: differences
+// applying cannot resolve, such as deleted issues or issues Jira has under a
+// planned parent that the plan does not list.
+type Diff struct {
+	Project     string   `json:"project"`
+	Changes     []Change `json:"changes"`
+	Drift       []Drift  `json:"drift,omitempty"`
+	Creates     int      `json:"creates"`
+	Updates     int      `json:"updates"`
+	Transitions int      `json:"transitions"`
+	Links       int      `json:"links"`
+}
+
+// Empty reports whether Jira matches the plan.
+func (d *Diff) Empty() bool {
+	return len(d.Changes) == 0 && len(d.Drift) == 0
+}
+
+// Change is what applying the plan does to one item.
+type Change struct {
+	Action     string        `json:"action"` // create or update
+	Key        string        `json:"key,omitempty"`
+	Type       string        `json:"type"`
+	Summary    string        `json:"summary"`
+	Fields     []FieldChange `json:"fields,omitempty"`
+	Transition *FieldChange  `json:"transition,omitempty"`
+	Links      []Link        `json:"links,omitempty"`
+	Depth      int           `json:"depth"`
+
+	item *Item
+}
+
+// FieldChange is one field going from its Jira value to the planned value.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to"`
+}
+
+// Drift is a difference between the plan and Jira that applying does not resolve.
+type Drift struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary,omitempty"`
+	Reason  string `json:"reason"`
+}
+
+// Change actions.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+)
+
+// Diff compares the plan with Jira. Only what the plan sets is compared:
+// an item without labels leaves the issue's labels alone.
+func (a *Applier) Diff(p *Plan) (*Diff, error) {
+	project := cmp.Or(p.Project, a.Project)
+	if project == "" {
+		return nil, fmt.Errorf("plan has no project: add 'project: KEY' or pass --project")
+	}
+
+	issues, missing, err := a.fetchIssues(p)
+	if err != nil {
+		return nil, err
+	}
+	var types []jira.IssueLinkType
+	if slices.ContainsFunc(p.Items(), func(it *Item) bool { return len(it.Links) > 0 }) {
+		if types, err = a.Client.ListIssueLinkTypes(); err != nil {
+			return nil, err
+		}
+	}
+
+	d := &Diff{Project: project}
+	for _, it := range p.Items() {
+		ch := Change{Key: it.Key, Type: a.typeName(it.Type), Summary: it.Summary, Depth: depth(it), item: it}
+		if it.Key == "" {
+			ch.Action = ActionCreate
+			ch.Fields = a.plannedFields(it)
+			if it.Status != "" {
+				ch.Transition = &FieldChange{Field: "status", To: it.Status}
+			}
+			ch.Links = it.Links
+			d.add(ch)
+			continue
+		}
+		if missing[strings.ToUpper(it.Key)] {
+			d.Drift = append(d.Drift, Drift{Key: it.Key, Summary: it.Summary, Reason: "issue not found"})
+			continue
+		}
+
+		issue := issues[strings.ToUpper(it.Key)]
+		ch.Action = ActionUpdate
+		drift := a.compare(project, p, it, issue, types, &ch)
+		d.Drift = append(d.Drift, drift...)
+		if len(ch.Fields) > 0 || ch.Transition != nil || len(ch.Links) > 0 {
+			d.add(ch)
+		}
+	}
+
+	unplanned, err := a.unplannedChildren(p)
+	if err != nil {
+		return nil, err
+	}
+	d.Drift = append(d.Drift, unplanned...)
+	return d, nil
+}
+
+func (d *Diff) add(ch Change) {
+	d.Changes = append(d.Changes, ch)
+	switch ch.Action {
+	case ActionCreate:
+		d.Creates++
+	case ActionUpdate:
+		if len(ch.Fields) > 0 {
+			d.Updates++
+		}
+	}
+	if ch.Transition != nil {
+		d.Transitions++
+	}
+	d.Links += len(ch.Links)
+}
+
+// plannedFields lists the fields a new item sets, for display.
+func (a *Applier) plannedFields(it *Item) []FieldChange {
+	var out []FieldChange
+	add := func(field, value string) {
+		if value != "" {
+			out = append(out, FieldChange{Field: field, To: value})
+		}
+	}
+	if it.Parent != nil {
+		add("parent", cmp.Or(it.Parent.Key, it.Parent.Summary))
+	}
+	add("assignee", it.Assignee)
+	add("priority", it.Priority)
+	add("labels", strings.Join(it.Labels, ", "))
+	add("components", strings.Join(it.Components, ", "))
+	for _, name := range sortedFieldNames(it.Fields) {
+		add(name, plannedString(it.Fields[name]))
+	}
+	return out
+}
+
+// compare fills ch with the fields, status and links of issue that differ
+// from the item, and returns drift it cannot change.
+func (a *Applier) compare(project string, p *Plan, it *Item, issue *jira.Issue, types []jira.IssueLinkType, ch *Change) []Drift {
+	f := &issue.Fields
+	var drift []Drift
+	driftf := func(format string, args ...any) {
+		drift = append(drift, Drift{Key: it.Key, Summary: it.Summary, Reason: fmt.Sprintf(format, args...)})
+	}
+	change := func(field, from, to string) {
+		ch.Fields = append(ch.Fields, FieldChange{Field: field, From: from, To: to})
+	}
+
+	if f.Project.Key != "" && !strings.EqualFold(f.Project.Key, project) {
+		driftf("issue is in project %s", f.Project.Key)
+	}
+	if want := a.typeName(it.Type); it.typed && f.IssueType.Name != "" && !strings.EqualFold(f.IssueType.Name, want) {
+		driftf("type is %s, plan says %s", f.IssueType.Name, want)
+	}
+
+	if strings.TrimSpace(it.Summary) != strings.TrimSpace(f.Summary) {
+		change("summary", f.Summary, it.Summary)
+	}
+	if it.Description != "" && normalizeText(it.Description) != normalizeText(f.DescriptionText()) {
+		change("description", abbreviate(f.DescriptionText()), abbreviate(it.Description))
+	}
+	if it.Assignee != "" && a.ResolveUser != nil {
+		ref, err := a.ResolveUser(it.Assignee, project)
+		switch {
+		case err != nil:
+			driftf("assignee: %v", err)
+		case !sameUser(ref, f.Assignee):
+			from := "unassigned"
+			if f.Assignee != nil {
+				from = f.Assignee.DisplayName
+			}
+			change("assignee", from, it.Assignee)
+		}
+	}
+	if it.Priority != "" && (f.Priority == nil || !strings.EqualFold(f.Priority.Name, it.Priority)) {
+		from := ""
+		if f.Priority != nil {
+			from = f.Priority.Name
+		}
+		change("priority", from, it.Priority)
+	}
+	if it.Labels != nil && !sameSet(it.Labels, f.Labels, false) {
+		change("labels", strings.Join(f.Labels, ", "), strings.Join(it.Labels, ", "))
+	}
+	if it.Components != nil {
+		var names []string
+		for _, c := range f.Components {
+			names = append(names, c.Name)
+		}
+		if !sameSet(it.Components, names, true) {
+			change("components", strings.Join(names, ", "), strings.Join(it.Components, ", "))
+		}
+	}
+	if it.Parent != nil {
+		have := a.parentKey(it, issue)
+		if it.Parent.Key == "" || !strings.EqualFold(have, it.Parent.Key) {
+			change("parent", have, cmp.Or(it.Parent.Key, it.Parent.Summary))
+		}
+	}
+	for _, name := range sortedFieldNames(it.Fields) {
+		id := name
+		if !strings.HasPrefix(name, "customfield_") && a.FieldID != nil {
+			var err error
+			if id, err = a.FieldID(name); err != nil {
+				driftf("%v", err)
+				continue
+			}
+		}
+		have, want := fieldString(f.Raw(id)), plannedString(it.Fields[name])
+		if have != want {
+			change(name, have, want)
+		}
+	}
+
+	if it.Status != "" && f.Status != nil && !strings.EqualFold(f.Status.Name, it.Status) {
+		ch.Transition = &FieldChange{Field: "status", From: f.Status.Name, To: it.Status}
+	}
+
+	for _, l := range it.Links {
+		lt, inward := matchLinkType(types, l.Type)
+		if lt == nil {
+			driftf("unknown link type %q", l.Type)
+			continue
+		}
+		to := l.To
+		if target := p.Lookup(l.To); target != nil {
+			to = target.Key
+		}
+		if to == "" || !hasLink(f.IssueLinks, lt.Name, to, inward) {
+			ch.Links = append(ch.Links, l)
+		}
+	}
+	return drift
+}
+
+// parentKey returns the issue's parent, read from the Epic Link field when
+// the item's parent is an epic on Server/DC.
+func (a *Applier) parentKey(it *Item, issue *jira.Issue) string {
+	if a.EpicLinkField != "" && strings.EqualFold(a.typeName(it.Parent.Type), "Epic") {
+		var key string
+		json.Unmarshal(issue.Fields.CustomFields[a.EpicLinkField], &key)
+		return key
+	}
+	if issue.Fields.Parent != nil {
+		return issue.Fields.Parent.Key
+	}
+	return ""
+}
+
+// fetchIssues gets the issues of all keyed items. Keys Jira does not know
+// are returned as missing.
+func (a *Applier) fetchIssues(p *Plan) (map[string]*jira.Issue, map[string]bool, error) {
+	fields := []string{"summary", "description", "status", "assignee", "priority", "labels",
+		"components", "parent", "issuelinks", "issuetype", "project"}
+	if a.EpicLinkField != "" {
+		fields = append(fields, a.EpicLinkField)
+	}
+	for _, it := range p.Items() {
+		for name := range it.Fields {
+			if strings.HasPrefix(name, "customfield_") {
+				fields = append(fields, name)
+			} else if a.FieldID != nil {
+				if id, err := a.FieldID(name); err == nil {
+					fields = append(fields, id)
+				}
+			}
+		}
+	}
+
+	var keys []string
+	for _, it := range p.Items() {
+		if it.Key != "" {
+			keys = append(keys, strings.ToUpper(it.Key))
+		}
+	}
+	// A nil issue without an error is a key Jira does not know.
+	fetched, err := parallel.Map(len(keys), func(i int) (*jira.Issue, error) {
+		issue, err := a.Client.GetIssue(keys[i], fields)
+		var apiErr *jira.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return issue, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	issues, missing := map[string]*jira.Issue{}, map[string]bool{}
+	for i, key := range keys {
+		if fetched[i] == nil {
+			missing[key] = true
+		} else {
+			issues[key] = fetched[i]
+		}
+	}
+	return issues, missing, nil
+}
+
+// unplannedChildren reports issues Jira has under planned items that list
+// children, but which the plan does not.
+func (a *Applier) unplannedChildren(p *Plan) ([]Drift, error) {
+	planned := map[string]bool{}
+	var parents, epics []string
+	for _, it := range p.Items() {
+		if it.Key == "" {
+			continue
+		}
+		planned[strings.ToUpper(it.Key)] = true
+		if len(it.Children) == 0 {
+			continue
+		}
+		parents = append(parents, it.Key)
+		if strings.EqualFold(a.typeName(it.Type), "Epic") {
+			epics = append(epics, it.Key)
+		}
+	}
+	if len(parents) == 0 {
+		return nil, nil
+	}
+
+	jql := fmt.Sprintf("parent in (%s)", strings.Join(parents, ", "))
+	if a.EpicLinkField != "" && len(epics) > 0 {
+		jql += fmt.Sprintf(` OR "Epic Link" in (%s)`, strings.Join(epics, ", "))
+	}
+	children, err := a.Client.SearchAll(jql+" ORDER BY key ASC", []string{"summary"})
+	if err != nil {
+		return nil, fmt.Errorf("searching children of planned issues: %w", err)
+	}
+
+	var drift []Drift
+	for _, c := range children {
+		if !planned[strings.ToUpper(c.Key)] {
+			drift = append(drift, Drift{Key: c.Key, Summary: c.Fields.Summary, Reason: "not in the plan"})
+		}
+	}
+	return drift, nil
+}
+
+func sameUser(ref *jira.UserRef, u *jira.User) bool {
+	switch {
+	case ref == nil || u == nil:
+		return ref == nil && u == nil
+	case ref.AccountID != "":
+		return ref.AccountID == u.AccountID
+	}
+	return strings.EqualFold(ref.Name, u.Name)
+}
+
+func sameSet(a, b []string, fold bool) bool {
+	norm := func(s []string) []string {
+		out := make([]string, len(s))
+		for i, v := range s {
+			if fold {
+				v = strings.ToLower(v)
+			}
+			out[i] = v
+		}
+		sort.Strings(out)
+		return slices.Compact(out)
+	}
+	return slices.Equal(norm(a), norm(b))
+}
+
+// normalizeText compares descriptions regardless of line wrapping and spacing.
+func normalizeText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func abbreviate(s string) string {
+	s = normalizeText(s)
+	if r := []rune(s); len(r) > 60 {
+		return string(r[:57]) + "..."
+	}
+	return s
+}
+
+// fieldString renders a custom field value the way plans write it: options
+// by value or name, lists comma-separated by fmt.
+func fieldString(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return string(raw)
+	}
+	return fmt.Sprint(simplify(v))
+}
+
+// plannedString renders a planned field value like fieldString renders Jira's,
+// so a select written as {value: High} compares equal to the option High.
+func plannedString(v any) string {
+	return fmt.Sprint(simplify(v))
+}
+
+func simplify(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for _, key := range []string{"value", "name", "key", "displayName"} {
+			if s, ok := t[key]; ok {
+				return s
+			}
+		}
+	case []any:
+		out := make([]any, len(t))
+		for i, item := range t {
+			out[i] = simplify(item)
+		}
+		return out
+	}
+	return v
+}
+
+func sortedFieldNames(fields map[string]any) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
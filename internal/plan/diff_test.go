@@ -0,0 +1,248 @@
+package plan
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// stateJira serves fixed issues and records the writes a reconcile makes.
+type stateJira struct {
+	mu          sync.Mutex
+	issues      map[string]string // key -> fields JSON
+	children    string            // search response issues JSON
+	updates     []string          // "KEY body"
+	transitions []string          // "KEY id"
+	created     []string
+	links       []string
+}
+
+func (s *stateJira) handler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		path := strings.TrimPrefix(r.URL.Path, "/rest/api/3/")
+		body, _ := io.ReadAll(r.Body)
+
+		switch {
+		case path == "search/jql":
+			w.Write([]byte(`{"issues":` + s.children + `,"isLast":true}`))
+		case path == "issueLinkType":
+			w.Write([]byte(`{"issueLinkTypes":[{"name":"Blocks","inward":"is blocked by","outward":"blocks"}]}`))
+		case path == "issueLink":
+			s.links = append(s.links, string(body))
+			w.WriteHeader(http.StatusCreated)
+		case path == "issue/bulk":
+			var req jira.BulkCreateRequest
+			json.Unmarshal(body, &req)
+			var resp jira.BulkCreateResponse
+			for _, u := range req.IssueUpdates {
+				s.created = append(s.created, u["fields"].(map[string]any)["summary"].(string))
+				resp.Issues = append(resp.Issues, jira.CreateIssueResponse{Key: "PROJ-10"})
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(resp)
+		case strings.HasSuffix(path, "/transitions"):
+			key := strings.TrimSuffix(strings.TrimPrefix(path, "issue/"), "/transitions")
+			if r.Method == http.MethodPost {
+				var req jira.DoTransitionRequest
+				json.Unmarshal(body, &req)
+				s.transitions = append(s.transitions, key+" "+req.Transition.ID)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.Write([]byte(`{"transitions":[{"id":"11","name":"Start","to":{"name":"In Progress"}},{"id":"31","name":"Finish","to":{"name":"Done"}}]}`))
+		case strings.HasPrefix(path, "issue/"):
+			key := strings.TrimPrefix(path, "issue/")
+			if r.Method == http.MethodPut {
+				s.updates = append(s.updates, key+" "+string(body))
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			fields, ok := s.issues[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"errorMessages":["Issue does not exist"]}`))
+				return
+			}
+			w.Write([]byte(`{"key":"` + key + `","fields":` + fields + `}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+const diffPlan = `project: PROJ
+issues:
+  - key: PROJ-1
+    type: epic
+    summary: Auth system
+    status: In Progress
+    labels: [auth]
+    children:
+      - key: PROJ-2
+        summary: Login flow
+        priority: High
+        links: [{type: blocks, to: PROJ-9}]
+      - summary: Signup
+  - key: PROJ-404
+    summary: Gone
+`
+
+func newStateJira() *stateJira {
+	return &stateJira{
+		issues: map[string]string{
+			"PROJ-1": `{"summary":"Auth","status":{"name":"To Do"},"labels":["auth"],"issuetype":{"name":"Epic"},"project":{"key":"PROJ"}}`,
+			"PROJ-2": `{"summary":"Login flow","priority":{"name":"High"},"parent":{"key":"PROJ-1"},"issuetype":{"name":"Story"},"project":{"key":"PROJ"}}`,
+		},
+		children: `[{"key":"PROJ-2","fields":{"summary":"Login flow"}},{"key":"PROJ-3","fields":{"summary":"Stray"}}]`,
+	}
+}
+
+func newStateApplier(t *testing.T, s *stateJira) *Applier {
+	srv := httptest.NewServer(s.handler(t))
+	t.Cleanup(srv.Close)
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: jira.InstanceCloud})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return &Applier{Client: client}
+}
+
+func TestDiff(t *testing.T) {
+	a := newStateApplier(t, newStateJira())
+	p, err := Parse([]byte(diffPlan), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	d, err := a.Diff(p)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+
+	if d.Creates != 1 || d.Updates != 1 || d.Transitions != 1 || d.Links != 1 {
+		t.Errorf("counts = %d creates, %d updates, %d transitions, %d links", d.Creates, d.Updates, d.Transitions, d.Links)
+	}
+	if len(d.Changes) != 3 {
+		t.Fatalf("changes = %+v", d.Changes)
+	}
+
+	epic := d.Changes[0]
+	if epic.Action != ActionUpdate || epic.Key != "PROJ-1" || len(epic.Fields) != 1 ||
+		epic.Fields[0] != (FieldChange{Field: "summary", From: "Auth", To: "Auth system"}) {
+		t.Errorf("epic change = %+v", epic)
+	}
+	if epic.Transition == nil || epic.Transition.From != "To Do" || epic.Transition.To != "In Progress" {
+		t.Errorf("epic transition = %+v", epic.Transition)
+	}
+
+	story := d.Changes[1]
+	if story.Key != "PROJ-2" || len(story.Fields) != 0 || len(story.Links) != 1 || story.Links[0].To != "PROJ-9" {
+		t.Errorf("story change = %+v", story)
+	}
+
+	signup := d.Changes[2]
+	if signup.Action != ActionCreate || signup.Summary != "Signup" || len(signup.Fields) != 1 || signup.Fields[0].To != "PROJ-1" {
+		t.Errorf("signup change = %+v", signup)
+	}
+
+	drift := map[string]string{}
+	for _, dr := range d.Drift {
+		drift[dr.Key] = dr.Reason
+	}
+	if len(drift) != 2 || drift["PROJ-404"] != "issue not found" || drift["PROJ-3"] != "not in the plan" {
+		t.Errorf("drift = %+v", d.Drift)
+	}
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	a := newStateApplier(t, newStateJira())
+	p, err := Parse([]byte("project: PROJ\nissues:\n  - key: PROJ-2\n    summary: Login flow\n    priority: high\n    labels: []\n"), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	d, err := a.Diff(p)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if !d.Empty() {
+		t.Errorf("diff = %+v, want empty", d)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	s := newStateJira()
+	a := newStateApplier(t, s)
+	p, err := Load(writePlan(t, diffPlan))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	d, err := a.Diff(p)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	res, err := a.Reconcile(p, d)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if res.Failed != 0 {
+		t.Errorf("failed = %d: %+v", res.Failed, res)
+	}
+
+	if len(s.created) != 1 || s.created[0] != "Signup" {
+		t.Errorf("created = %v", s.created)
+	}
+	if len(s.updates) != 1 || s.updates[0] != `PROJ-1 {"fields":{"summary":"Auth system"}}` {
+		t.Errorf("updates = %v", s.updates)
+	}
+	if len(s.transitions) != 1 || s.transitions[0] != "PROJ-1 11" {
+		t.Errorf("transitions = %v", s.transitions)
+	}
+	if len(s.links) != 1 || !strings.Contains(s.links[0], `"inwardIssue":{"key":"PROJ-2"}`) {
+		t.Errorf("links = %v", s.links)
+	}
+	if len(res.Updates) != 2 || res.Updates[0].Status != StatusUpdated || res.Updates[1].Action != "transition" {
+		t.Errorf("outcomes = %+v", res.Updates)
+	}
+}
+
+func TestDiff_SelectAndSystemFields(t *testing.T) {
+	s := newStateJira()
+	s.issues["PROJ-2"] = `{"summary":"Login flow","duedate":"2026-11-01","customfield_10050":{"value":"High","id":"1"},"project":{"key":"PROJ"}}`
+	a := newStateApplier(t, s)
+	a.FieldID = func(name string) (string, error) {
+		return map[string]string{"Due date": "duedate", "Severity": "customfield_10050"}[name], nil
+	}
+
+	plan := "project: PROJ\nissues:\n  - key: PROJ-2\n    summary: Login flow\n    fields:\n      Due date: 2026-11-01\n      Severity: {value: High}\n"
+	p, err := Parse([]byte(plan), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	d, err := a.Diff(p)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if !d.Empty() {
+		t.Errorf("diff = %+v, want empty", d)
+	}
+
+	p, err = Parse([]byte(strings.ReplaceAll(plan, "High", "Low")), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if d, err = a.Diff(p); err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if len(d.Changes) != 1 || len(d.Changes[0].Fields) != 1 ||
+		d.Changes[0].Fields[0] != (FieldChange{Field: "Severity", From: "High", To: "Low"}) {
+		t.Errorf("changes = %+v", d.Changes)
+	}
+}
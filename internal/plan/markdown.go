@@ -0,0 +1,231 @@
+package plan
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	mdHeading  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	mdTask     = regexp.MustCompile(`^\s*[-*]\s+\[[ xX]\]\s+(.*)$`)
+	mdKey      = regexp.MustCompile(`^\[([A-Za-z][A-Za-z0-9_]*-\d+)\]\s*`)
+	mdMeta     = regexp.MustCompile(`^([A-Za-z][A-Za-z ]*):\s*(.*)$`)
+	mdLinePfx  = regexp.MustCompile(`^(#{1,6}\s+|\s*[-*]\s+\[[ xX]\]\s+)`)
+	mdMetaKeys = map[string]bool{
+		"id": true, "status": true, "assignee": true, "priority": true, "labels": true,
+		"components": true, "dod": true, "link": true, "field": true, "fields": true,
+	}
+)
+
+// parseMarkdown reads a plan written as
+//
+//	Project: PROJ
+//
+//	# Epic: Auth system
+//	Labels: auth
+//
+//	Description paragraphs.
+//
+//	## Story: Login flow
+//	Link: blocks PROJ-9
+//	Field: Story Points = 5
+//
+//	- [ ] Write handler
+//
+// Headings reading "Type: Summary" are issues, nested by heading depth; other
+// headings below an issue are part of its description. Checklist items are
+// child issues of the heading above them. "Name: value" lines right under a
+// heading set fields, "Field: name = value" (or "Fields: a = 1; b = 2") any
+// other field with a YAML value; the rest is the description. Keys are written
+// back as a "[PROJ-1]" prefix of the heading or checklist text.
+func parseMarkdown(data []byte) (*Plan, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	p := &Plan{lines: strings.Split(text, "\n")}
+
+	type open struct {
+		depth int
+		item  *Item
+	}
+	var (
+		stack   []open
+		current *Item
+		desc    []string
+		inMeta  bool
+		inFence bool
+	)
+	flush := func() {
+		if current != nil {
+			current.Description = strings.TrimSpace(strings.Join(desc, "\n"))
+		}
+		desc = nil
+	}
+
+	for n, line := range p.lines {
+		lineNo := n + 1
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		if inFence || strings.HasPrefix(trimmed, "```") {
+			inMeta = false
+			desc = append(desc, line)
+			continue
+		}
+
+		if m := mdHeading.FindStringSubmatch(line); m != nil {
+			depth := len(m[1])
+			key, rest := splitMarkdownKey(m[2])
+			typ, summary, ok := strings.Cut(rest, ":")
+			if !ok || strings.TrimSpace(typ) == "" {
+				if current != nil && key == "" {
+					desc = append(desc, line) // a heading within the description
+					inMeta = false
+					continue
+				}
+				return nil, fmt.Errorf("line %d: heading %q is not \"Type: Summary\"", lineNo, strings.TrimSpace(m[2]))
+			}
+			flush()
+			for len(stack) > 0 && stack[len(stack)-1].depth >= depth {
+				stack = stack[:len(stack)-1]
+			}
+			it := &Item{Key: key, Type: strings.TrimSpace(typ), Summary: strings.TrimSpace(summary), typed: true, line: n}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1].item
+				it.Parent = parent
+				parent.Children = append(parent.Children, it)
+			} else {
+				p.Issues = append(p.Issues, it)
+			}
+			stack = append(stack, open{depth: depth, item: it})
+			current, inMeta = it, true
+			continue
+		}
+
+		if m := mdTask.FindStringSubmatch(line); m != nil && current != nil {
+			key, summary := splitMarkdownKey(m[1])
+			it := &Item{Key: key, Type: defaultType(current), Summary: strings.TrimSpace(summary), Parent: current, line: n}
+			current.Children = append(current.Children, it)
+			inMeta = false
+			continue
+		}
+
+		if m := mdMeta.FindStringSubmatch(trimmed); m != nil && (inMeta || current == nil) {
+			name, value := strings.ToLower(strings.TrimSpace(m[1])), strings.TrimSpace(m[2])
+			switch {
+			case current == nil && name == "project":
+				p.Project = value
+				continue
+			case current != nil && mdMetaKeys[name]:
+				if err := setMarkdownMeta(current, name, value); err != nil {
+					return nil, fmt.Errorf("line %d: %w", lineNo, err)
+				}
+				continue
+			}
+		}
+
+		if current == nil {
+			continue // preamble
+		}
+		if trimmed != "" {
+			inMeta = false
+		}
+		if !inMeta {
+			desc = append(desc, line)
+		}
+	}
+	flush()
+	return p, nil
+}
+
+func setMarkdownMeta(it *Item, name, value string) error {
+	switch name {
+	case "id":
+		it.ID = value
+	case "status":
+		it.Status = value
+	case "assignee":
+		it.Assignee = value
+	case "priority":
+		it.Priority = value
+	case "labels":
+		it.Labels = append(it.Labels, splitList(value)...)
+	case "components":
+		it.Components = append(it.Components, splitList(value)...)
+	case "dod":
+		it.DoD = value
+	case "link":
+		i := strings.LastIndexAny(value, " \t")
+		if i < 0 {
+			return fmt.Errorf("link %q is not \"<type> <issue>\"", value)
+		}
+		it.Links = append(it.Links, Link{Type: strings.TrimSpace(value[:i]), To: value[i+1:]})
+	case "field", "fields":
+		for _, assignment := range strings.Split(value, ";") {
+			if strings.TrimSpace(assignment) == "" {
+				continue
+			}
+			field, v, ok := strings.Cut(assignment, "=")
+			if field = strings.TrimSpace(field); !ok || field == "" {
+				return fmt.Errorf("field %q is not \"<name> = <value>\"", strings.TrimSpace(assignment))
+			}
+			if it.Fields == nil {
+				it.Fields = map[string]any{}
+			}
+			it.Fields[field] = markdownFieldValue(strings.TrimSpace(v))
+		}
+	}
+	return nil
+}
+
+// markdownFieldValue reads a field value as YAML does in YAML plans: 5 is a
+// number, [a, b] a list, 2026-11-01 a date, "5" quoted text; anything else
+// is kept as written.
+func markdownFieldValue(s string) any {
+	var v any
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil || v == nil {
+		return s
+	}
+	switch v.(type) {
+	case map[string]any:
+		return s
+	case string:
+		if !strings.HasPrefix(s, `"`) && !strings.HasPrefix(s, "'") {
+			return s
+		}
+	}
+	return dateStrings(v)
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// splitMarkdownKey splits a leading "[PROJ-1]" off s.
+func splitMarkdownKey(s string) (key, rest string) {
+	s = strings.TrimSpace(s)
+	if m := mdKey.FindStringSubmatch(s); m != nil {
+		return m[1], s[len(m[0]):]
+	}
+	return "", s
+}
+
+// setMarkdownKey sets the "[KEY]" prefix of a heading or checklist line.
+func setMarkdownKey(line, key string) string {
+	loc := mdLinePfx.FindStringIndex(line)
+	if loc == nil {
+		return line
+	}
+	prefix, text := line[:loc[1]], line[loc[1]:]
+	_, text = splitMarkdownKey(text)
+	return prefix + "[" + key + "] " + text
+}
@@ -0,0 +1,230 @@
+// Package plan reads declarative issue plans — a tree of epics, stories and
+// subtasks in YAML or Markdown — and creates them in Jira. The keys of created
+// issues are written back into the plan file, so applying it again only
+// creates what is still missing. Diff and Reconcile compare keyed items with
+// their issues and make Jira follow the plan.
+package plan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the syntax of a plan file.
+type Format string
+
+const (
+	FormatYAML     Format = "yaml"
+	FormatMarkdown Format = "markdown"
+)
+
+// Plan is a tree of issues to create in one project.
+type Plan struct {
+	Project string
+	Issues  []*Item
+
+	path   string
+	format Format
+	doc    *yaml.Node // YAML source, updated in place by SetKey
+	lines  []string   // Markdown source, updated in place by SetKey
+}
+
+// Item is one planned issue.
+type Item struct {
+	Key         string         `json:"key,omitempty"`
+	ID          string         `json:"id,omitempty"` // local name other items link to
+	Type        string         `json:"type"`
+	Summary     string         `json:"summary"`
+	Description string         `json:"description,omitempty"`
+	Status      string         `json:"status,omitempty"`
+	Assignee    string         `json:"assignee,omitempty"`
+	Priority    string         `json:"priority,omitempty"`
+	Labels      []string       `json:"labels,omitempty"`
+	Components  []string       `json:"components,omitempty"`
+	Fields      map[string]any `json:"fields,omitempty"`
+	Links       []Link         `json:"links,omitempty"`
+	DoD         string         `json:"dod,omitempty"`
+	Children    []*Item        `json:"children,omitempty"`
+
+	Parent *Item `json:"-"`
+
+	typed bool       // the type was given, not defaulted
+	node  *yaml.Node // YAML mapping of the item
+	line  int        // Markdown line of the item
+}
+
+// Link is a planned issue link: this item <Type> To, e.g. "blocks PROJ-9".
+// To is an issue key or the ID of another item in the plan.
+type Link struct {
+	Type string `json:"type" yaml:"type"`
+	To   string `json:"to" yaml:"to"`
+}
+
+// Load reads a plan file; the format follows the extension (.md and
+// .markdown are Markdown, anything else YAML).
+func Load(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading plan: %w", err)
+	}
+	format := FormatYAML
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		format = FormatMarkdown
+	}
+	p, err := Parse(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	p.path = path
+	return p, nil
+}
+
+// Parse parses a plan from data.
+func Parse(data []byte, format Format) (*Plan, error) {
+	var (
+		p   *Plan
+		err error
+	)
+	switch format {
+	case FormatYAML:
+		p, err = parseYAML(data)
+	case FormatMarkdown:
+		p, err = parseMarkdown(data)
+	default:
+		return nil, fmt.Errorf("unknown plan format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.format = format
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Path returns the file the plan was loaded from.
+func (p *Plan) Path() string {
+	return p.path
+}
+
+// Items returns all items, parents before their children.
+func (p *Plan) Items() []*Item {
+	var all []*Item
+	var walk func(items []*Item)
+	walk = func(items []*Item) {
+		for _, it := range items {
+			all = append(all, it)
+			walk(it.Children)
+		}
+	}
+	walk(p.Issues)
+	return all
+}
+
+// Levels returns the items grouped by depth: top-level items first, then
+// their children, and so on. Every item comes after its parent.
+func (p *Plan) Levels() [][]*Item {
+	var levels [][]*Item
+	for level := p.Issues; len(level) > 0; {
+		levels = append(levels, level)
+		var next []*Item
+		for _, it := range level {
+			next = append(next, it.Children...)
+		}
+		level = next
+	}
+	return levels
+}
+
+// Lookup returns the item with the given local ID or key.
+func (p *Plan) Lookup(ref string) *Item {
+	for _, it := range p.Items() {
+		if (it.ID != "" && it.ID == ref) || (it.Key != "" && strings.EqualFold(it.Key, ref)) {
+			return it
+		}
+	}
+	return nil
+}
+
+// SetKey records the key of a created issue in the item and in the plan source.
+func (p *Plan) SetKey(it *Item, key string) {
+	it.Key = key
+	switch p.format {
+	case FormatYAML:
+		setYAMLKey(it.node, key)
+	case FormatMarkdown:
+		p.lines[it.line] = setMarkdownKey(p.lines[it.line], key)
+	}
+}
+
+// Bytes returns the plan source including the keys set so far.
+func (p *Plan) Bytes() ([]byte, error) {
+	if p.format == FormatMarkdown {
+		return []byte(strings.Join(p.lines, "\n")), nil
+	}
+	var b strings.Builder
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(p.doc); err != nil {
+		return nil, fmt.Errorf("encoding plan: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("encoding plan: %w", err)
+	}
+	return []byte(b.String()), nil
+}
+
+// Save writes the plan back to the file it was loaded from.
+func (p *Plan) Save() error {
+	if p.path == "" {
+		return fmt.Errorf("plan was not loaded from a file")
+	}
+	data, err := p.Bytes()
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return fmt.Errorf("saving plan: %w", err)
+	}
+	if err := os.WriteFile(p.path, data, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("saving plan: %w", err)
+	}
+	return nil
+}
+
+// validate checks summaries and that local IDs are unique.
+func (p *Plan) validate() error {
+	ids := map[string]bool{}
+	for _, it := range p.Items() {
+		if strings.TrimSpace(it.Summary) == "" {
+			return fmt.Errorf("%s item without a summary", it.Type)
+		}
+		if it.ID == "" {
+			continue
+		}
+		if ids[it.ID] {
+			return fmt.Errorf("duplicate id %q", it.ID)
+		}
+		ids[it.ID] = true
+	}
+	return nil
+}
+
+// defaultType is the type of an item that does not name one: top-level items
+// are tasks, children of epics stories, and children of anything else subtasks.
+func defaultType(parent *Item) string {
+	switch {
+	case parent == nil:
+		return "task"
+	case strings.EqualFold(parent.Type, "epic"):
+		return "story"
+	}
+	return "subtask"
+}
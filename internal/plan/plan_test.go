@@ -0,0 +1,243 @@
+package plan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const yamlPlan = `project: PROJ
+issues:
+  # The auth epic.
+  - type: epic
+    summary: Auth system
+    labels: [auth]
+    children:
+      - id: login
+        summary: Login flow
+        assignee: me
+        links:
+          - {type: blocks, to: PROJ-9}
+        dod: Tests pass
+        children:
+          - summary: Write handler
+  - key: PROJ-5
+    summary: Existing task
+`
+
+func TestParseYAML(t *testing.T) {
+	p, err := Parse([]byte(yamlPlan), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if p.Project != "PROJ" || len(p.Issues) != 2 {
+		t.Fatalf("plan = %+v", p)
+	}
+
+	epic := p.Issues[0]
+	if epic.Type != "epic" || epic.Summary != "Auth system" || len(epic.Labels) != 1 || len(epic.Children) != 1 {
+		t.Errorf("epic = %+v", epic)
+	}
+	story := epic.Children[0]
+	if story.Type != "story" || story.ID != "login" || story.Assignee != "me" || story.DoD != "Tests pass" || story.Parent != epic {
+		t.Errorf("story = %+v", story)
+	}
+	if len(story.Links) != 1 || story.Links[0] != (Link{Type: "blocks", To: "PROJ-9"}) {
+		t.Errorf("links = %+v", story.Links)
+	}
+	if sub := story.Children[0]; sub.Type != "subtask" || sub.Summary != "Write handler" {
+		t.Errorf("subtask = %+v", sub)
+	}
+	if task := p.Issues[1]; task.Key != "PROJ-5" || task.Type != "task" {
+		t.Errorf("task = %+v", task)
+	}
+
+	levels := p.Levels()
+	if len(levels) != 3 || len(levels[0]) != 2 || levels[2][0].Summary != "Write handler" {
+		t.Errorf("levels = %v", levels)
+	}
+	if p.Lookup("login") != story || p.Lookup("proj-5") != p.Issues[1] {
+		t.Error("Lookup by id or key failed")
+	}
+}
+
+func TestParseYAML_Errors(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":  "issues:\n  - summary: A\n    lables: [x]\n",
+		"no summary":   "issues:\n  - type: task\n",
+		"duplicate id": "issues:\n  - {id: a, summary: A}\n  - {id: a, summary: B}\n",
+		"bad link":     "issues:\n  - summary: A\n    links: [{type: blocks}]\n",
+		"not a list":   "issues: {summary: A}\n",
+	}
+	for name, src := range tests {
+		if _, err := Parse([]byte(src), FormatYAML); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSetKey_YAML(t *testing.T) {
+	p, err := Parse([]byte(yamlPlan), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	p.SetKey(p.Issues[0], "PROJ-1")
+	p.SetKey(p.Issues[1], "PROJ-6")
+
+	data, err := p.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	out := string(data)
+	for _, want := range []string{"- key: PROJ-1\n    type: epic", "- key: PROJ-6\n    summary: Existing task", "# The auth epic."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	again, err := Parse(data, FormatYAML)
+	if err != nil {
+		t.Fatalf("re-Parse: %v", err)
+	}
+	if again.Issues[0].Key != "PROJ-1" || again.Issues[0].Children[0].Key != "" {
+		t.Errorf("keys after write-back = %q, %q", again.Issues[0].Key, again.Issues[0].Children[0].Key)
+	}
+}
+
+const mdPlan = `Project: PROJ
+
+Notes for the team are ignored.
+
+# Epic: Auth system
+Labels: auth, security
+
+Everything about signing in.
+
+` + "```" + `
+# not a heading
+` + "```" + `
+
+## Story: Login flow
+ID: login
+Assignee: me
+Link: is blocked by PROJ-9
+DoD: Tests pass
+
+Note: keep it simple.
+
+- [ ] Write handler
+- [x] [PROJ-12] Add tests
+
+## [PROJ-3] Story: Signup
+`
+
+func TestParseMarkdown(t *testing.T) {
+	p, err := Parse([]byte(mdPlan), FormatMarkdown)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if p.Project != "PROJ" || len(p.Issues) != 1 {
+		t.Fatalf("plan = %+v", p)
+	}
+
+	epic := p.Issues[0]
+	if epic.Type != "Epic" || epic.Summary != "Auth system" || strings.Join(epic.Labels, ",") != "auth,security" {
+		t.Errorf("epic = %+v", epic)
+	}
+	if !strings.HasPrefix(epic.Description, "Everything about signing in.") || !strings.Contains(epic.Description, "# not a heading") {
+		t.Errorf("epic description = %q", epic.Description)
+	}
+	if len(epic.Children) != 2 {
+		t.Fatalf("epic children = %d", len(epic.Children))
+	}
+
+	story := epic.Children[0]
+	if story.ID != "login" || story.Assignee != "me" || story.DoD != "Tests pass" || story.Description != "Note: keep it simple." {
+		t.Errorf("story = %+v", story)
+	}
+	if len(story.Links) != 1 || story.Links[0] != (Link{Type: "is blocked by", To: "PROJ-9"}) {
+		t.Errorf("links = %+v", story.Links)
+	}
+	if len(story.Children) != 2 || story.Children[0].Type != "subtask" || story.Children[1].Key != "PROJ-12" || story.Children[1].Summary != "Add tests" {
+		t.Errorf("subtasks = %+v, %+v", story.Children[0], story.Children[1])
+	}
+	if signup := epic.Children[1]; signup.Key != "PROJ-3" || signup.Summary != "Signup" {
+		t.Errorf("signup = %+v", signup)
+	}
+}
+
+func TestParseMarkdown_HeadingWithoutType(t *testing.T) {
+	if _, err := Parse([]byte("# Auth system\n"), FormatMarkdown); err == nil {
+		t.Error("expected an error for a heading without a type")
+	}
+}
+
+func TestParseMarkdown_FieldsAndDescriptionHeadings(t *testing.T) {
+	p, err := Parse([]byte(`# Story: Login flow
+Field: Story Points = 5
+Fields: Due date = 2026-11-01; Team = Platform #2; Platforms = [web, ios]; Code = "007"
+
+Intro.
+
+### Acceptance criteria
+
+Works.
+
+## Task: Write handler
+`), FormatMarkdown)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	story := p.Issues[0]
+	want := map[string]any{
+		"Story Points": 5,
+		"Due date":     "2026-11-01",
+		"Team":         "Platform #2",
+		"Platforms":    []any{"web", "ios"},
+		"Code":         "007",
+	}
+	if fmt.Sprint(story.Fields) != fmt.Sprint(want) {
+		t.Errorf("fields = %#v, want %#v", story.Fields, want)
+	}
+	if story.Description != "Intro.\n\n### Acceptance criteria\n\nWorks." {
+		t.Errorf("description = %q", story.Description)
+	}
+	if len(story.Children) != 1 || story.Children[0].Summary != "Write handler" {
+		t.Errorf("children = %+v", story.Children)
+	}
+
+	if _, err := Parse([]byte("# Story: X\nField: Story Points\n"), FormatMarkdown); err == nil {
+		t.Error("expected an error for a field without a value")
+	}
+}
+
+func TestSaveMarkdown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.md")
+	if err := os.WriteFile(path, []byte(mdPlan), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	story := p.Issues[0].Children[0]
+	p.SetKey(p.Issues[0], "PROJ-1")
+	p.SetKey(story, "PROJ-2")
+	p.SetKey(story.Children[0], "PROJ-10")
+	if err := p.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	out := string(data)
+	for _, want := range []string{"# [PROJ-1] Epic: Auth system\n", "## [PROJ-2] Story: Login flow\n", "- [ ] [PROJ-10] Write handler\n", "- [x] [PROJ-12] Add tests\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("saved plan missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "\n") != strings.Count(mdPlan, "\n") {
+		t.Errorf("line count changed:\n%s", out)
+	}
+}
@@ -0,0 +1,121 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// ReconcileResult is the outcome of making Jira follow a plan.
+type ReconcileResult struct {
+	Apply   *Result   `json:"apply"`
+	Updates []Outcome `json:"updates,omitempty"`
+	Failed  int       `json:"failed"`
+}
+
+// Outcome is the result of one update or transition of an existing issue.
+type Outcome struct {
+	Key    string `json:"key"`
+	Action string `json:"action"` // update or transition
+	Detail string `json:"detail"`
+	Status string `json:"status"` // updated or failed
+	Error  string `json:"error,omitempty"`
+}
+
+// Reconcile applies a diff of p: it creates the missing issues, links and DoD
+// comments as Apply does, then updates the fields and transitions the status
+// of existing issues that differ from the plan. Drift is left alone.
+func (a *Applier) Reconcile(p *Plan, d *Diff) (*ReconcileResult, error) {
+	applied, err := a.Apply(p)
+	if err != nil {
+		return nil, err
+	}
+	res := &ReconcileResult{Apply: applied, Failed: applied.Failed}
+	record := func(o Outcome, err error) {
+		o.Status = StatusUpdated
+		if err != nil {
+			o.Status, o.Error = StatusFailed, err.Error()
+			res.Failed++
+		}
+		res.Updates = append(res.Updates, o)
+	}
+
+	for _, ch := range d.Changes {
+		if ch.Action != ActionUpdate {
+			continue
+		}
+		it := ch.item
+		if len(ch.Fields) > 0 {
+			var names []string
+			for _, fc := range ch.Fields {
+				names = append(names, fc.Field)
+			}
+			o := Outcome{Key: it.Key, Action: ActionUpdate, Detail: strings.Join(names, ", ")}
+			fields, err := a.updateFields(d.Project, it, ch.Fields)
+			if err == nil {
+				err = a.Client.UpdateIssue(it.Key, &jira.UpdateIssueRequest{Fields: fields})
+			}
+			record(o, err)
+		}
+		if ch.Transition != nil {
+			o := Outcome{Key: it.Key, Action: "transition", Detail: fmt.Sprintf("%s -> %s", ch.Transition.From, ch.Transition.To)}
+			record(o, a.transition(it.Key, ch.Transition.To))
+		}
+	}
+	return res, nil
+}
+
+// updateFields builds the update of the changed fields from the item, after
+// Apply, so parents created in the same run have keys.
+func (a *Applier) updateFields(project string, it *Item, changes []FieldChange) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	for _, fc := range changes {
+		switch fc.Field {
+		case "summary":
+			fields["summary"] = it.Summary
+		case "description":
+			// Server/DC v2 accepts plain string, Cloud v3 needs ADF.
+			if a.Client.IsCloud() {
+				fields["description"] = jira.NewADFParagraphs(paragraphs(it.Description))
+			} else {
+				fields["description"] = it.Description
+			}
+		case "assignee":
+			ref, err := a.ResolveUser(it.Assignee, project)
+			if err != nil {
+				return nil, err
+			}
+			fields["assignee"] = ref
+		case "priority":
+			fields["priority"] = jira.PriorityRef{Name: it.Priority}
+		case "labels":
+			fields["labels"] = it.Labels
+		case "components":
+			refs := []jira.ComponentRef{}
+			for _, name := range it.Components {
+				refs = append(refs, jira.ComponentRef{Name: name})
+			}
+			fields["components"] = refs
+		case "parent":
+			if it.Parent.Key == "" {
+				return nil, fmt.Errorf("parent %q was not created", it.Parent.Summary)
+			}
+			if a.EpicLinkField != "" && strings.EqualFold(a.typeName(it.Parent.Type), "Epic") {
+				fields[a.EpicLinkField] = it.Parent.Key
+			} else {
+				fields["parent"] = jira.IssueRef{Key: it.Parent.Key}
+			}
+		default:
+			id := fc.Field
+			if !strings.HasPrefix(id, "customfield_") && a.FieldID != nil {
+				var err error
+				if id, err = a.FieldID(fc.Field); err != nil {
+					return nil, err
+				}
+			}
+			fields[id] = it.Fields[fc.Field]
+		}
+	}
+	return fields, nil
+}
@@ -0,0 +1,168 @@
+package plan
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlItemKeys are the keys an item mapping may have.
+var yamlItemKeys = []string{
+	"key", "id", "type", "summary", "description", "status", "assignee", "priority",
+	"labels", "components", "fields", "links", "dod", "children",
+}
+
+type yamlItem struct {
+	Key         string         `yaml:"key"`
+	ID          string         `yaml:"id"`
+	Type        string         `yaml:"type"`
+	Summary     string         `yaml:"summary"`
+	Description string         `yaml:"description"`
+	Status      string         `yaml:"status"`
+	Assignee    string         `yaml:"assignee"`
+	Priority    string         `yaml:"priority"`
+	Labels      []string       `yaml:"labels"`
+	Components  []string       `yaml:"components"`
+	Fields      map[string]any `yaml:"fields"`
+	Links       []Link         `yaml:"links"`
+	DoD         string         `yaml:"dod"`
+	Children    yaml.Node      `yaml:"children"`
+}
+
+// parseYAML reads
+//
+//	project: PROJ
+//	issues:
+//	  - type: epic
+//	    summary: Auth system
+//	    children:
+//	      - summary: Login flow
+//
+// keeping the node tree so keys can be written back with comments intact.
+func parseYAML(data []byte) (*Plan, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing plan: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parsing plan: expected a mapping with project and issues")
+	}
+
+	p := &Plan{doc: &doc}
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		switch key.Value {
+		case "project":
+			p.Project = value.Value
+		case "issues":
+			items, err := yamlItems(value, nil)
+			if err != nil {
+				return nil, err
+			}
+			p.Issues = items
+		default:
+			return nil, fmt.Errorf("line %d: unknown plan key %q", key.Line, key.Value)
+		}
+	}
+	return p, nil
+}
+
+func yamlItems(seq *yaml.Node, parent *Item) ([]*Item, error) {
+	if seq.Kind == 0 {
+		return nil, nil
+	}
+	if seq.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("line %d: expected a list of issues", seq.Line)
+	}
+
+	var items []*Item
+	for _, node := range seq.Content {
+		if node.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("line %d: expected an issue mapping", node.Line)
+		}
+		for i := 0; i < len(node.Content); i += 2 {
+			if k := node.Content[i]; !slices.Contains(yamlItemKeys, k.Value) {
+				return nil, fmt.Errorf("line %d: unknown issue key %q", k.Line, k.Value)
+			}
+		}
+
+		var raw yamlItem
+		if err := node.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		it := &Item{
+			Key:         raw.Key,
+			ID:          raw.ID,
+			Type:        raw.Type,
+			Summary:     raw.Summary,
+			Description: raw.Description,
+			Status:      raw.Status,
+			Assignee:    raw.Assignee,
+			Priority:    raw.Priority,
+			Labels:      raw.Labels,
+			Components:  raw.Components,
+			Fields:      dateStrings(raw.Fields).(map[string]any),
+			Links:       raw.Links,
+			DoD:         raw.DoD,
+			Parent:      parent,
+			node:        node,
+		}
+		it.typed = it.Type != ""
+		if !it.typed {
+			it.Type = defaultType(parent)
+		}
+		for _, l := range it.Links {
+			if l.Type == "" || l.To == "" {
+				return nil, fmt.Errorf("line %d: links need a type and a to", node.Line)
+			}
+		}
+
+		children, err := yamlItems(&raw.Children, it)
+		if err != nil {
+			return nil, err
+		}
+		it.Children = children
+		items = append(items, it)
+	}
+	return items, nil
+}
+
+// dateStrings turns the timestamps YAML decodes from unquoted dates back into
+// the text Jira expects: 2026-11-01 for dates, RFC 3339 otherwise.
+func dateStrings(v any) any {
+	switch t := v.(type) {
+	case time.Time:
+		if t.Equal(t.Truncate(24 * time.Hour)) {
+			return t.Format(time.DateOnly)
+		}
+		return t.Format(time.RFC3339)
+	case map[string]any:
+		for k, item := range t {
+			t[k] = dateStrings(item)
+		}
+	case []any:
+		for i, item := range t {
+			t[i] = dateStrings(item)
+		}
+	}
+	return v
+}
+
+// setYAMLKey sets the key entry of an item mapping, adding it first if missing.
+func setYAMLKey(node *yaml.Node, key string) {
+	if node == nil {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "key" {
+			node.Content[i+1].SetString(key)
+			return
+		}
+	}
+	k := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "key"}
+	v := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+	node.Content = append([]*yaml.Node{k, v}, node.Content...)
+}
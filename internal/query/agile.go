@@ -0,0 +1,169 @@
+// Agile board and sprint helpers for boards(), sprints(), sprint_issues() and
+// backlog(): argument resolution against the configured board and row rendering.
+
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/relux-works/skill-agent-facing-api/agentquery"
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+var sprintStates = []string{"future", "active", "closed"}
+
+// boardArg returns board= (or the positional arg), falling back to defaultBoard.
+func boardArg(op string, args []agentquery.Arg, defaultBoard int) (int, error) {
+	value := ""
+	for _, arg := range args {
+		switch arg.Key {
+		case "board":
+			value = arg.Value
+		case "":
+			if value == "" {
+				value = arg.Value
+			}
+		}
+	}
+	if value == "" {
+		if defaultBoard == 0 {
+			return 0, &agentquery.Error{
+				Code:    agentquery.ErrValidation,
+				Message: op + " requires a board (via argument or config)",
+			}
+		}
+		return defaultBoard, nil
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &agentquery.Error{
+			Code:    agentquery.ErrValidation,
+			Message: fmt.Sprintf("invalid board ID: %s", value),
+		}
+	}
+	return id, nil
+}
+
+// sprintStateArg reads state= as a comma-separated list of sprint states.
+func sprintStateArg(args []agentquery.Arg) ([]string, error) {
+	var states []string
+	for _, arg := range args {
+		if arg.Key != "state" {
+			continue
+		}
+		for _, state := range splitList(strings.ToLower(arg.Value)) {
+			if state == "current" {
+				state = "active"
+			}
+			if !slices.Contains(sprintStates, state) {
+				return nil, &agentquery.Error{
+					Code:    agentquery.ErrValidation,
+					Message: fmt.Sprintf("invalid sprint state %q: use future, active or closed", state),
+				}
+			}
+			states = append(states, state)
+		}
+	}
+	return states, nil
+}
+
+// resolveSprints returns the sprints sprint_issues() reads: sprint=N (or a
+// positional ID), or the active sprints of board= / the configured board.
+func resolveSprints(client *jira.Client, args []agentquery.Arg, defaultBoard int) ([]jira.Sprint, error) {
+	value := ""
+	var boardArgs []agentquery.Arg
+	for _, arg := range args {
+		switch arg.Key {
+		case "sprint":
+			value = arg.Value
+		case "":
+			if value == "" {
+				value = arg.Value
+			}
+		case "board":
+			boardArgs = append(boardArgs, arg)
+		}
+	}
+
+	if value != "" && !isActiveSprintValue(value) {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, &agentquery.Error{
+				Code:    agentquery.ErrValidation,
+				Message: fmt.Sprintf("invalid sprint ID: %s (use a number or active)", value),
+			}
+		}
+		sprint, err := client.GetSprint(id)
+		if err != nil {
+			return nil, err
+		}
+		return []jira.Sprint{*sprint}, nil
+	}
+
+	if len(boardArgs) == 0 && defaultBoard == 0 {
+		return nil, &agentquery.Error{
+			Code:    agentquery.ErrValidation,
+			Message: "sprint_issues requires a sprint ID, or a board (via argument or config) for its active sprint",
+		}
+	}
+	boardID, err := boardArg("sprint_issues", boardArgs, defaultBoard)
+	if err != nil {
+		return nil, err
+	}
+	sprints, err := client.ListSprints(boardID, "active")
+	if err != nil {
+		return nil, err
+	}
+	if len(sprints) == 0 {
+		return nil, &agentquery.Error{
+			Code:    agentquery.ErrNotFound,
+			Message: fmt.Sprintf("board %d has no active sprint", boardID),
+		}
+	}
+	return sprints, nil
+}
+
+func isActiveSprintValue(value string) bool {
+	switch strings.ToLower(value) {
+	case "active", "current":
+		return true
+	}
+	return false
+}
+
+// argValue returns the value of the last key= arg, or "".
+func argValue(args []agentquery.Arg, key string) string {
+	value := ""
+	for _, arg := range args {
+		if arg.Key == key {
+			value = arg.Value
+		}
+	}
+	return value
+}
+
+func boardRow(b jira.Board) map[string]any {
+	row := map[string]any{"id": b.ID, "name": b.Name, "type": b.Type}
+	if b.Location != nil && b.Location.ProjectKey != "" {
+		row["project"] = b.Location.ProjectKey
+	}
+	return row
+}
+
+func sprintRow(s jira.Sprint) map[string]any {
+	row := map[string]any{"id": s.ID, "name": s.Name, "state": s.State}
+	if s.StartDate != nil {
+		row["start"] = s.StartDate.Format(time.RFC3339)
+	}
+	if s.EndDate != nil {
+		row["end"] = s.EndDate.Format(time.RFC3339)
+	}
+	if s.Goal != "" {
+		row["goal"] = s.Goal
+	}
+	return row
+}
@@ -0,0 +1,147 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// fakeAgile serves the Agile board, sprint and backlog endpoints, paging issues
+// two at a time, and records request URLs.
+type fakeAgile struct {
+	urls []*url.URL
+}
+
+func (f *fakeAgile) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.urls = append(f.urls, r.URL)
+	switch r.URL.Path {
+	case "/rest/agile/1.0/board":
+		fmt.Fprint(w, `{"isLast":true,"values":[
+			{"id":1,"name":"Team scrum","type":"scrum","location":{"projectKey":"AG"}},
+			{"id":2,"name":"Ops kanban","type":"kanban","location":{"projectKey":"AG"}}]}`)
+	case "/rest/agile/1.0/board/7/sprint":
+		fmt.Fprint(w, `{"isLast":true,"values":[{"id":70,"name":"Sprint 7","state":"active","startDate":"2026-03-02T09:00:00.000Z","goal":"Ship it"}]}`)
+	case "/rest/agile/1.0/sprint/70/issue", "/rest/agile/1.0/board/7/backlog":
+		start := 0
+		fmt.Sscan(r.URL.Query().Get("startAt"), &start)
+		var issues []jira.Issue
+		for n := start; n < min(start+2, 5); n++ {
+			issues = append(issues, jira.Issue{Key: fmt.Sprintf("AG-%d", 5-n), Fields: jira.IssueFields{Summary: fmt.Sprintf("issue %d", 5-n)}})
+		}
+		json.NewEncoder(w).Encode(jira.AgileIssueResult{StartAt: start, MaxResults: 2, Total: 5, Issues: issues})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newAgileSchema(t *testing.T, defaultBoard int) (*fakeAgile, func(string) any) {
+	t.Helper()
+	fake := &fakeAgile{}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: jira.InstanceCloud})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	schema := NewSchema(client, "AG", defaultBoard)
+	return fake, func(q string) any {
+		t.Helper()
+		result, err := schema.Query(q)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		data, _ := json.Marshal(result)
+		var out any
+		json.Unmarshal(data, &out)
+		return out
+	}
+}
+
+func TestBoards(t *testing.T) {
+	fake, query := newAgileSchema(t, 0)
+
+	rows := query("boards(type=kanban)").([]any)
+	if len(rows) != 1 || rows[0].(map[string]any)["name"] != "Ops kanban" || rows[0].(map[string]any)["project"] != "AG" {
+		t.Errorf("rows = %v", rows)
+	}
+	if got := fake.urls[0].Query().Get("projectKeyOrId"); got != "AG" {
+		t.Errorf("projectKeyOrId = %q, want configured project", got)
+	}
+}
+
+func TestSprints_DefaultBoardAndState(t *testing.T) {
+	fake, query := newAgileSchema(t, 7)
+
+	rows := query(`sprints(state="current,future")`).([]any)
+	row := rows[0].(map[string]any)
+	if row["name"] != "Sprint 7" || row["goal"] != "Ship it" || row["start"] != "2026-03-02T09:00:00Z" {
+		t.Errorf("row = %v", row)
+	}
+	if got := fake.urls[0].Query().Get("state"); got != "active,future" {
+		t.Errorf("state = %q", got)
+	}
+
+	if _, ok := query("sprints(state=done)").(map[string]any)["error"]; !ok {
+		t.Error("expected invalid state error")
+	}
+}
+
+func TestSprintIssues_ActiveSprintPaged(t *testing.T) {
+	fake, query := newAgileSchema(t, 7)
+
+	rows := query(`sprint_issues(jql="type = Bug", sort_key=asc, take=3) { key }`).([]any)
+	if len(rows) != 3 || rows[0].(map[string]any)["key"] != "AG-1" {
+		t.Errorf("rows = %v", rows)
+	}
+	// One sprint lookup plus three pages of two issues.
+	if len(fake.urls) != 4 {
+		t.Fatalf("requests = %d, want 4", len(fake.urls))
+	}
+	q := fake.urls[1].Query()
+	if q.Get("jql") != "type = Bug" || q.Get("fields") != "" {
+		t.Errorf("sprint issue query = %v", q)
+	}
+}
+
+func TestBacklog(t *testing.T) {
+	fake, query := newAgileSchema(t, 0)
+
+	rows := query("backlog(7, skip=1, take=2) { key summary }").([]any)
+	if len(rows) != 2 || rows[0].(map[string]any)["key"] != "AG-4" {
+		t.Errorf("rows = %v", rows)
+	}
+	// skip/take are pushed down: one request for exactly the page.
+	if len(fake.urls) != 1 {
+		t.Fatalf("requests = %d, want 1", len(fake.urls))
+	}
+	q := fake.urls[0].Query()
+	if q.Get("fields") != "summary" || q.Get("startAt") != "1" || q.Get("maxResults") != "2" {
+		t.Errorf("backlog query = %v", q)
+	}
+
+	if _, ok := query("backlog()").(map[string]any)["error"]; !ok {
+		t.Error("expected missing board error")
+	}
+}
+
+func TestBacklog_SortFetchesSortFields(t *testing.T) {
+	fake, query := newAgileSchema(t, 7)
+
+	rows := query("backlog(sort_summary=asc, take=2) { key }").([]any)
+	if len(rows) != 2 || rows[0].(map[string]any)["key"] != "AG-1" || rows[1].(map[string]any)["key"] != "AG-2" {
+		t.Errorf("rows = %v", rows)
+	}
+	// The whole backlog is read, with the sort field, to sort it in memory.
+	if len(fake.urls) != 3 {
+		t.Fatalf("requests = %d, want 3", len(fake.urls))
+	}
+	if got := fake.urls[0].Query().Get("fields"); got != "summary" {
+		t.Errorf("fields = %q, want the sort field", got)
+	}
+}
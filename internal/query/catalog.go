@@ -0,0 +1,326 @@
+// Custom fields: the instance's field catalog is turned into DSL fields that can
+// be selected, filtered and sorted like the built-in ones. Names are the field
+// name in snake_case ("Story Points" → story_points) plus configured aliases.
+
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// DefaultFieldCatalogTTL is how long a cached field catalog is used before refetching.
+const DefaultFieldCatalogTTL = 24 * time.Hour
+
+// CustomField is a catalog field exposed in the DSL.
+type CustomField struct {
+	Name       string `json:"name"`      // DSL name
+	ID         string `json:"id"`        // Jira field ID, e.g. customfield_10016
+	JiraName   string `json:"jira_name"` // display name in Jira
+	Type       string `json:"type"`      // e.g. number, option, array<string>
+	JQL        string `json:"jql"`       // JQL clause, e.g. cf[10016]
+	Sortable   bool   `json:"sortable"`
+	Filterable bool   `json:"filterable"`      // false when a built-in filter has the name
+	Alias      bool   `json:"alias,omitempty"` // named by field_aliases
+	filterKind filterKind
+}
+
+// WithFieldCatalog exposes the catalog's custom fields, and any field named by
+// aliases (alias → field ID or name), as DSL fields.
+func WithFieldCatalog(fields []jira.Field, aliases map[string]string) Option {
+	return func(o *schemaOptions) {
+		o.catalog = fields
+		o.aliases = aliases
+	}
+}
+
+// schemaFields holds the per-schema lookup tables: the built-in ones plus custom fields.
+type schemaFields struct {
+	api     map[string]string     // DSL field → API field (JiraAPIFieldMap + custom)
+	sort    map[string]string     // DSL field → JQL ORDER BY field (JQLSortFieldMap + custom)
+	filters map[string]filterSpec // DSL filter → JQL field (issueFilters + custom)
+	custom  []CustomField
+
+	epicName string // Epic Name field ID (Server/DC epic detection), "" if absent
+}
+
+var nonIdentRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// dslFieldName converts a Jira field name to a DSL identifier: "Story Points" → story_points.
+func dslFieldName(name string) string {
+	return strings.Trim(nonIdentRe.ReplaceAllString(strings.ToLower(name), "_"), "_")
+}
+
+// presetNames are the projection presets, which field names cannot shadow.
+var presetNames = []string{"minimal", "default", "overview", "full", "custom"}
+
+// operationArgNames are operation args that are not filters. A field with one
+// of these names would also become a filter and turn take=5 into a JQL clause.
+var operationArgNames = []string{
+	"project", "key", "skip", "take", "exact", "jql", "by", "metric", "percent",
+	"since", "author", "body", "depth", "board", "state",
+}
+
+// ReservedFieldName reports whether name is a built-in field, preset or
+// operation arg (including sort_*), which a field alias cannot take.
+func ReservedFieldName(name string) bool {
+	_, builtin := JiraAPIFieldMap[name]
+	return builtin || slices.Contains(presetNames, name) || slices.Contains(operationArgNames, name) ||
+		strings.HasPrefix(name, "sort_")
+}
+
+func newSchemaFields(catalog []jira.Field, aliases map[string]string) *schemaFields {
+	sf := &schemaFields{
+		api:     maps.Clone(JiraAPIFieldMap),
+		sort:    maps.Clone(JQLSortFieldMap),
+		filters: maps.Clone(issueFilters),
+
+		epicName: jira.EpicNameFieldID(catalog),
+	}
+	// Fields and filters are separate namespaces: a custom field may share its
+	// name with a built-in filter (the Sprint field with sprint=), it is then
+	// filtered by the built-in one.
+	taken := func(name string) bool {
+		_, isField := sf.api[name]
+		return name == "" || isField || ReservedFieldName(name)
+	}
+
+	// Aliases first, so they win over generated names.
+	for _, alias := range sortedKeys(aliases) {
+		field, ok := findCatalogField(catalog, aliases[alias])
+		if !ok || taken(alias) {
+			continue
+		}
+		sf.add(alias, field, true)
+	}
+	for _, field := range catalog {
+		if !field.Custom {
+			continue
+		}
+		name := dslFieldName(field.Name)
+		if taken(name) {
+			// Duplicate and reserved names (duplicates are common across
+			// team-managed projects) get the numeric ID appended; sort_* names
+			// stay reserved with it, so they fall back to cf_<ID>.
+			id := strings.TrimPrefix(field.ID, "customfield_")
+			name = dslFieldName(field.Name + " " + id)
+			if taken(name) {
+				name = "cf_" + id
+			}
+			if taken(name) {
+				continue
+			}
+		}
+		sf.add(name, field, false)
+	}
+	return sf
+}
+
+func (sf *schemaFields) add(name string, field jira.Field, alias bool) {
+	cf := CustomField{
+		Name:       name,
+		ID:         field.ID,
+		JiraName:   field.Name,
+		Type:       field.Schema.TypeName(),
+		JQL:        jqlFieldRef(field.JQLClause()),
+		Sortable:   field.Orderable,
+		Filterable: field.Searchable,
+		Alias:      alias,
+		filterKind: catalogFilterKind(field.Schema),
+	}
+
+	_, isFilter := sf.filters[name]
+	_, isBound := dateBounds[name]
+	if isFilter || isBound || name == "project" {
+		cf.Filterable = false
+	}
+
+	sf.api[name] = field.ID
+	if cf.Filterable {
+		sf.filters[name] = filterSpec{jqlField: cf.JQL, kind: cf.filterKind, description: fmt.Sprintf("%s (%s)", field.Name, cf.Type)}
+	}
+	if cf.Sortable {
+		sf.sort[name] = cf.JQL
+	}
+	sf.custom = append(sf.custom, cf)
+}
+
+// jqlFieldRef quotes clause names containing spaces; cf[N] references and plain names are used as is.
+func jqlFieldRef(clause string) string {
+	if strings.ContainsAny(clause, " ") {
+		return quoteJQL(clause)
+	}
+	return clause
+}
+
+func catalogFilterKind(schema *jira.FieldSchema) filterKind {
+	if schema == nil {
+		return filterValue
+	}
+	switch {
+	case strings.HasSuffix(schema.Custom, ":gh-sprint"):
+		return filterSprint
+	case schema.Type == "number":
+		return filterNumber
+	case schema.Type == "date" || schema.Type == "datetime":
+		return filterDate
+	case schema.Type == "user" || schema.Items == "user":
+		return filterUser
+	case schema.Type == "string" && (strings.HasSuffix(schema.Custom, ":textarea") || strings.HasSuffix(schema.Custom, ":textfield")):
+		return filterText
+	default:
+		return filterValue
+	}
+}
+
+func findCatalogField(catalog []jira.Field, ref string) (jira.Field, bool) {
+	ref = strings.TrimSpace(ref)
+	for _, field := range catalog {
+		if strings.EqualFold(field.ID, ref) {
+			return field, true
+		}
+	}
+	for _, field := range catalog {
+		if strings.EqualFold(field.Name, ref) || dslFieldName(field.Name) == strings.ToLower(ref) {
+			return field, true
+		}
+	}
+	return jira.Field{}, false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// customFieldValue decodes a catalog field into a compact value: option and
+// user objects become their value or name, arrays are simplified element-wise.
+// Aliases may name system fields (duedate), which are read the same way.
+func customFieldValue(issue jira.Issue, id string) any {
+	raw := issue.Fields.Raw(id)
+	if raw == nil {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil
+	}
+	return simplifyFieldValue(v)
+}
+
+var serverSprintNameRe = regexp.MustCompile(`\bname=([^,\]]*)`)
+
+func simplifyFieldValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for _, key := range []string{"value", "name", "displayName", "title", "key"} {
+			if s, ok := t[key]; ok {
+				if child, ok := t["child"].(map[string]any); ok {
+					return fmt.Sprintf("%v / %v", s, simplifyFieldValue(child))
+				}
+				return s
+			}
+		}
+		return t
+	case []any:
+		out := make([]any, len(t))
+		for i, item := range t {
+			out[i] = simplifyFieldValue(item)
+		}
+		return out
+	case string:
+		// Server/DC renders sprints as "com.atlassian.greenhopper.service.sprint.Sprint@1a2b[id=1,...,name=Sprint 1,...]".
+		if strings.HasPrefix(t, "com.atlassian.greenhopper.service.sprint.Sprint@") {
+			if m := serverSprintNameRe.FindStringSubmatch(t); m != nil {
+				return m[1]
+			}
+		}
+		return t
+	default:
+		return t
+	}
+}
+
+// customFieldNumber returns a numeric custom field for sorting; missing values sort first.
+func customFieldNumber(issue jira.Issue, id string) float64 {
+	switch v := customFieldValue(issue, id).(type) {
+	case float64:
+		return v
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	default:
+		return 0
+	}
+}
+
+// customFieldString returns a custom field as text for sorting.
+func customFieldString(issue jira.Issue, id string) string {
+	v := customFieldValue(issue, id)
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+type fieldCatalogCache struct {
+	FetchedAt time.Time    `json:"fetched_at"`
+	Fields    []jira.Field `json:"fields"`
+}
+
+// LoadFieldCatalog returns the instance's field catalog from cachePath when the
+// cache is younger than ttl, fetching and caching it otherwise. If the fetch fails,
+// a stale cache is still used. An empty cachePath disables caching.
+func LoadFieldCatalog(client *jira.Client, cachePath string, ttl time.Duration) ([]jira.Field, error) {
+	var cached fieldCatalogCache
+	haveCache := false
+	if cachePath != "" {
+		if data, err := os.ReadFile(cachePath); err == nil && json.Unmarshal(data, &cached) == nil {
+			haveCache = true
+			if time.Since(cached.FetchedAt) < ttl {
+				return cached.Fields, nil
+			}
+		}
+	}
+
+	fields, err := client.ListFields()
+	if err != nil {
+		if haveCache {
+			return cached.Fields, nil
+		}
+		return nil, err
+	}
+
+	if cachePath != "" {
+		_ = writeFieldCatalogCache(cachePath, fields)
+	}
+	return fields, nil
+}
+
+func writeFieldCatalogCache(path string, fields []jira.Field) error {
+	data, err := json.Marshal(fieldCatalogCache{FetchedAt: time.Now().UTC(), Fields: fields})
+	if err != nil {
+		return fmt.Errorf("encoding field catalog: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing field catalog cache: %w", err)
+	}
+	return nil
+}
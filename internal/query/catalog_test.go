@@ -0,0 +1,302 @@
+package query
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+var testCatalog = []jira.Field{
+	{ID: "summary", Name: "Summary", Orderable: true, Navigable: true, Searchable: true, ClauseNames: []string{"summary"}},
+	{ID: "customfield_10016", Name: "Story Points", Custom: true, Orderable: true, Searchable: true,
+		ClauseNames: []string{"cf[10016]", "Story Points"}, Schema: &jira.FieldSchema{Type: "number", CustomID: 10016}},
+	{ID: "customfield_10050", Name: "Team", Custom: true, Orderable: true, Searchable: true,
+		ClauseNames: []string{"cf[10050]", "Team"}, Schema: &jira.FieldSchema{Type: "option", CustomID: 10050}},
+	{ID: "customfield_10051", Name: "Team", Custom: true, Searchable: true,
+		ClauseNames: []string{"cf[10051]", "Team"}, Schema: &jira.FieldSchema{Type: "array", Items: "string", CustomID: 10051}},
+}
+
+func TestDSLFieldName(t *testing.T) {
+	for name, want := range map[string]string{
+		"Story Points":        "story_points",
+		"Team (Advanced)":     "team_advanced",
+		"  Épic / Link-Name ": "pic_link_name",
+	} {
+		if got := dslFieldName(name); got != want {
+			t.Errorf("dslFieldName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestNewSchemaFields(t *testing.T) {
+	sf := newSchemaFields(testCatalog, map[string]string{"sp": "customfield_10016", "status": "Team", "ghost": "nope"})
+
+	names := map[string]CustomField{}
+	for _, cf := range sf.custom {
+		names[cf.Name] = cf
+	}
+	if cf, ok := names["sp"]; !ok || !cf.Alias || cf.ID != "customfield_10016" {
+		t.Errorf("alias sp = %+v", cf)
+	}
+	if _, ok := names["status"]; ok {
+		t.Error("alias must not shadow a built-in field")
+	}
+	if _, ok := names["ghost"]; ok {
+		t.Error("alias to an unknown field must be skipped")
+	}
+	if cf := names["story_points"]; cf.Type != "number" || cf.JQL != "cf[10016]" || !cf.Sortable {
+		t.Errorf("story_points = %+v", cf)
+	}
+	if names["team"].ID != "customfield_10050" || names["team_10051"].ID != "customfield_10051" {
+		t.Errorf("duplicate names: team=%s team_10051=%s", names["team"].ID, names["team_10051"].ID)
+	}
+	if _, ok := sf.sort["team_10051"]; ok {
+		t.Error("non-orderable field must not be sortable")
+	}
+	if sf.api["story_points"] != "customfield_10016" || sf.api["summary"] != "summary" {
+		t.Errorf("api map = %v", sf.api)
+	}
+}
+
+func TestNewSchemaFields_OperationArgNamesAreReserved(t *testing.T) {
+	catalog := []jira.Field{
+		{ID: "customfield_10070", Name: "Take", Custom: true, Searchable: true, ClauseNames: []string{"cf[10070]"}, Schema: &jira.FieldSchema{Type: "number"}},
+		{ID: "customfield_10071", Name: "JQL", Custom: true, Searchable: true, ClauseNames: []string{"cf[10071]"}, Schema: &jira.FieldSchema{Type: "string"}},
+		{ID: "customfield_10072", Name: "Sort Order", Custom: true, Searchable: true, ClauseNames: []string{"cf[10072]"}, Schema: &jira.FieldSchema{Type: "number"}},
+	}
+	sf := newSchemaFields(catalog, map[string]string{"skip": "customfield_10070", "author": "customfield_10071"})
+	for _, name := range []string{"take", "jql", "skip", "author", "sort_order", "sort_order_10072"} {
+		if _, ok := sf.api[name]; ok {
+			t.Errorf("%s: operation arg name used as a field", name)
+		}
+		if _, ok := sf.filters[name]; ok {
+			t.Errorf("%s: operation arg name used as a filter", name)
+		}
+	}
+	if sf.api["take_10070"] != "customfield_10070" || sf.api["jql_10071"] != "customfield_10071" || sf.api["cf_10072"] != "customfield_10072" {
+		t.Errorf("api map = %v, want ID-suffixed names", sf.api)
+	}
+}
+
+func TestNewSchemaFields_FieldMayShareFilterName(t *testing.T) {
+	catalog := []jira.Field{{ID: "customfield_10020", Name: "Sprint", Custom: true, Searchable: true,
+		ClauseNames: []string{"cf[10020]", "Sprint"}, Schema: &jira.FieldSchema{Type: "array", Items: "json", Custom: "com.pyxis.greenhopper.jira:gh-sprint"}}}
+	sf := newSchemaFields(catalog, map[string]string{"text": "Sprint"})
+	if sf.api["sprint"] != "customfield_10020" || sf.api["text"] != "customfield_10020" {
+		t.Errorf("api map = %v, want sprint and text for the Sprint field", sf.api)
+	}
+	if sf.filters["sprint"] != issueFilters["sprint"] || sf.filters["text"] != issueFilters["text"] {
+		t.Error("built-in filters must not be replaced by custom fields")
+	}
+	for _, cf := range sf.custom {
+		if cf.Filterable {
+			t.Errorf("%s: filterable under a built-in filter name", cf.Name)
+		}
+	}
+}
+
+func TestReservedFieldName(t *testing.T) {
+	for name, want := range map[string]bool{"status": true, "overview": true, "take": true, "sort_sp": true, "sprint": false, "sp": false} {
+		if got := ReservedFieldName(name); got != want {
+			t.Errorf("ReservedFieldName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestFilterClause_CustomNumber(t *testing.T) {
+	sf := newSchemaFields(testCatalog, nil)
+	for value, want := range map[string]string{
+		">=5":  "cf[10016] >= 5",
+		"3":    "cf[10016] = 3",
+		"1,2":  "cf[10016] in (1, 2)",
+		"none": "cf[10016] is EMPTY",
+	} {
+		got, ok, err := filterClause(sf.filters, "story_points", value, true)
+		if err != nil || !ok || got != want {
+			t.Errorf("story_points=%q → %q, %v, %v; want %q", value, got, ok, err, want)
+		}
+	}
+	if _, _, err := filterClause(sf.filters, "story_points", ">=lots", true); err == nil {
+		t.Error("expected invalid number error")
+	}
+	if got, _, _ := filterClause(sf.filters, "team", "Core,Infra", true); got != `cf[10050] in ("Core", "Infra")` {
+		t.Errorf("team filter = %q", got)
+	}
+}
+
+func TestSimplifyFieldValue(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want any
+	}{
+		{`5`, 5.0},
+		{`{"value":"Core","id":"1"}`, "Core"},
+		{`{"value":"EMEA","child":{"value":"Berlin"}}`, "EMEA / Berlin"},
+		{`[{"name":"Sprint 7"}]`, []any{"Sprint 7"}},
+		{`["com.atlassian.greenhopper.service.sprint.Sprint@1a2b[id=7,rapidViewId=1,state=ACTIVE,name=Sprint 7,startDate=x]"]`, []any{"Sprint 7"}},
+	}
+	for _, tt := range tests {
+		issue := jira.Issue{Fields: jira.IssueFields{CustomFields: map[string]json.RawMessage{"customfield_1": json.RawMessage(tt.raw)}}}
+		got, _ := json.Marshal(customFieldValue(issue, "customfield_1"))
+		want, _ := json.Marshal(tt.want)
+		if string(got) != string(want) {
+			t.Errorf("customFieldValue(%s) = %s, want %s", tt.raw, got, want)
+		}
+	}
+}
+
+func TestList_CustomFields(t *testing.T) {
+	var requests []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		var req map[string]any
+		json.Unmarshal(data, &req)
+		requests = append(requests, req)
+		if r.URL.Path == "/rest/api/3/issue/BIG-1" {
+			w.Write([]byte(`{"key":"BIG-1","fields":{"customfield_10016":8}}`))
+			return
+		}
+		w.Write([]byte(`{"issues":[{"key":"BIG-1","fields":{"summary":"One","customfield_10016":8,"customfield_10050":{"value":"Core"}}}],"isLast":true}`))
+	}))
+	defer srv.Close()
+
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: jira.InstanceCloud})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	schema := NewSchema(client, "BIG", 0, WithFieldCatalog(testCatalog, map[string]string{"sp": "Story Points"}))
+
+	result, err := schema.Query(`list(story_points=">=5", sort_sp=desc, take=10) { key sp team }`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows, ok := result.([]map[string]any)
+	if !ok || len(rows) != 1 {
+		t.Fatalf("result = %#v", result)
+	}
+	if rows[0]["sp"] != 8.0 || rows[0]["team"] != "Core" {
+		t.Errorf("row = %v", rows[0])
+	}
+
+	req := requests[0]
+	if want := `project = "BIG" AND cf[10016] >= 5 ORDER BY cf[10016] DESC`; req["jql"] != want {
+		t.Errorf("jql = %q, want %q", req["jql"], want)
+	}
+	fields, _ := req["fields"].([]any)
+	if len(fields) != 2 || fields[0] != "customfield_10016" || fields[1] != "customfield_10050" {
+		t.Errorf("fields = %v", req["fields"])
+	}
+
+	row, err := schema.Query("get(BIG-1) { key custom }")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := row.(map[string]any); got["story_points"] != 8.0 || got["sp"] != 8.0 {
+		t.Errorf("custom preset = %v", got)
+	}
+
+	out, err := schema.Query("fields()")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := out.(map[string]any)["count"]; n != 4 {
+		t.Errorf("fields() count = %v, want 4", n)
+	}
+}
+
+func TestList_AliasToSystemField(t *testing.T) {
+	var requests []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		var req map[string]any
+		json.Unmarshal(data, &req)
+		requests = append(requests, req)
+		w.Write([]byte(`{"issues":[{"key":"BIG-1","fields":{"duedate":"2026-11-01"}}],"isLast":true}`))
+	}))
+	defer srv.Close()
+
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: jira.InstanceCloud})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	catalog := append(testCatalog, jira.Field{ID: "duedate", Name: "Due date", Orderable: true, Searchable: true,
+		ClauseNames: []string{"duedate", "due"}, Schema: &jira.FieldSchema{Type: "date", System: "duedate"}})
+	schema := NewSchema(client, "BIG", 0, WithFieldCatalog(catalog, map[string]string{"due": "duedate"}))
+
+	result, err := schema.Query(`list(due=">=2026-10-01", sort_due=asc) { key due }`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows, ok := result.([]map[string]any)
+	if !ok || len(rows) != 1 || rows[0]["due"] != "2026-11-01" {
+		t.Fatalf("result = %#v, want due 2026-11-01", result)
+	}
+	if want := `project = "BIG" AND duedate >= "2026-10-01" ORDER BY duedate ASC`; requests[0]["jql"] != want {
+		t.Errorf("jql = %q, want %q", requests[0]["jql"], want)
+	}
+}
+
+func TestList_CustomSortWithoutCatalogIsRejected(t *testing.T) {
+	schema, fake := newTestSchema(t, 10, jira.InstanceCloud)
+
+	result, err := schema.Query("list(sort_story_points=desc) { minimal }")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := result.(map[string]any)["error"]; !ok {
+		t.Errorf("result = %v, want error", result)
+	}
+	if fake.requestCount() != 0 {
+		t.Errorf("requests = %d, want 0", fake.requestCount())
+	}
+}
+
+func TestLoadFieldCatalog_Cache(t *testing.T) {
+	calls := 0
+	fail := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if fail {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		json.NewEncoder(w).Encode(testCatalog)
+	}))
+	defer srv.Close()
+
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: jira.InstanceCloud})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "fields.json")
+
+	for range 2 {
+		fields, err := LoadFieldCatalog(client, path, time.Hour)
+		if err != nil || len(fields) != len(testCatalog) {
+			t.Fatalf("LoadFieldCatalog = %d fields, %v", len(fields), err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 (second load from cache)", calls)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("cache not written: %v", err)
+	}
+
+	// An expired cache is refetched; when that fails, the stale cache is used.
+	fail = true
+	fields, err := LoadFieldCatalog(client, path, 0)
+	if err != nil || len(fields) != len(testCatalog) {
+		t.Errorf("stale fallback = %d fields, %v", len(fields), err)
+	}
+	if calls < 2 {
+		t.Errorf("calls = %d, want a refetch", calls)
+	}
+}
@@ -0,0 +1,109 @@
+// Board columns for summary() and board show: issues are counted per column of
+// the board configuration, one count query per column scoped like the board
+// (its filter, plus the active sprints on scrum boards or the sub-filter on kanban).
+
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/relux-works/skill-jira-management/internal/parallel"
+)
+
+// ColumnCount is the issue count of a board column and its WIP state.
+// With the issueCountExclSubs constraint, subtasks are not counted.
+type ColumnCount struct {
+	Column string `json:"column"`
+	Issues int    `json:"issues"`
+	Min    *int   `json:"min,omitempty"`
+	Max    *int   `json:"max,omitempty"`
+	WIP    string `json:"wip,omitempty"` // "over" or "under" when a limit is broken
+}
+
+// BoardScopeJQL returns the JQL for the issues a board shows. Scrum boards show
+// their active sprints, listed by ID as openSprints() also matches future
+// sprints; a scrum board without an active sprint shows nothing and gets "".
+func BoardScopeJQL(client *jira.Client, cfg *jira.BoardConfiguration) (string, error) {
+	jql := "filter = " + cfg.Filter.ID
+	switch {
+	case strings.EqualFold(cfg.Type, "scrum"):
+		sprints, err := client.ListSprints(cfg.ID, "active")
+		if err != nil {
+			return "", fmt.Errorf("listing active sprints: %w", err)
+		}
+		if len(sprints) == 0 {
+			return "", nil
+		}
+		ids := make([]string, len(sprints))
+		for i, sprint := range sprints {
+			ids[i] = strconv.Itoa(sprint.ID)
+		}
+		jql += " AND sprint in (" + strings.Join(ids, ", ") + ")"
+	case cfg.SubQuery != nil && strings.TrimSpace(cfg.SubQuery.Query) != "":
+		jql += " AND (" + cfg.SubQuery.Query + ")"
+	}
+	return jql, nil
+}
+
+// ColumnJQL returns the JQL for the issues in a board column within scope (from
+// BoardScopeJQL), or "" for an empty scope or a column with no statuses mapped to it.
+func ColumnJQL(scope string, cfg *jira.BoardConfiguration, column jira.BoardColumn) string {
+	if scope == "" || len(column.Statuses) == 0 {
+		return ""
+	}
+	ids := make([]string, len(column.Statuses))
+	for i, s := range column.Statuses {
+		ids[i] = s.ID
+	}
+	jql := fmt.Sprintf("%s AND status in (%s)", scope, strings.Join(ids, ", "))
+	if cfg.ColumnConfig.ConstraintType == "issueCountExclSubs" {
+		jql += " AND issuetype not in subTaskIssueTypes()"
+	}
+	return jql
+}
+
+// BoardColumnCounts counts the issues in every column of a board within scope
+// (from BoardScopeJQL), concurrently.
+func BoardColumnCounts(client *jira.Client, cfg *jira.BoardConfiguration, scope string) (counts []ColumnCount, approximate bool, err error) {
+	columns := cfg.ColumnConfig.Columns
+	results, err := parallel.Map(len(columns), func(i int) (issueCount, error) {
+		jql := ColumnJQL(scope, cfg, columns[i])
+		if jql == "" {
+			return issueCount{}, nil
+		}
+		n, approx, err := client.CountIssues(jql)
+		if err != nil {
+			return issueCount{}, fmt.Errorf("counting column %q: %w", columns[i].Name, err)
+		}
+		return issueCount{n, approx}, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	counts = make([]ColumnCount, len(columns))
+	for i, column := range columns {
+		counts[i] = ColumnCount{Column: column.Name, Min: column.Min, Max: column.Max, Issues: results[i].n}
+		approximate = approximate || results[i].approximate
+	}
+
+	if c := cfg.ColumnConfig.ConstraintType; c != "" && c != "none" {
+		for i := range counts {
+			counts[i].WIP = wipState(counts[i])
+		}
+	}
+	return counts, approximate, nil
+}
+
+func wipState(c ColumnCount) string {
+	switch {
+	case c.Max != nil && c.Issues > *c.Max:
+		return "over"
+	case c.Min != nil && c.Issues < *c.Min:
+		return "under"
+	}
+	return ""
+}
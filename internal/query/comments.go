@@ -0,0 +1,240 @@
+// Comments for comments(): one issue's comments, or those of every issue a JQL
+// matches (fetched concurrently), filtered by date and author and projected
+// to comment fields. Comments have their own schema, so their fields are not
+// issue fields; Runner sends comments() statements to it.
+
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/relux-works/skill-agent-facing-api/agentquery"
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/relux-works/skill-jira-management/internal/parallel"
+)
+
+// jiraTimeLayout is the timestamp format of Jira REST responses.
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// commentsMetadata describes comments() for schema introspection.
+var commentsMetadata = agentquery.OperationMetadata{
+	Description: "Comments of an issue, or of every issue matching a JQL, with date and author filters. Fields: issue, id, author, created, updated, body (default: author created body); rows always carry the issue key",
+	Parameters: []agentquery.ParameterDef{
+		{Name: "key", Type: "string", Optional: true, Description: "Issue key (positional), e.g. PROJ-123; required unless jql is set"},
+		{Name: "jql", Type: "string", Optional: true, Description: "Fetch comments of every matching issue (concurrently)"},
+		{Name: "since", Type: "date", Optional: true, Description: "Only comments created since a date (2026-01-31) or duration back from now (7d, 2w, 12h)"},
+		{Name: "author", Type: "string", Optional: true, Description: "Author account ID, username, email, part of the display name, or me"},
+		{Name: "take", Type: "int", Optional: true, Description: "Keep the N most recent comments per issue"},
+		{Name: "body", Type: "plain|markdown", Optional: true, Default: "plain", Description: "Body rendering"},
+	},
+	Examples: []string{
+		"comments(PROJ-123) { author created body }",
+		"comments(PROJ-123, since=7d, take=5, body=markdown) { author created body }",
+		`comments(jql="sprint in openSprints() AND status = Blocked", since=2d) { author body }`,
+	},
+}
+
+// commentRow is one comment of an issue, as comments() projects it.
+type commentRow struct {
+	issue    string
+	comment  jira.Comment
+	markdown bool
+}
+
+// newCommentSchema builds the schema comments() runs on: comment fields only.
+func newCommentSchema(client *jira.Client) *agentquery.Schema[commentRow] {
+	schema := agentquery.NewSchema[commentRow]()
+	schema.Field("issue", func(r commentRow) any { return r.issue })
+	schema.Field("id", func(r commentRow) any { return r.comment.ID })
+	schema.Field("author", func(r commentRow) any {
+		if r.comment.Author != nil {
+			return r.comment.Author.DisplayName
+		}
+		return nil
+	})
+	schema.Field("created", func(r commentRow) any { return r.comment.Created })
+	schema.Field("updated", func(r commentRow) any { return r.comment.Updated })
+	schema.Field("body", func(r commentRow) any {
+		if r.markdown {
+			return r.comment.Body.Markdown()
+		}
+		return r.comment.Body.PlainText()
+	})
+	schema.DefaultFields("author", "created", "body")
+	schema.OperationWithMetadata("comments", opComments(client), commentsMetadata)
+	return schema
+}
+
+// commentQuery is a parsed comments() request.
+type commentQuery struct {
+	key      string
+	jql      string
+	since    time.Time
+	author   string
+	take     int
+	markdown bool
+}
+
+// parseCommentQuery reads the comments() args. now anchors relative since= durations.
+func parseCommentQuery(args []agentquery.Arg, now time.Time) (*commentQuery, error) {
+	q := &commentQuery{}
+	for _, arg := range args {
+		switch arg.Key {
+		case "", "key":
+			if q.key == "" {
+				q.key = arg.Value
+			}
+		case "jql":
+			q.jql = arg.Value
+		case "since":
+			since, err := parseSince(arg.Value, now)
+			if err != nil {
+				return nil, &agentquery.Error{Code: agentquery.ErrValidation, Message: err.Error()}
+			}
+			q.since = since
+		case "author":
+			q.author = strings.TrimSpace(arg.Value)
+		case "take":
+			n, err := strconv.Atoi(arg.Value)
+			if err != nil || n < 0 {
+				return nil, &agentquery.Error{
+					Code:    agentquery.ErrValidation,
+					Message: fmt.Sprintf("take must be a non-negative integer, got %q", arg.Value),
+				}
+			}
+			q.take = n
+		case "body":
+			switch strings.ToLower(arg.Value) {
+			case "plain", "text":
+				q.markdown = false
+			case "markdown", "md":
+				q.markdown = true
+			default:
+				return nil, &agentquery.Error{
+					Code:    agentquery.ErrValidation,
+					Message: fmt.Sprintf("body must be plain or markdown, got %q", arg.Value),
+				}
+			}
+		}
+	}
+	if (q.key == "") == (q.jql == "") {
+		return nil, &agentquery.Error{
+			Code:    agentquery.ErrValidation,
+			Message: "comments requires an issue key or a jql argument (not both)",
+		}
+	}
+	return q, nil
+}
+
+// parseSince accepts a date (2026-01-31), a duration back from now (7d, 2w, 12h)
+// or an RFC 3339 timestamp.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if relativeDateRe.MatchString(value) {
+		v := strings.TrimPrefix(value, "-")
+		n, _ := strconv.Atoi(v[:len(v)-1])
+		unit := map[byte]time.Duration{'w': 7 * 24 * time.Hour, 'd': 24 * time.Hour, 'h': time.Hour, 'm': time.Minute}[v[len(v)-1]]
+		return now.Add(-time.Duration(n) * unit), nil
+	}
+	for _, layout := range []string{"2006-01-02", "2006/01/02", time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid since %q: use 2026-01-31, a duration like 7d or 2w, or an RFC 3339 time", value)
+}
+
+// keep reports whether a comment passes the since= and author= filters.
+// me is the current user's identity, used for author=me.
+func (q *commentQuery) keep(c jira.Comment, me *jira.User) bool {
+	if !q.since.IsZero() {
+		created, err := time.Parse(jiraTimeLayout, c.Created)
+		if err != nil || created.Before(q.since) {
+			return false
+		}
+	}
+	if q.author == "" {
+		return true
+	}
+	if c.Author == nil {
+		return false
+	}
+	if strings.EqualFold(q.author, "me") && me != nil {
+		return c.Author.AccountID != "" && c.Author.AccountID == me.AccountID ||
+			c.Author.Name != "" && c.Author.Name == me.Name
+	}
+	for _, id := range []string{c.Author.AccountID, c.Author.Name, c.Author.EmailAddress} {
+		if id != "" && strings.EqualFold(id, q.author) {
+			return true
+		}
+	}
+	return strings.Contains(strings.ToLower(c.Author.DisplayName), strings.ToLower(q.author))
+}
+
+// rows filters one issue's comments; take= keeps the most recent.
+func (q *commentQuery) rows(issueKey string, comments []jira.Comment, me *jira.User) []commentRow {
+	var kept []commentRow
+	for _, c := range comments {
+		if q.keep(c, me) {
+			kept = append(kept, commentRow{issue: issueKey, comment: c, markdown: q.markdown})
+		}
+	}
+	if q.take > 0 && len(kept) > q.take {
+		kept = kept[len(kept)-q.take:]
+	}
+	return kept
+}
+
+// opComments: comments(PROJ-1, since=7d, author=me, take=5, body=markdown) { author created body }
+// or comments(jql="...") for every matching issue. Rows always carry the issue key.
+func opComments(client *jira.Client) agentquery.OperationHandler[commentRow] {
+	return func(ctx agentquery.OperationContext[commentRow]) (any, error) {
+		q, err := parseCommentQuery(ctx.Statement.Args, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		var me *jira.User
+		if strings.EqualFold(q.author, "me") {
+			if me, err = client.GetMyself(); err != nil {
+				return nil, err
+			}
+		}
+
+		keys := []string{q.key}
+		if q.jql != "" {
+			issues, err := client.SearchAll(q.jql, []string{"id"})
+			if err != nil {
+				return nil, err
+			}
+			keys = make([]string, len(issues))
+			for i, issue := range issues {
+				keys[i] = issue.Key
+			}
+		}
+
+		comments, err := fetchComments(client, keys)
+		if err != nil {
+			return nil, err
+		}
+		rows := []map[string]any{}
+		for i, key := range keys {
+			for _, r := range q.rows(key, comments[i], me) {
+				row := ctx.Selector.Apply(r)
+				row["issue"] = r.issue
+				rows = append(rows, row)
+			}
+		}
+		return rows, nil
+	}
+}
+
+// fetchComments returns the comments of every key, fetched concurrently, in key order.
+func fetchComments(client *jira.Client, keys []string) ([][]jira.Comment, error) {
+	return parallel.Map(len(keys), func(i int) ([]jira.Comment, error) {
+		return client.ListAllComments(keys[i])
+	})
+}
@@ -0,0 +1,180 @@
+package query
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/relux-works/skill-agent-facing-api/agentquery"
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+const commentsJSON = `{"startAt":0,"maxResults":50,"total":3,"comments":[
+	{"id":"1","author":{"accountId":"a1","displayName":"Ann Lee"},"created":"2026-01-01T10:00:00.000+0000","body":{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"old"}]}]}},
+	{"id":"2","author":{"accountId":"b2","displayName":"Bob Stone"},"created":"2026-03-01T10:00:00.000+0000","body":{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"see "},{"type":"text","text":"PR","marks":[{"type":"link","attrs":{"href":"https://x/pr/1"}}]}]}]}},
+	{"id":"3","author":{"accountId":"a1","displayName":"Ann Lee"},"created":"2026-03-02T10:00:00.000+0000","body":{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"done","marks":[{"type":"strong"}]}]}]}}
+]}`
+
+func newCommentsSchema(t *testing.T) (func(string) any, *atomic.Int32) {
+	t.Helper()
+	var commentCalls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/comment"):
+			commentCalls.Add(1)
+			w.Write([]byte(commentsJSON))
+		case r.URL.Path == "/rest/api/3/myself":
+			w.Write([]byte(`{"accountId":"a1","displayName":"Ann Lee"}`))
+		case r.URL.Path == "/rest/api/3/issue/C-1":
+			w.Write([]byte(`{"key":"C-1","fields":{"summary":"Checkout","reporter":{"displayName":"Rita"}}}`))
+		case r.URL.Path == "/rest/api/3/search/jql":
+			w.Write([]byte(`{"issues":[{"key":"C-1"},{"key":"C-2"}],"isLast":true}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: jira.InstanceCloud})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	runner := NewRunner(client, "C", 0)
+	return func(q string) any {
+		t.Helper()
+		result, err := runner.Query(q)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		data, _ := json.Marshal(result)
+		var out any
+		json.Unmarshal(data, &out)
+		return out
+	}, &commentCalls
+}
+
+func TestComments_SingleIssue(t *testing.T) {
+	query, _ := newCommentsSchema(t)
+
+	rows := query(`comments(C-1, since="2026-02-01", take=1, body=markdown) { author created body }`).([]any)
+	if len(rows) != 1 {
+		t.Fatalf("rows = %v", rows)
+	}
+	row := rows[0].(map[string]any)
+	if row["issue"] != "C-1" || row["author"] != "Ann Lee" || row["body"] != "**done**" {
+		t.Errorf("row = %v", row)
+	}
+	if _, ok := row["id"]; ok {
+		t.Error("id was not selected")
+	}
+
+	rows = query(`comments(C-1, author=bob) { id body }`).([]any)
+	if len(rows) != 1 || rows[0].(map[string]any)["body"] != "see PR" {
+		t.Errorf("author=bob rows = %v", rows)
+	}
+
+	rows = query(`comments(C-1, author=me)`).([]any)
+	if len(rows) != 2 || rows[0].(map[string]any)["body"] != "old" {
+		t.Errorf("author=me rows = %v", rows)
+	}
+}
+
+func TestComments_JQLFetchesEachIssue(t *testing.T) {
+	query, calls := newCommentsSchema(t)
+
+	rows := query(`comments(jql="project = C", take=2) { id }`).([]any)
+	if len(rows) != 4 || calls.Load() != 2 {
+		t.Fatalf("rows = %d, comment calls = %d; want 4 rows from 2 calls", len(rows), calls.Load())
+	}
+	first, last := rows[0].(map[string]any), rows[3].(map[string]any)
+	if first["issue"] != "C-1" || first["id"] != "2" || last["issue"] != "C-2" || last["id"] != "3" {
+		t.Errorf("rows = %v", rows)
+	}
+}
+
+func TestComments_Errors(t *testing.T) {
+	query, calls := newCommentsSchema(t)
+	for _, q := range []string{
+		"comments()",
+		`comments(C-1, jql="x")`,
+		"comments(C-1, since=lastweek)",
+		"comments(C-1, body=html)",
+		`comments(C-1, take="-1")`,
+	} {
+		if _, ok := query(q).(map[string]any)["error"]; !ok {
+			t.Errorf("%s: expected error", q)
+		}
+	}
+	if calls.Load() != 0 {
+		t.Errorf("comment calls = %d, want 0", calls.Load())
+	}
+}
+
+func TestComments_FieldsAreNotIssueFields(t *testing.T) {
+	schema := NewSchema(nil, "C", 0)
+	for _, q := range []string{"get(C-1) { author }", "list() { body }", "get(C-1) { id }"} {
+		if _, err := schema.Query(q); err == nil || !strings.Contains(err.Error(), "unknown field") {
+			t.Errorf("%s: err = %v, want unknown field", q, err)
+		}
+	}
+}
+
+func TestComments_MixedBatch(t *testing.T) {
+	query, _ := newCommentsSchema(t)
+
+	results := query(`get(C-1) { key summary }; comments(C-1, take=1) { author }`).([]any)
+	if len(results) != 2 {
+		t.Fatalf("results = %v", results)
+	}
+	if issue := results[0].(map[string]any); issue["key"] != "C-1" || issue["summary"] != "Checkout" {
+		t.Errorf("get result = %v", issue)
+	}
+	rows := results[1].([]any)
+	if len(rows) != 1 || rows[0].(map[string]any)["author"] != "Ann Lee" {
+		t.Errorf("comments result = %v", rows)
+	}
+}
+
+func TestRunner_MixedBatchCompact(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/comment"):
+			w.Write([]byte(commentsJSON))
+		default:
+			w.Write([]byte(`{"key":"C-1","fields":{"summary":"Checkout"}}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: jira.InstanceCloud})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	out, err := NewRunner(client, "C", 0).QueryJSONWithMode(`get(C-1) { key }; comments(C-1, take=1) { author }`, agentquery.LLMReadable)
+	if err != nil {
+		t.Fatalf("QueryJSONWithMode: %v", err)
+	}
+	parts := strings.Split(strings.TrimSpace(string(out)), "\n\n")
+	if len(parts) != 2 || !strings.Contains(parts[0], "C-1") || !strings.Contains(parts[1], "Ann Lee") {
+		t.Errorf("output = %s", out)
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	for value, want := range map[string]time.Time{
+		"7d":         now.AddDate(0, 0, -7),
+		"-2w":        now.AddDate(0, 0, -14),
+		"12h":        now.Add(-12 * time.Hour),
+		"2026-01-31": time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+	} {
+		got, err := parseSince(value, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseSince(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+}
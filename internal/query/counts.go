@@ -0,0 +1,97 @@
+// Count queries for summary(): one count request per status and issue type of
+// the project, run concurrently, instead of downloading every issue.
+
+package query
+
+import (
+	"fmt"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/relux-works/skill-jira-management/internal/parallel"
+)
+
+// issueCount is the result of one count request.
+type issueCount struct {
+	n           int
+	approximate bool
+}
+
+// OtherStatus is the by_status bucket for issues in statuses outside the
+// project's current workflows (left behind by a workflow migration, say).
+const OtherStatus = "other"
+
+// ProjectCounts is the issue breakdown reported by summary().
+type ProjectCounts struct {
+	Total       int
+	ByStatus    map[string]int
+	ByType      map[string]int
+	Approximate bool
+}
+
+// projectCounts counts a project's issues in total, per issue type and, when
+// byStatus is set, per status. Statuses and types come from the project's
+// workflow; those with no issues are omitted. Issues in no workflow status are
+// counted under OtherStatus.
+func projectCounts(client *jira.Client, projectKey string, byStatus bool) (*ProjectCounts, error) {
+	issueTypes, err := client.ListProjectStatuses(projectKey)
+	if err != nil {
+		return nil, fmt.Errorf("listing project statuses: %w", err)
+	}
+
+	var statuses, types []string
+	seenStatus := map[string]bool{}
+	for _, it := range issueTypes {
+		types = append(types, it.Name)
+		for _, st := range it.Statuses {
+			if !seenStatus[st.Name] {
+				seenStatus[st.Name] = true
+				statuses = append(statuses, st.Name)
+			}
+		}
+	}
+
+	type countQuery struct {
+		into map[string]int
+		name string
+		jql  string
+	}
+	counts := &ProjectCounts{ByStatus: map[string]int{}, ByType: map[string]int{}}
+	total := map[string]int{}
+	queries := []countQuery{{into: total, jql: IssueFilter{Project: projectKey}.JQL()}}
+	if byStatus {
+		for _, name := range statuses {
+			queries = append(queries, countQuery{counts.ByStatus, name, IssueFilter{Project: projectKey, Status: name}.JQL()})
+		}
+	}
+	for _, name := range types {
+		queries = append(queries, countQuery{counts.ByType, name, IssueFilter{Project: projectKey, Type: name}.JQL()})
+	}
+
+	results, err := parallel.Map(len(queries), func(i int) (issueCount, error) {
+		n, approximate, err := client.CountIssues(queries[i].jql)
+		if err != nil {
+			return issueCount{}, fmt.Errorf("counting issues: %w", err)
+		}
+		return issueCount{n, approximate}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, q := range queries {
+		if n := results[i].n; n > 0 || q.name == "" {
+			q.into[q.name] = n
+		}
+		counts.Approximate = counts.Approximate || results[i].approximate
+	}
+	counts.Total = total[""]
+	if byStatus {
+		rest := counts.Total
+		for _, n := range counts.ByStatus {
+			rest -= n
+		}
+		if rest > 0 {
+			counts.ByStatus[OtherStatus] += rest
+		}
+	}
+	return counts, nil
+}
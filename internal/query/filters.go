@@ -0,0 +1,306 @@
+// DSL filters for list() and count(), translated to JQL conditions.
+//
+// The DSL only has key=value args, so richer conditions live in the value:
+//   - lists:      status="To Do,In Progress"   → status in ("To Do", "In Progress")
+//   - negation:   status="!Done"               → status != "Done"
+//   - empty:      resolution=none              → resolution is EMPTY
+//   - users:      assignee=me                  → assignee = currentUser()
+//   - dates:      updated_after=7d             → updated >= -7d
+//                 created=">=2026-01-01"       → created >= "2026-01-01"
+
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/relux-works/skill-agent-facing-api/agentquery"
+)
+
+type filterKind int
+
+const (
+	filterValue  filterKind = iota // exact match, lists, negation, none
+	filterUser                     // like filterValue, plus me → currentUser()
+	filterSprint                   // current/future/closed, numeric IDs or names
+	filterText                     // contains (~)
+	filterDate                     // comparison against absolute or relative dates
+	filterNumber                   // exact match or comparison against a number
+)
+
+type filterSpec struct {
+	jqlField    string
+	kind        filterKind
+	description string
+}
+
+// issueFilters maps DSL filter args to JQL fields. Every filter is pushed down.
+var issueFilters = map[string]filterSpec{
+	"type":        {"issuetype", filterValue, "Issue type (epic, story, task, bug)"},
+	"status":      {"status", filterValue, "Status"},
+	"priority":    {"priority", filterValue, "Priority"},
+	"labels":      {"labels", filterValue, "Label the issue carries"},
+	"resolution":  {"resolution", filterValue, "Resolution; none for unresolved"},
+	"component":   {"component", filterValue, "Component name"},
+	"components":  {"component", filterValue, "Component name (alias: component)"},
+	"fix_version": {"fixVersion", filterValue, "Fix version name"},
+	"parent":      {"parent", filterValue, "Parent issue key"},
+	"epic":        {"parent", filterValue, "Epic key (Epic Link on Server/DC)"},
+	"assignee":    {"assignee", filterUser, "Assignee; me for the current user, none for unassigned"},
+	"reporter":    {"reporter", filterUser, "Reporter; me for the current user"},
+	"sprint":      {"sprint", filterSprint, "Sprint: current, future, closed, an ID or a name"},
+	"text":        {"text", filterText, "Full-text search over summary, description and comments"},
+	"summary":     {"summary", filterText, "Summary contains"},
+	"created":     {"created", filterDate, `Created date with operator, e.g. ">=2026-01-01"`},
+	"updated":     {"updated", filterDate, `Updated date with operator, e.g. ">-7d"`},
+}
+
+// filterKeys lists issueFilters in the order they are documented.
+var filterKeys = []string{
+	"type", "status", "priority", "labels", "resolution", "components", "fix_version",
+	"parent", "epic", "assignee", "reporter", "sprint", "text", "summary", "created", "updated",
+}
+
+// dateBounds maps *_after/*_before args to a date filter and operator.
+var dateBounds = map[string][2]string{
+	"created_after":  {"created", ">="},
+	"created_before": {"created", "<"},
+	"updated_after":  {"updated", ">="},
+	"updated_before": {"updated", "<"},
+}
+
+var (
+	relativeDateRe = regexp.MustCompile(`^-?\d+[wdhm]$`)
+	absoluteDateRe = regexp.MustCompile(`^\d{4}[-/]\d{2}[-/]\d{2}( \d{2}:\d{2})?$`)
+	dateFuncRe     = regexp.MustCompile(`^[a-zA-Z]+\([^()]*\)$`)
+	sprintIDRe     = regexp.MustCompile(`^\d+$`)
+)
+
+// filterParameters documents the filters, including custom fields, for operation metadata.
+func filterParameters(custom []CustomField) []agentquery.ParameterDef {
+	params := []agentquery.ParameterDef{
+		{Name: "project", Type: "string", Optional: true, Description: "Project key (defaults to configured project)"},
+	}
+	for _, key := range filterKeys {
+		params = append(params, agentquery.ParameterDef{
+			Name: key, Type: "string", Optional: true, Description: issueFilters[key].description,
+		})
+	}
+	for _, key := range []string{"created_after", "created_before", "updated_after", "updated_before"} {
+		params = append(params, agentquery.ParameterDef{
+			Name: key, Type: "date", Optional: true, Description: "Date (2026-01-31), relative duration back from now (7d, 2w) or JQL date function",
+		})
+	}
+	for _, cf := range custom {
+		if cf.Filterable {
+			params = append(params, agentquery.ParameterDef{
+				Name: cf.Name, Type: cf.Type, Optional: true, Description: fmt.Sprintf("Custom field %s (%s)", cf.JiraName, cf.ID),
+			})
+		}
+	}
+	return params
+}
+
+// parseIssueFilter reads the filter args shared by list() and count(), looking
+// filters up in filters (issueFilters plus custom fields). A positional arg is
+// treated as the project key when none is set. Args that are not filters
+// (sort_*, skip, take, ...) are ignored.
+func parseIssueFilter(args []agentquery.Arg, defaultProject string, cloud bool, filters map[string]filterSpec) (IssueFilter, error) {
+	var f IssueFilter
+	for _, arg := range args {
+		switch arg.Key {
+		case "project":
+			f.Project = arg.Value
+			continue
+		case "":
+			if f.Project == "" {
+				f.Project = arg.Value
+			}
+			continue
+		}
+
+		clause, ok, err := filterClause(filters, arg.Key, arg.Value, cloud)
+		if err != nil {
+			return IssueFilter{}, &agentquery.Error{
+				Code:    agentquery.ErrValidation,
+				Message: fmt.Sprintf("filter %s: %v", arg.Key, err),
+				Details: map[string]any{"arg": arg.Key, "value": arg.Value},
+			}
+		}
+		if ok {
+			f.Clauses = append(f.Clauses, clause)
+		}
+	}
+	if f.Project == "" {
+		f.Project = defaultProject
+	}
+	return f, nil
+}
+
+// filterClause renders one DSL filter arg as a JQL condition. ok is false for
+// args that are not filters.
+func filterClause(filters map[string]filterSpec, key, value string, cloud bool) (clause string, ok bool, err error) {
+	if bound, isBound := dateBounds[key]; isBound {
+		clause, err := dateClause(bound[0], bound[1], value)
+		return clause, true, err
+	}
+
+	spec, isFilter := filters[key]
+	if !isFilter {
+		return "", false, nil
+	}
+	field := spec.jqlField
+	if key == "epic" && !cloud {
+		field = `"Epic Link"`
+	}
+
+	switch spec.kind {
+	case filterDate:
+		op, rest := splitOperator(value)
+		if op == "" {
+			return "", true, fmt.Errorf(`needs a comparison such as ">=2026-01-01" (or use %s_after/%s_before)`, key, key)
+		}
+		clause, err := dateClause(field, op, rest)
+		return clause, true, err
+	case filterText:
+		negate, rest := splitNegation(value)
+		if rest == "" {
+			return "", true, fmt.Errorf("empty text")
+		}
+		op := "~"
+		if negate {
+			op = "!~"
+		}
+		return fmt.Sprintf("%s %s %s", field, op, quoteJQL(rest)), true, nil
+	}
+
+	if spec.kind == filterNumber {
+		if op, rest := splitOperator(value); op != "" {
+			if _, err := strconv.ParseFloat(rest, 64); err != nil {
+				return "", true, fmt.Errorf("invalid number %q", rest)
+			}
+			return fmt.Sprintf("%s %s %s", field, op, rest), true, nil
+		}
+	}
+
+	negate, rest := splitNegation(value)
+	values := splitList(rest)
+	if len(values) == 0 {
+		return "", true, fmt.Errorf("empty value")
+	}
+
+	if len(values) == 1 && isNoneValue(values[0]) {
+		if negate {
+			return field + " is not EMPTY", true, nil
+		}
+		return field + " is EMPTY", true, nil
+	}
+
+	operands := make([]string, len(values))
+	for i, v := range values {
+		if spec.kind == filterNumber {
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return "", true, fmt.Errorf("invalid number %q", v)
+			}
+		}
+		operands[i] = filterOperand(spec.kind, v)
+	}
+
+	if len(operands) == 1 {
+		op := "="
+		if negate {
+			op = "!="
+		}
+		if spec.kind == filterSprint && strings.HasSuffix(operands[0], "()") {
+			op = "in"
+			if negate {
+				op = "not in"
+			}
+		}
+		return fmt.Sprintf("%s %s %s", field, op, operands[0]), true, nil
+	}
+
+	op := "in"
+	if negate {
+		op = "not in"
+	}
+	return fmt.Sprintf("%s %s (%s)", field, op, strings.Join(operands, ", ")), true, nil
+}
+
+// filterOperand renders one value, expanding the keywords the filter kind understands.
+func filterOperand(kind filterKind, value string) string {
+	switch kind {
+	case filterUser:
+		if strings.EqualFold(value, "me") {
+			return "currentUser()"
+		}
+	case filterNumber:
+		return value
+	case filterSprint:
+		switch strings.ToLower(value) {
+		case "current", "open", "active":
+			return "openSprints()"
+		case "future":
+			return "futureSprints()"
+		case "closed":
+			return "closedSprints()"
+		}
+		if sprintIDRe.MatchString(value) {
+			return value
+		}
+	}
+	return quoteJQL(value)
+}
+
+// dateClause renders a date comparison. Durations count back from now, so 7d and -7d are the same.
+func dateClause(field, op, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch {
+	case relativeDateRe.MatchString(value):
+		if !strings.HasPrefix(value, "-") {
+			value = "-" + value
+		}
+		return fmt.Sprintf("%s %s %s", field, op, value), nil
+	case absoluteDateRe.MatchString(value):
+		return fmt.Sprintf("%s %s %s", field, op, quoteJQL(value)), nil
+	case dateFuncRe.MatchString(value):
+		return fmt.Sprintf("%s %s %s", field, op, value), nil
+	default:
+		return "", fmt.Errorf("invalid date %q: use 2026-01-31, a duration like 7d or 2w, or a JQL function like startOfWeek()", value)
+	}
+}
+
+// splitOperator separates a leading comparison operator from a date value.
+func splitOperator(value string) (op, rest string) {
+	value = strings.TrimSpace(value)
+	for _, candidate := range []string{">=", "<=", "!=", ">", "<", "="} {
+		if strings.HasPrefix(value, candidate) {
+			return candidate, strings.TrimSpace(value[len(candidate):])
+		}
+	}
+	return "", value
+}
+
+func splitNegation(value string) (bool, string) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "!") {
+		return true, strings.TrimSpace(value[1:])
+	}
+	return false, value
+}
+
+func splitList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func isNoneValue(value string) bool {
+	return strings.EqualFold(value, "none") || strings.EqualFold(value, "empty")
+}
@@ -0,0 +1,101 @@
+package query
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/relux-works/skill-agent-facing-api/agentquery"
+)
+
+func TestFilterClause(t *testing.T) {
+	tests := []struct {
+		key, value string
+		cloud      bool
+		want       string
+	}{
+		{"status", "Done", true, `status = "Done"`},
+		{"status", "!Done", true, `status != "Done"`},
+		{"status", "To Do, In Progress", true, `status in ("To Do", "In Progress")`},
+		{"status", "!Done,Closed", true, `status not in ("Done", "Closed")`},
+		{"type", "bug", true, `issuetype = "bug"`},
+		{"labels", "backend", true, `labels = "backend"`},
+		{"labels", "none", true, `labels is EMPTY`},
+		{"resolution", "none", true, `resolution is EMPTY`},
+		{"resolution", "!none", true, `resolution is not EMPTY`},
+		{"component", "API,Web", true, `component in ("API", "Web")`},
+		{"components", "none", true, `component is EMPTY`},
+		{"fix_version", "1.2", true, `fixVersion = "1.2"`},
+		{"parent", "PROJ-1", true, `parent = "PROJ-1"`},
+		{"epic", "PROJ-1", true, `parent = "PROJ-1"`},
+		{"epic", "PROJ-1", false, `"Epic Link" = "PROJ-1"`},
+		{"assignee", "me", true, `assignee = currentUser()`},
+		{"assignee", "!me", true, `assignee != currentUser()`},
+		{"assignee", "none", true, `assignee is EMPTY`},
+		{"reporter", "me,alice@example.com", true, `reporter in (currentUser(), "alice@example.com")`},
+		{"sprint", "current", true, `sprint in openSprints()`},
+		{"sprint", "!closed", true, `sprint not in closedSprints()`},
+		{"sprint", "42", true, `sprint = 42`},
+		{"sprint", "Sprint 7", true, `sprint = "Sprint 7"`},
+		{"text", "login, timeout", true, `text ~ "login, timeout"`},
+		{"summary", "!flaky", true, `summary !~ "flaky"`},
+		{"updated", ">-7d", true, `updated > -7d`},
+		{"updated", ">=7d", true, `updated >= -7d`},
+		{"created", ">=2026-01-01", true, `created >= "2026-01-01"`},
+		{"created", "<startOfMonth()", true, `created < startOfMonth()`},
+		{"updated_after", "2w", true, `updated >= -2w`},
+		{"created_before", "2026-02-01 09:30", true, `created < "2026-02-01 09:30"`},
+	}
+
+	for _, tt := range tests {
+		got, ok, err := filterClause(issueFilters, tt.key, tt.value, tt.cloud)
+		if err != nil || !ok {
+			t.Errorf("filterClause(%s=%q) error = %v, ok = %v", tt.key, tt.value, err, ok)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("filterClause(%s=%q) = %s, want %s", tt.key, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFilterClause_Errors(t *testing.T) {
+	for _, tt := range []struct{ key, value, wantErr string }{
+		{"updated", "-7d", "needs a comparison"},
+		{"created_after", "last tuesday", "invalid date"},
+		{"status", " , ", "empty value"},
+		{"text", "!", "empty text"},
+	} {
+		if _, _, err := filterClause(issueFilters, tt.key, tt.value, true); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("filterClause(%s=%q) error = %v, want %q", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+
+	if _, ok, err := filterClause(issueFilters, "sort_key", "asc", true); ok || err != nil {
+		t.Errorf("non-filter arg: ok = %v, err = %v", ok, err)
+	}
+}
+
+func TestParseIssueFilter(t *testing.T) {
+	args := []agentquery.Arg{
+		{Value: "PROJ"},
+		{Key: "assignee", Value: "me"},
+		{Key: "status", Value: "!Done"},
+		{Key: "updated_after", Value: "7d"},
+		{Key: "take", Value: "5"},
+	}
+	f, err := parseIssueFilter(args, "DEFAULT", true, issueFilters)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `project = "PROJ" AND assignee = currentUser() AND status != "Done" AND updated >= -7d`
+	if got := f.JQL(); got != want {
+		t.Errorf("JQL = %s, want %s", got, want)
+	}
+
+	_, err = parseIssueFilter([]agentquery.Arg{{Key: "created", Value: "yesterday"}}, "P", true, issueFilters)
+	var qerr *agentquery.Error
+	if err == nil || !errors.As(err, &qerr) || qerr.Code != agentquery.ErrValidation {
+		t.Errorf("err = %v, want validation error", err)
+	}
+}
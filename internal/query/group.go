@@ -0,0 +1,295 @@
+// Group-by aggregation for group(): the issues matching a filter are scanned once
+// and bucketed by one or more fields, with a count or a numeric sum per bucket.
+
+package query
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/relux-works/skill-agent-facing-api/agentquery"
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// noneGroup labels issues that have no value for a group field.
+const noneGroup = "(none)"
+
+// groupFields extracts the values an issue is grouped under, keyed by DSL field.
+// Multi-valued fields (labels, array custom fields) put an issue in several groups.
+var groupFields = map[string]func(jira.Issue) []string{
+	"status": func(i jira.Issue) []string {
+		if i.Fields.Status != nil {
+			return []string{i.Fields.Status.Name}
+		}
+		return nil
+	},
+	"status_category": func(i jira.Issue) []string {
+		if i.Fields.Status != nil && i.Fields.Status.StatusCategory != nil {
+			return []string{i.Fields.Status.StatusCategory.Name}
+		}
+		return nil
+	},
+	"assignee": func(i jira.Issue) []string {
+		if i.Fields.Assignee != nil {
+			return []string{i.Fields.Assignee.DisplayName}
+		}
+		return nil
+	},
+	"reporter": func(i jira.Issue) []string {
+		if i.Fields.Reporter != nil {
+			return []string{i.Fields.Reporter.DisplayName}
+		}
+		return nil
+	},
+	"type": func(i jira.Issue) []string {
+		if i.Fields.IssueType.Name != "" {
+			return []string{i.Fields.IssueType.Name}
+		}
+		return nil
+	},
+	"priority": func(i jira.Issue) []string {
+		if i.Fields.Priority != nil {
+			return []string{i.Fields.Priority.Name}
+		}
+		return nil
+	},
+	"parent": func(i jira.Issue) []string {
+		if i.Fields.Parent != nil {
+			return []string{i.Fields.Parent.Key}
+		}
+		return nil
+	},
+	"labels":     func(i jira.Issue) []string { return i.Fields.Labels },
+	"components": componentNames,
+	"project":    func(i jira.Issue) []string { return []string{i.Fields.Project.Key} },
+}
+
+// groupAPIFields maps group fields whose API field differs from the DSL field map.
+var groupAPIFields = map[string]string{"status_category": "status"}
+
+var sumMetricRe = regexp.MustCompile(`^sum\(\s*([a-z0-9_]+)\s*\)$`)
+
+// Group is one bucket of a group() result. Sum is set for sum() metrics and
+// Percent when percent=true; nested Groups hold the next by= level.
+type Group struct {
+	Field   string   `json:"field"`
+	Value   string   `json:"value"`
+	Count   int      `json:"count"`
+	Sum     *float64 `json:"sum,omitempty"`
+	Percent *float64 `json:"percent,omitempty"`
+	Groups  []*Group `json:"groups,omitempty"`
+}
+
+// groupSpec is a parsed group() request.
+type groupSpec struct {
+	by      []string
+	values  []func(jira.Issue) []string
+	metric  string // "count" or "sum(field)"
+	sumID   string // custom field ID summed; empty for count
+	percent bool
+}
+
+// groupNode accumulates one bucket while issues are scanned.
+type groupNode struct {
+	count    int
+	sum      float64
+	children map[string]*groupNode
+}
+
+func (n *groupNode) add(spec *groupSpec, issue jira.Issue, level int, amount float64) {
+	n.count++
+	n.sum += amount
+	if level == len(spec.values) {
+		return
+	}
+	values := spec.values[level](issue)
+	if len(values) == 0 {
+		values = []string{noneGroup}
+	}
+	if n.children == nil {
+		n.children = map[string]*groupNode{}
+	}
+	for _, v := range slices.Compact(slices.Sorted(slices.Values(values))) {
+		child, ok := n.children[v]
+		if !ok {
+			child = &groupNode{}
+			n.children[v] = child
+		}
+		child.add(spec, issue, level+1, amount)
+	}
+}
+
+// groups renders a node's children, largest metric first.
+func (n *groupNode) groups(spec *groupSpec, level int, total float64) []*Group {
+	out := make([]*Group, 0, len(n.children))
+	for value, child := range n.children {
+		g := &Group{Field: spec.by[level], Value: value, Count: child.count}
+		metric := float64(child.count)
+		if spec.sumID != "" {
+			sum := child.sum
+			g.Sum = &sum
+			metric = sum
+		}
+		if spec.percent {
+			pct := 0.0
+			if total != 0 {
+				pct = math.Round(metric/total*1000) / 10
+			}
+			g.Percent = &pct
+		}
+		if level+1 < len(spec.by) {
+			g.Groups = child.groups(spec, level+1, total)
+		}
+		out = append(out, g)
+	}
+	slices.SortFunc(out, func(a, b *Group) int {
+		if c := cmp.Compare(groupMetricValue(b), groupMetricValue(a)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Value, b.Value)
+	})
+	return out
+}
+
+func groupMetricValue(g *Group) float64 {
+	if g.Sum != nil {
+		return *g.Sum
+	}
+	return float64(g.Count)
+}
+
+// parseGroupSpec reads by=, metric= and percent= and resolves them against the
+// built-in group fields and the schema's custom fields.
+func parseGroupSpec(args []agentquery.Arg, sf *schemaFields) (*groupSpec, error) {
+	spec := &groupSpec{metric: "count"}
+	custom := make(map[string]CustomField, len(sf.custom))
+	for _, cf := range sf.custom {
+		custom[cf.Name] = cf
+	}
+
+	for _, arg := range args {
+		switch arg.Key {
+		case "by":
+			for _, name := range splitList(arg.Value) {
+				name = strings.ToLower(name)
+				if fn, ok := groupFields[name]; ok {
+					spec.by = append(spec.by, name)
+					spec.values = append(spec.values, fn)
+					continue
+				}
+				cf, ok := custom[name]
+				if !ok {
+					return nil, &agentquery.Error{
+						Code:    agentquery.ErrValidation,
+						Message: fmt.Sprintf("cannot group by %q", name),
+						Details: map[string]any{"groupable": groupableFields(sf)},
+					}
+				}
+				id := cf.ID
+				spec.by = append(spec.by, name)
+				spec.values = append(spec.values, func(i jira.Issue) []string { return customFieldStrings(i, id) })
+			}
+		case "metric":
+			metric := strings.ToLower(strings.TrimSpace(arg.Value))
+			if metric == "count" {
+				spec.metric, spec.sumID = metric, ""
+				continue
+			}
+			m := sumMetricRe.FindStringSubmatch(metric)
+			if m == nil {
+				return nil, &agentquery.Error{
+					Code:    agentquery.ErrValidation,
+					Message: fmt.Sprintf("metric must be count or sum(<number field>), got %q", arg.Value),
+				}
+			}
+			cf, ok := custom[m[1]]
+			if !ok || cf.Type != "number" {
+				return nil, &agentquery.Error{
+					Code:    agentquery.ErrValidation,
+					Message: fmt.Sprintf("cannot sum %q: not a number field (see fields())", m[1]),
+				}
+			}
+			spec.metric, spec.sumID = metric, cf.ID
+		case "percent":
+			v, err := strconv.ParseBool(arg.Value)
+			if err != nil {
+				return nil, &agentquery.Error{
+					Code:    agentquery.ErrValidation,
+					Message: fmt.Sprintf("percent must be true or false, got %q", arg.Value),
+				}
+			}
+			spec.percent = v
+		}
+	}
+
+	if len(spec.by) == 0 {
+		return nil, &agentquery.Error{
+			Code:    agentquery.ErrValidation,
+			Message: "group requires a by argument, e.g. by=assignee or by=\"status,type\"",
+			Details: map[string]any{"groupable": groupableFields(sf)},
+		}
+	}
+	return spec, nil
+}
+
+// apiFields returns the Jira API fields the spec reads.
+func (spec *groupSpec) apiFields(sf *schemaFields) []string {
+	var fields []string
+	for _, name := range spec.by {
+		field, ok := groupAPIFields[name]
+		if !ok {
+			field = sf.api[name]
+		}
+		if field != "" && !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	if spec.sumID != "" && !slices.Contains(fields, spec.sumID) {
+		fields = append(fields, spec.sumID)
+	}
+	return fields
+}
+
+func groupableFields(sf *schemaFields) []string {
+	names := make([]string, 0, len(groupFields)+len(sf.custom))
+	for name := range groupFields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, cf := range sf.custom {
+		names = append(names, cf.Name)
+	}
+	return names
+}
+
+// componentNames returns the names of an issue's components.
+func componentNames(i jira.Issue) []string {
+	var names []string
+	for _, c := range i.Fields.Components {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// customFieldStrings returns a custom field's values as group labels.
+func customFieldStrings(issue jira.Issue, id string) []string {
+	switch v := customFieldValue(issue, id).(type) {
+	case nil:
+		return nil
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if item != nil {
+				out = append(out, fmt.Sprint(item))
+			}
+		}
+		return out
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
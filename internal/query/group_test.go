@@ -0,0 +1,159 @@
+package query
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+const groupIssues = `{"issues":[
+	{"key":"G-1","fields":{"status":{"name":"Done"},"issuetype":{"name":"Story"},"assignee":{"displayName":"Ann"},"labels":["api","web"],"components":[{"id":"1","name":"Backend"}],"customfield_10016":5}},
+	{"key":"G-2","fields":{"status":{"name":"Done"},"issuetype":{"name":"Bug"},"assignee":{"displayName":"Ann"},"customfield_10016":1}},
+	{"key":"G-3","fields":{"status":{"name":"To Do"},"issuetype":{"name":"Story"},"labels":["api"],"components":[{"id":"1","name":"Backend"},{"id":"2","name":"Web"}],"customfield_10016":3}},
+	{"key":"G-4","fields":{"status":{"name":"Done"},"issuetype":{"name":"Story"},"assignee":{"displayName":"Bob"}}}
+],"isLast":true}`
+
+func newGroupSchema(t *testing.T) (func(q string) map[string]any, *[]map[string]any) {
+	t.Helper()
+	var requests []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		var req map[string]any
+		json.Unmarshal(data, &req)
+		requests = append(requests, req)
+		w.Write([]byte(groupIssues))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: jira.InstanceCloud})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	schema := NewSchema(client, "G", 0, WithFieldCatalog(testCatalog, nil))
+
+	query := func(q string) map[string]any {
+		t.Helper()
+		result, err := schema.Query(q)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// Round-trip through JSON to compare plain values.
+		data, _ := json.Marshal(result)
+		var out map[string]any
+		json.Unmarshal(data, &out)
+		return out
+	}
+	return query, &requests
+}
+
+func TestGroup_CountByAssignee(t *testing.T) {
+	query, requests := newGroupSchema(t)
+
+	out := query(`group(by=assignee, percent=true, status="!Closed")`)
+	if out["issues"] != 4.0 || out["metric"] != "count" {
+		t.Fatalf("result = %v", out)
+	}
+	groups := out["groups"].([]any)
+	want := []struct {
+		value   string
+		count   float64
+		percent float64
+	}{{"Ann", 2, 50}, {"(none)", 1, 25}, {"Bob", 1, 25}}
+	if len(groups) != len(want) {
+		t.Fatalf("groups = %v", groups)
+	}
+	for i, w := range want {
+		g := groups[i].(map[string]any)
+		if g["value"] != w.value || g["count"] != w.count || g["percent"] != w.percent {
+			t.Errorf("group %d = %v, want %+v", i, g, w)
+		}
+	}
+
+	req := (*requests)[0]
+	if req["jql"] != `project = "G" AND status != "Closed"` {
+		t.Errorf("jql = %q", req["jql"])
+	}
+	if fields := req["fields"].([]any); len(fields) != 1 || fields[0] != "assignee" {
+		t.Errorf("fields = %v, want only assignee", fields)
+	}
+}
+
+func TestGroup_NestedSum(t *testing.T) {
+	query, requests := newGroupSchema(t)
+
+	out := query(`group(by="status,type", metric="sum(story_points)", jql="sprint in openSprints()")`)
+	if out["sum"] != 9.0 {
+		t.Errorf("sum = %v, want 9", out["sum"])
+	}
+	done := out["groups"].([]any)[0].(map[string]any)
+	if done["value"] != "Done" || done["sum"] != 6.0 || done["count"] != 3.0 {
+		t.Fatalf("first group = %v", done)
+	}
+	nested := done["groups"].([]any)
+	story := nested[0].(map[string]any)
+	if story["field"] != "type" || story["value"] != "Story" || story["sum"] != 5.0 || story["count"] != 2.0 {
+		t.Errorf("nested = %v", nested)
+	}
+
+	req := (*requests)[0]
+	if req["jql"] != "sprint in openSprints()" {
+		t.Errorf("jql = %q", req["jql"])
+	}
+	if fields := req["fields"].([]any); len(fields) != 3 {
+		t.Errorf("fields = %v, want status, issuetype, customfield_10016", fields)
+	}
+}
+
+func TestGroup_MultiValued(t *testing.T) {
+	query, _ := newGroupSchema(t)
+
+	out := query(`group(by=labels)`)
+	got := map[string]float64{}
+	for _, g := range out["groups"].([]any) {
+		g := g.(map[string]any)
+		got[g["value"].(string)] = g["count"].(float64)
+	}
+	if got["api"] != 2 || got["web"] != 1 || got["(none)"] != 2 {
+		t.Errorf("labels = %v", got)
+	}
+}
+
+func TestGroup_ByComponents(t *testing.T) {
+	query, requests := newGroupSchema(t)
+
+	out := query(`group(by=components)`)
+	got := map[string]float64{}
+	for _, g := range out["groups"].([]any) {
+		g := g.(map[string]any)
+		got[g["value"].(string)] = g["count"].(float64)
+	}
+	if got["Backend"] != 2 || got["Web"] != 1 || got["(none)"] != 2 {
+		t.Errorf("components = %v", got)
+	}
+	if fields := (*requests)[0]["fields"].([]any); len(fields) != 1 || fields[0] != "components" {
+		t.Errorf("fields = %v, want components", fields)
+	}
+}
+
+func TestGroup_Errors(t *testing.T) {
+	query, requests := newGroupSchema(t)
+
+	for _, q := range []string{
+		"group()",
+		"group(by=summary)",
+		`group(by=status, metric="sum(team)")`,
+		`group(by=status, metric=avg)`,
+		"group(by=status, percent=maybe)",
+	} {
+		if _, ok := query(q)["error"]; !ok {
+			t.Errorf("%s: expected error", q)
+		}
+	}
+	if len(*requests) != 0 {
+		t.Errorf("requests = %d, want 0", len(*requests))
+	}
+}
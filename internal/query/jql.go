@@ -0,0 +1,93 @@
+// JQL pushdown: translates DSL filters, sort specs and skip/take into a JQL
+// query and server-side pagination so list() does not page through whole projects.
+
+package query
+
+import (
+	"strings"
+
+	"github.com/relux-works/skill-agent-facing-api/agentquery"
+)
+
+// JQLSortFieldMap maps sortable DSL fields to JQL ORDER BY fields.
+// Sortable fields missing from this map are sorted in memory after a full fetch.
+var JQLSortFieldMap = map[string]string{
+	"key":      "key",
+	"summary":  "summary",
+	"status":   "status",
+	"assignee": "assignee",
+	"type":     "issuetype",
+	"priority": "priority",
+	"created":  "created",
+	"updated":  "updated",
+}
+
+// IssueFilter holds the issue filters shared by list(), count() and summary().
+type IssueFilter struct {
+	Project string
+	Type    string // exact issue type name
+	Status  string // exact status name
+
+	// Clauses are extra JQL conditions built from DSL filter args (see filters.go).
+	Clauses []string
+}
+
+// JQL renders the filter as a JQL condition (without ORDER BY).
+func (f IssueFilter) JQL() string {
+	var clauses []string
+	if f.Project != "" {
+		clauses = append(clauses, "project = "+quoteJQL(f.Project))
+	}
+	if f.Type != "" {
+		clauses = append(clauses, "issuetype = "+quoteJQL(f.Type))
+	}
+	if f.Status != "" {
+		clauses = append(clauses, "status = "+quoteJQL(f.Status))
+	}
+	clauses = append(clauses, f.Clauses...)
+	return strings.Join(clauses, " AND ")
+}
+
+// JQLOrderBy renders sort specs as a JQL ORDER BY clause using sortFields
+// (DSL field → JQL field, JQLSortFieldMap when nil). It returns false when any
+// field has no JQL equivalent, in which case the caller must sort in memory.
+// An empty spec list yields an empty clause.
+func JQLOrderBy(specs []agentquery.SortSpec, sortFields map[string]string) (string, bool) {
+	if sortFields == nil {
+		sortFields = JQLSortFieldMap
+	}
+	if len(specs) == 0 {
+		return "", true
+	}
+	parts := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		field, ok := sortFields[spec.Field]
+		if !ok {
+			return "", false
+		}
+		dir := "ASC"
+		if spec.Direction == agentquery.Desc {
+			dir = "DESC"
+		}
+		parts = append(parts, field+" "+dir)
+	}
+	return "ORDER BY " + strings.Join(parts, ", "), true
+}
+
+// joinJQL appends an ORDER BY clause to a condition; either may be empty.
+func joinJQL(condition, orderBy string) string {
+	switch {
+	case orderBy == "":
+		return condition
+	case condition == "":
+		return orderBy
+	default:
+		return condition + " " + orderBy
+	}
+}
+
+// quoteJQL quotes a value as a JQL string literal.
+func quoteJQL(value string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(value) + `"`
+}
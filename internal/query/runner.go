@@ -0,0 +1,117 @@
+// Runner: queries run on the issue schema, except comments() statements, which
+// run on the comment schema so that comment fields (author, body, ...) are not
+// issue fields. Batches mixing both are split per statement.
+
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/relux-works/skill-agent-facing-api/agentquery"
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// Runner runs DSL queries over the issue and comment schemas.
+type Runner struct {
+	issues   *agentquery.Schema[jira.Issue]
+	comments *agentquery.Schema[commentRow]
+}
+
+// NewRunner builds the issue schema (see NewSchema) and the comment schema.
+func NewRunner(client *jira.Client, defaultProject string, defaultBoard int, opts ...Option) *Runner {
+	return &Runner{
+		issues:   NewSchema(client, defaultProject, defaultBoard, opts...),
+		comments: newCommentSchema(client),
+	}
+}
+
+// querier is what Runner needs of either schema.
+type querier interface {
+	Query(input string) (any, error)
+	QueryJSONWithMode(input string, mode agentquery.OutputMode) ([]byte, error)
+}
+
+// Query runs input like agentquery.Schema.Query: one statement returns its
+// result, a batch returns one result or error object per statement.
+func (r *Runner) Query(input string) (any, error) {
+	parts, single := r.split(input)
+	if single != nil {
+		return single.Query(input)
+	}
+	results := make([]any, 0, len(parts))
+	for _, p := range parts {
+		result, err := p.schema.Query(p.input)
+		if err != nil {
+			result = errorResult(err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// QueryJSONWithMode runs input and serializes the result in mode.
+func (r *Runner) QueryJSONWithMode(input string, mode agentquery.OutputMode) ([]byte, error) {
+	parts, single := r.split(input)
+	if single != nil {
+		return single.QueryJSONWithMode(input, mode)
+	}
+	if mode != agentquery.LLMReadable {
+		result, _ := r.Query(input)
+		return json.Marshal(result)
+	}
+	var out []byte
+	for i, p := range parts {
+		data, err := p.schema.QueryJSONWithMode(p.input, mode)
+		if err != nil {
+			data, _ = agentquery.FormatCompact(errorResult(err), nil)
+		}
+		if i > 0 {
+			out = append(out, '\n')
+		}
+		out = append(append(out, bytes.TrimRight(data, "\n")...), '\n')
+	}
+	return out, nil
+}
+
+type statementPart struct {
+	schema querier
+	input  string
+}
+
+// split returns the schema to run the whole input on when every statement
+// belongs to one schema (or the input does not parse, so the issue schema
+// reports the error); otherwise the statements with their schemas.
+func (r *Runner) split(input string) ([]statementPart, querier) {
+	q, err := agentquery.Parse(input, nil)
+	if err != nil || len(q.Statements) == 0 {
+		return nil, r.issues
+	}
+	var parts []statementPart
+	comments := 0
+	for i, stmt := range q.Statements {
+		end := len(input)
+		if i+1 < len(q.Statements) {
+			end = q.Statements[i+1].Pos.Offset
+		}
+		text := strings.TrimRight(strings.TrimSpace(input[stmt.Pos.Offset:end]), ";")
+		var schema querier = r.issues
+		if stmt.Operation == "comments" {
+			schema = r.comments
+			comments++
+		}
+		parts = append(parts, statementPart{schema: schema, input: text})
+	}
+	switch comments {
+	case 0:
+		return nil, r.issues
+	case len(parts):
+		return nil, r.comments
+	}
+	return parts, nil
+}
+
+func errorResult(err error) map[string]any {
+	return map[string]any{"error": map[string]any{"message": err.Error()}}
+}
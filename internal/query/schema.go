@@ -5,8 +5,12 @@
 package query
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/relux-works/skill-agent-facing-api/agentquery"
 	"github.com/relux-works/skill-jira-management/internal/jira"
@@ -24,6 +28,9 @@ var JiraAPIFieldMap = map[string]string{
 	"parent":      "parent",
 	"description": "description",
 	"labels":      "labels",
+	"components":  "components",
+	"watchers":    "watches",
+	"votes":       "votes",
 	"reporter":    "reporter",
 	"created":     "created",
 	"updated":     "updated",
@@ -35,11 +42,17 @@ var JiraAPIFieldMap = map[string]string{
 // selected in the given FieldSelector. This optimizes API calls by requesting
 // only the fields that will be projected.
 func APIFieldsFromSelector(sel *agentquery.FieldSelector[jira.Issue]) []string {
+	return apiFieldsFor(sel, JiraAPIFieldMap)
+}
+
+// apiFieldsFor is APIFieldsFromSelector over a schema's own field map, which
+// includes custom fields.
+func apiFieldsFor(sel *agentquery.FieldSelector[jira.Issue], apiMap map[string]string) []string {
 	selected := sel.Fields()
 	seen := make(map[string]bool, len(selected))
 	var apiFields []string
 	for _, f := range selected {
-		apiField, ok := JiraAPIFieldMap[f]
+		apiField, ok := apiMap[f]
 		if !ok || apiField == "" {
 			continue
 		}
@@ -53,7 +66,14 @@ func APIFieldsFromSelector(sel *agentquery.FieldSelector[jira.Issue]) []string {
 
 // NewSchema builds a fully configured agentquery.Schema[jira.Issue].
 // The client, defaultProject, and defaultBoard are captured by operation closures.
-func NewSchema(client *jira.Client, defaultProject string, defaultBoard int) *agentquery.Schema[jira.Issue] {
+// Custom fields from WithFieldCatalog are registered next to the built-in fields.
+func NewSchema(client *jira.Client, defaultProject string, defaultBoard int, opts ...Option) *agentquery.Schema[jira.Issue] {
+	var options schemaOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	sf := newSchemaFields(options.catalog, options.aliases)
+
 	schema := agentquery.NewSchema[jira.Issue]()
 
 	// --- Fields ---
@@ -86,6 +106,15 @@ func NewSchema(client *jira.Client, defaultProject string, defaultBoard int) *ag
 	})
 	schema.Field("description", func(i jira.Issue) any { return i.Fields.DescriptionText() })
 	schema.Field("labels", func(i jira.Issue) any { return i.Fields.Labels })
+	schema.Field("components", func(i jira.Issue) any { return componentNames(i) })
+	// watchers needs a request per watched issue; see loadWatchers.
+	schema.Field("watchers", watcherNames)
+	schema.Field("votes", func(i jira.Issue) any {
+		if i.Fields.Votes != nil {
+			return i.Fields.Votes.Votes
+		}
+		return nil
+	})
 	schema.Field("reporter", func(i jira.Issue) any {
 		if i.Fields.Reporter != nil {
 			return i.Fields.Reporter.DisplayName
@@ -113,12 +142,33 @@ func NewSchema(client *jira.Client, defaultProject string, defaultBoard int) *ag
 		return subs
 	})
 
+	// --- Custom fields ---
+	for _, cf := range sf.custom {
+		id := cf.ID
+		schema.Field(cf.Name, func(i jira.Issue) any { return customFieldValue(i, id) })
+		if !cf.Sortable {
+			continue
+		}
+		if cf.Type == "number" {
+			agentquery.SortableField(schema, cf.Name, func(i jira.Issue) float64 { return customFieldNumber(i, id) })
+		} else {
+			agentquery.SortableField(schema, cf.Name, func(i jira.Issue) string { return customFieldString(i, id) })
+		}
+	}
+
 	// --- Presets ---
 	schema.Preset("minimal", "key", "status")
 	schema.Preset("default", "key", "summary", "status", "assignee")
 	schema.Preset("overview", "key", "summary", "status", "assignee", "type", "priority", "parent")
 	schema.Preset("full", "key", "summary", "status", "assignee", "type", "priority", "parent",
-		"description", "labels", "reporter", "created", "updated", "project", "subtasks")
+		"description", "labels", "components", "reporter", "created", "updated", "project", "subtasks")
+	if len(sf.custom) > 0 {
+		names := make([]string, len(sf.custom))
+		for i, cf := range sf.custom {
+			names[i] = cf.Name
+		}
+		schema.Preset("custom", names...)
+	}
 
 	// --- Default fields ---
 	schema.DefaultFields("default")
@@ -173,13 +223,15 @@ func NewSchema(client *jira.Client, defaultProject string, defaultBoard int) *ag
 		}
 		return ""
 	})
+	agentquery.SortableField(schema, "created", func(i jira.Issue) string { return i.Fields.Created })
+	agentquery.SortableField(schema, "updated", func(i jira.Issue) string { return i.Fields.Updated })
 
 	// --- Operations ---
 	// Note: operations are closures that capture client, defaultProject, defaultBoard.
 	// The schema's SetLoader is NOT used because each operation needs to call the Jira API
 	// with different parameters (issue key, JQL, project filters, etc.).
 
-	schema.OperationWithMetadata("get", opGet(client), agentquery.OperationMetadata{
+	schema.OperationWithMetadata("get", opGet(client, sf), agentquery.OperationMetadata{
 		Description: "Fetch a single Jira issue by key",
 		Parameters: []agentquery.ParameterDef{
 			{Name: "key", Type: "string", Optional: false, Description: "Issue key (positional), e.g. PROJ-123"},
@@ -190,40 +242,74 @@ func NewSchema(client *jira.Client, defaultProject string, defaultBoard int) *ag
 		},
 	})
 
-	schema.OperationWithMetadata("list", opList(client, defaultProject, schema), agentquery.OperationMetadata{
-		Description: "List issues with filters, sorting, and pagination",
+	schema.OperationWithMetadata("tree", opTree(client, sf), agentquery.OperationMetadata{
+		Description: "Issue hierarchy: children of an issue (epic children, subtasks) with status-category rollups and progress",
 		Parameters: []agentquery.ParameterDef{
-			{Name: "project", Type: "string", Optional: true, Description: "Project key (defaults to configured project)"},
-			{Name: "type", Type: "string", Optional: true, Description: "Issue type filter (epic, story, task, bug)"},
-			{Name: "status", Type: "string", Optional: true, Description: "Status filter"},
-			{Name: "sort_<field>", Type: "asc|desc", Optional: true, Description: "Sort by field (key, summary, status, assignee, type, priority)"},
+			{Name: "key", Type: "string", Optional: false, Description: "Root issue key (positional), e.g. PROJ-1"},
+			{Name: "depth", Type: "int", Optional: true, Default: defaultTreeDepth, Description: fmt.Sprintf("Levels of children to fetch (0-%d)", maxTreeDepth)},
+		},
+		Examples: []string{
+			"tree(PROJ-1) { minimal }",
+			"tree(PROJ-1, depth=1) { key summary status assignee }",
+		},
+	})
+
+	// comments() runs on its own schema (see Runner); it is registered here so
+	// the parser accepts it and schema() lists it.
+	schema.OperationWithMetadata("comments", func(agentquery.OperationContext[jira.Issue]) (any, error) {
+		return nil, &agentquery.Error{Code: agentquery.ErrValidation, Message: "comments() has comment fields, run it through query.Runner"}
+	}, commentsMetadata)
+
+	schema.OperationWithMetadata("list", opList(client, defaultProject, schema, sf), agentquery.OperationMetadata{
+		Description: "List issues with filters, sorting, and pagination",
+		Parameters: append(filterParameters(sf.custom), []agentquery.ParameterDef{
+			{Name: "sort_<field>", Type: "asc|desc", Optional: true, Description: "Sort by field (key, summary, status, assignee, type, priority, created, updated, sortable custom fields); pushed down to JQL ORDER BY"},
 			{Name: "skip", Type: "int", Optional: true, Default: 0, Description: "Skip first N items"},
 			{Name: "take", Type: "int", Optional: true, Description: "Return at most N items"},
-		},
+		}...),
 		Examples: []string{
 			"list() { overview }",
 			"list(project=PROJ, type=epic) { minimal }",
 			"list(status=open, sort_key=asc) { default }",
 			"list(skip=10, take=5) { overview }",
+			"list(sort_updated=desc, take=5) { default }",
+			`list(assignee=me, status="!Done,Closed", updated_after=7d) { default }`,
+			`list(sprint=current, labels="backend,api", resolution=none) { overview }`,
 		},
 	})
 
-	schema.OperationWithMetadata("count", opCount(client, defaultProject), agentquery.OperationMetadata{
+	schema.OperationWithMetadata("count", opCount(client, defaultProject, sf), agentquery.OperationMetadata{
 		Description: "Count issues matching filters",
-		Parameters: []agentquery.ParameterDef{
-			{Name: "project", Type: "string", Optional: true, Description: "Project key (defaults to configured project)"},
-			{Name: "type", Type: "string", Optional: true, Description: "Issue type filter"},
-			{Name: "status", Type: "string", Optional: true, Description: "Status filter"},
-		},
+		Parameters: append(filterParameters(sf.custom), []agentquery.ParameterDef{
+			{Name: "exact", Type: "bool", Optional: true, Default: false, Description: "Cloud only: scan issue IDs for an exact count instead of the approximate-count API"},
+		}...),
 		Examples: []string{
 			"count()",
 			"count(status=done)",
 			"count(project=PROJ, type=bug)",
+			"count(status=done, exact=true)",
+			`count(type=bug, created=">=startOfMonth()")`,
+		},
+	})
+
+	schema.OperationWithMetadata("group", opGroup(client, defaultProject, sf), agentquery.OperationMetadata{
+		Description: "Group issues by one or more fields and aggregate a count or sum per group",
+		Parameters: append([]agentquery.ParameterDef{
+			{Name: "by", Type: "string", Optional: false, Description: `Field or comma-separated fields for nested groups, e.g. by=assignee or by="status,type"`},
+			{Name: "metric", Type: "string", Optional: true, Default: "count", Description: `count or sum(<number field>), e.g. metric="sum(story_points)"`},
+			{Name: "percent", Type: "bool", Optional: true, Default: false, Description: "Add each group's share of the overall metric"},
+			{Name: "jql", Type: "string", Optional: true, Description: "Raw JQL selecting the issues; replaces project and filters"},
+		}, filterParameters(sf.custom)...),
+		Examples: []string{
+			"group(by=assignee)",
+			`group(by="status,type", percent=true)`,
+			`group(by=assignee, metric="sum(story_points)", sprint=current)`,
+			`group(by=priority, jql="project = PROJ AND resolution is EMPTY")`,
 		},
 	})
 
 	schema.OperationWithMetadata("summary", opSummary(client, defaultProject, defaultBoard), agentquery.OperationMetadata{
-		Description: "Project/board overview with issue counts by status and type",
+		Description: "Project/board overview with issue counts by type, and by board column (with WIP limits) when a board is set, else by status",
 		Parameters: []agentquery.ParameterDef{
 			{Name: "project", Type: "string", Optional: true, Description: "Project key (defaults to configured project)"},
 			{Name: "board", Type: "int", Optional: true, Description: "Board ID (defaults to configured board)"},
@@ -234,7 +320,62 @@ func NewSchema(client *jira.Client, defaultProject string, defaultBoard int) *ag
 		},
 	})
 
-	schema.OperationWithMetadata("search", opSearch(client), agentquery.OperationMetadata{
+	schema.OperationWithMetadata("boards", opBoards(client, defaultProject), agentquery.OperationMetadata{
+		Description: "Agile boards of a project (all visible boards when no project is set)",
+		Parameters: []agentquery.ParameterDef{
+			{Name: "project", Type: "string", Optional: true, Description: "Project key (defaults to configured project)"},
+			{Name: "type", Type: "scrum|kanban", Optional: true, Description: "Only boards of this type"},
+		},
+		Examples: []string{
+			"boards()",
+			"boards(project=PROJ, type=scrum)",
+		},
+	})
+
+	schema.OperationWithMetadata("sprints", opSprints(client, defaultBoard), agentquery.OperationMetadata{
+		Description: "Sprints of a board",
+		Parameters: []agentquery.ParameterDef{
+			{Name: "board", Type: "int", Optional: true, Description: "Board ID (defaults to configured board)"},
+			{Name: "state", Type: "string", Optional: true, Description: `future, active, closed, or a comma-separated list, e.g. state="active,future"`},
+		},
+		Examples: []string{
+			"sprints(state=active)",
+			`sprints(board=42, state="active,future")`,
+		},
+	})
+
+	schema.OperationWithMetadata("sprint_issues", opSprintIssues(client, defaultBoard, schema, sf), agentquery.OperationMetadata{
+		Description: "Issues in a sprint (the board's active sprint by default)",
+		Parameters: []agentquery.ParameterDef{
+			{Name: "sprint", Type: "int", Optional: true, Description: "Sprint ID (positional) or active; defaults to the active sprint of the board"},
+			{Name: "board", Type: "int", Optional: true, Description: "Board whose active sprint is used (defaults to configured board)"},
+			{Name: "jql", Type: "string", Optional: true, Description: "Extra JQL narrowing the sprint's issues"},
+			{Name: "sort_<field>", Type: "asc|desc", Optional: true, Description: "Sort by field"},
+			{Name: "skip", Type: "int", Optional: true, Default: 0, Description: "Skip first N items"},
+			{Name: "take", Type: "int", Optional: true, Description: "Return at most N items"},
+		},
+		Examples: []string{
+			"sprint_issues() { default }",
+			"sprint_issues(sprint=123, sort_status=asc) { overview }",
+			`sprint_issues(board=42, jql="assignee = currentUser()") { minimal }`,
+		},
+	})
+
+	schema.OperationWithMetadata("backlog", opBacklog(client, defaultBoard, schema, sf), agentquery.OperationMetadata{
+		Description: "Backlog of a board (issues not in an active or future sprint), in rank order",
+		Parameters: []agentquery.ParameterDef{
+			{Name: "board", Type: "int", Optional: true, Description: "Board ID (defaults to configured board)"},
+			{Name: "jql", Type: "string", Optional: true, Description: "Extra JQL narrowing the backlog"},
+			{Name: "skip", Type: "int", Optional: true, Default: 0, Description: "Skip first N items"},
+			{Name: "take", Type: "int", Optional: true, Description: "Return at most N items"},
+		},
+		Examples: []string{
+			"backlog(take=20) { overview }",
+			`backlog(board=42, jql="type = Bug") { default }`,
+		},
+	})
+
+	schema.OperationWithMetadata("search", opSearch(client, options.stream, sf), agentquery.OperationMetadata{
 		Description: "Search issues using raw JQL",
 		Parameters: []agentquery.ParameterDef{
 			{Name: "jql", Type: "string", Optional: false, Description: "JQL query string"},
@@ -245,13 +386,20 @@ func NewSchema(client *jira.Client, defaultProject string, defaultBoard int) *ag
 		},
 	})
 
+	schema.OperationWithMetadata("fields", opFields(sf), agentquery.OperationMetadata{
+		Description: "List the custom fields available as DSL fields, filters and sort keys",
+		Examples: []string{
+			"fields()",
+		},
+	})
+
 	return schema
 }
 
 // --- Operation handlers (closures capturing client) ---
 
 // opGet: get(ISSUE-KEY) { fields }
-func opGet(client *jira.Client) agentquery.OperationHandler[jira.Issue] {
+func opGet(client *jira.Client, sf *schemaFields) agentquery.OperationHandler[jira.Issue] {
 	return func(ctx agentquery.OperationContext[jira.Issue]) (any, error) {
 		if len(ctx.Statement.Args) == 0 {
 			return nil, &agentquery.Error{
@@ -261,67 +409,100 @@ func opGet(client *jira.Client) agentquery.OperationHandler[jira.Issue] {
 		}
 
 		issueKey := ctx.Statement.Args[0].Value
-		apiFields := APIFieldsFromSelector(ctx.Selector)
+		apiFields := apiFieldsFor(ctx.Selector, sf.api)
 
 		issue, err := client.GetIssue(issueKey, apiFields)
 		if err != nil {
 			return nil, err
 		}
+		issues := []jira.Issue{*issue}
+		if err := loadWatchers(client, ctx.Selector, issues); err != nil {
+			return nil, err
+		}
 
-		return ctx.Selector.Apply(*issue), nil
+		return ctx.Selector.Apply(issues[0]), nil
 	}
 }
 
-// opList: list(project=X, type=epic, status=open) { fields }
-func opList(client *jira.Client, defaultProject string, schema *agentquery.Schema[jira.Issue]) agentquery.OperationHandler[jira.Issue] {
+// opTree: tree(PROJ-1, depth=3) { fields }
+// Each node carries its selected fields, its children and a rollup of its descendants.
+func opTree(client *jira.Client, sf *schemaFields) agentquery.OperationHandler[jira.Issue] {
 	return func(ctx agentquery.OperationContext[jira.Issue]) (any, error) {
-		opts := jira.ListIssuesOptions{}
+		key, depth, err := parseTreeArgs(ctx.Statement.Args)
+		if err != nil {
+			return nil, err
+		}
 
-		for _, arg := range ctx.Statement.Args {
-			switch arg.Key {
-			case "project":
-				opts.ProjectKey = arg.Value
-			case "type":
-				opts.IssueType = arg.Value
-			case "status":
-				opts.Status = arg.Value
-			case "":
-				// Positional arg - treat as project key if none set
-				if opts.ProjectKey == "" {
-					opts.ProjectKey = arg.Value
-				}
+		apiFields := apiFieldsFor(ctx.Selector, sf.api)
+		for _, f := range append(slices.Clone(treeAPIFields), sf.epicName) {
+			if f != "" && !slices.Contains(apiFields, f) {
+				apiFields = append(apiFields, f)
 			}
 		}
 
-		if opts.ProjectKey == "" {
-			opts.ProjectKey = defaultProject
+		root, err := fetchTree(client, key, depth, apiFields, sf.epicName)
+		if err != nil {
+			return nil, err
 		}
-		if opts.ProjectKey == "" {
+		out, _ := root.render(ctx.Selector)
+		return out, nil
+	}
+}
+
+// opList: list(project=X, type=epic, status=open) { fields }
+// Filters, sort and skip/take are pushed down into JQL and server pagination.
+// Sorting by a field JQL cannot order by falls back to a full fetch sorted in memory.
+func opList(client *jira.Client, defaultProject string, schema *agentquery.Schema[jira.Issue], sf *schemaFields) agentquery.OperationHandler[jira.Issue] {
+	return func(ctx agentquery.OperationContext[jira.Issue]) (any, error) {
+		filter, err := parseIssueFilter(ctx.Statement.Args, defaultProject, client.IsCloud(), sf.filters)
+		if err != nil {
+			return nil, err
+		}
+		if filter.Project == "" {
 			return nil, &agentquery.Error{
 				Code:    agentquery.ErrValidation,
 				Message: "list requires a project (via argument or config)",
 			}
 		}
 
-		apiFields := APIFieldsFromSelector(ctx.Selector)
-		opts.Fields = apiFields
-
-		issues, err := client.ListIssues(opts)
+		specs, err := agentquery.ParseSortSpecs(ctx.Statement.Args)
 		if err != nil {
 			return nil, err
 		}
-
-		// Sort after fetching, before pagination.
-		if err := agentquery.SortSlice(issues, ctx.Statement.Args, schema.SortFields()); err != nil {
+		// Validates sort fields before any request is made.
+		if _, err := agentquery.BuildSortFunc(specs, schema.SortFields()); err != nil {
 			return nil, err
 		}
-
-		// Apply skip/take pagination.
-		page, err := agentquery.PaginateSlice(issues, ctx.Statement.Args)
+		skip, take, err := agentquery.ParseSkipTake(ctx.Statement.Args)
 		if err != nil {
 			return nil, err
 		}
 
+		apiFields := apiFieldsFor(ctx.Selector, sf.api)
+
+		var page []jira.Issue
+		if orderBy, ok := JQLOrderBy(specs, sf.sort); ok {
+			page, err = client.SearchLimited(joinJQL(filter.JQL(), orderBy), apiFields, skip, take)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			issues, err := client.SearchAll(filter.JQL(), withSortAPIFields(apiFields, specs, sf.api))
+			if err != nil {
+				return nil, err
+			}
+			if err := agentquery.SortSlice(issues, ctx.Statement.Args, schema.SortFields()); err != nil {
+				return nil, err
+			}
+			page, err = agentquery.PaginateSlice(issues, ctx.Statement.Args)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := loadWatchers(client, ctx.Selector, page); err != nil {
+			return nil, err
+		}
+
 		results := make([]map[string]any, 0, len(page))
 		for _, issue := range page {
 			results = append(results, ctx.Selector.Apply(issue))
@@ -330,45 +511,121 @@ func opList(client *jira.Client, defaultProject string, schema *agentquery.Schem
 	}
 }
 
-// opCount: count(project=X, type=epic, status=open)
-func opCount(client *jira.Client, defaultProject string) agentquery.OperationHandler[jira.Issue] {
+// withSortAPIFields adds the API fields needed to sort in memory to the projected ones.
+func withSortAPIFields(apiFields []string, specs []agentquery.SortSpec, apiMap map[string]string) []string {
+	fields := append([]string(nil), apiFields...)
+	for _, spec := range specs {
+		apiField := apiMap[spec.Field]
+		if apiField != "" && !slices.Contains(fields, apiField) {
+			fields = append(fields, apiField)
+		}
+	}
+	return fields
+}
+
+// opCount: count(project=X, type=epic, status=open, exact=true)
+// Counts come from the search API without fetching issues. Cloud only offers an
+// approximate count; exact=true on Cloud scans issue IDs instead.
+func opCount(client *jira.Client, defaultProject string, sf *schemaFields) agentquery.OperationHandler[jira.Issue] {
 	return func(ctx agentquery.OperationContext[jira.Issue]) (any, error) {
-		opts := jira.ListIssuesOptions{}
+		filter, err := parseIssueFilter(ctx.Statement.Args, defaultProject, client.IsCloud(), sf.filters)
+		if err != nil {
+			return nil, err
+		}
+		if filter.Project == "" {
+			return nil, &agentquery.Error{
+				Code:    agentquery.ErrValidation,
+				Message: "count requires a project (via argument or config)",
+			}
+		}
 
+		exact := false
 		for _, arg := range ctx.Statement.Args {
-			switch arg.Key {
-			case "project":
-				opts.ProjectKey = arg.Value
-			case "type":
-				opts.IssueType = arg.Value
-			case "status":
-				opts.Status = arg.Value
-			case "":
-				if opts.ProjectKey == "" {
-					opts.ProjectKey = arg.Value
+			if arg.Key == "exact" {
+				v, err := strconv.ParseBool(arg.Value)
+				if err != nil {
+					return nil, &agentquery.Error{
+						Code:    agentquery.ErrValidation,
+						Message: fmt.Sprintf("exact must be true or false, got %q", arg.Value),
+					}
 				}
+				exact = v
 			}
 		}
 
-		if opts.ProjectKey == "" {
-			opts.ProjectKey = defaultProject
-		}
-		if opts.ProjectKey == "" {
-			return nil, &agentquery.Error{
-				Code:    agentquery.ErrValidation,
-				Message: "count requires a project (via argument or config)",
+		if exact && client.IsCloud() {
+			issues, err := client.SearchAll(filter.JQL(), []string{"id"})
+			if err != nil {
+				return nil, err
 			}
+			return map[string]any{"count": len(issues)}, nil
 		}
 
-		// Only need status and issuetype for counting.
-		opts.Fields = []string{"status", "issuetype"}
+		count, approximate, err := client.CountIssues(filter.JQL())
+		if err != nil {
+			return nil, err
+		}
+		result := map[string]any{"count": count}
+		if approximate {
+			result["approximate"] = true
+		}
+		return result, nil
+	}
+}
 
-		issues, err := client.ListIssues(opts)
+// opGroup: group(by="status,type", metric="sum(story_points)", percent=true, jql="...")
+// Scans the matching issues once, fetching only the fields grouped and summed.
+func opGroup(client *jira.Client, defaultProject string, sf *schemaFields) agentquery.OperationHandler[jira.Issue] {
+	return func(ctx agentquery.OperationContext[jira.Issue]) (any, error) {
+		spec, err := parseGroupSpec(ctx.Statement.Args, sf)
 		if err != nil {
 			return nil, err
 		}
 
-		return map[string]any{"count": len(issues)}, nil
+		var jql string
+		for _, arg := range ctx.Statement.Args {
+			if arg.Key == "jql" {
+				jql = arg.Value
+			}
+		}
+		if jql == "" {
+			filter, err := parseIssueFilter(ctx.Statement.Args, defaultProject, client.IsCloud(), sf.filters)
+			if err != nil {
+				return nil, err
+			}
+			if filter.Project == "" {
+				return nil, &agentquery.Error{
+					Code:    agentquery.ErrValidation,
+					Message: "group requires a project or jql (via argument or config)",
+				}
+			}
+			jql = filter.JQL()
+		}
+
+		root := &groupNode{}
+		for issue, err := range client.SearchIter(jql, spec.apiFields(sf)) {
+			if err != nil {
+				return nil, err
+			}
+			amount := 0.0
+			if spec.sumID != "" {
+				amount = customFieldNumber(issue, spec.sumID)
+			}
+			root.add(spec, issue, 0, amount)
+		}
+
+		total := float64(root.count)
+		result := map[string]any{
+			"by":     spec.by,
+			"metric": spec.metric,
+			"issues": root.count,
+		}
+		if spec.sumID != "" {
+			total = root.sum
+			result["sum"] = root.sum
+		}
+		result["groups"] = root.groups(spec, 0, total)
+		return result, nil
 	}
 }
 
@@ -412,29 +669,22 @@ func opSummary(client *jira.Client, defaultProject string, defaultBoard int) age
 				"type": proj.ProjectTypeKey,
 			}
 
-			// Count issues by status and type.
-			issues, err := client.ListIssues(jira.ListIssuesOptions{
-				ProjectKey: projectKey,
-				Fields:     []string{"status", "issuetype"},
-			})
+			// Count issues by type, and by status unless the board's columns replace it.
+			counts, err := projectCounts(client, projectKey, boardID == 0)
 			if err != nil {
-				return nil, fmt.Errorf("listing issues: %w", err)
+				return nil, err
 			}
-
-			statusCounts := map[string]int{}
-			typeCounts := map[string]int{}
-			for _, issue := range issues {
-				if issue.Fields.Status != nil {
-					statusCounts[issue.Fields.Status.Name]++
-				}
-				typeCounts[issue.Fields.IssueType.Name]++
+			result["total_issues"] = counts.Total
+			if boardID == 0 {
+				result["by_status"] = counts.ByStatus
+			}
+			result["by_type"] = counts.ByType
+			if counts.Approximate {
+				result["approximate"] = true
 			}
-			result["total_issues"] = len(issues)
-			result["by_status"] = statusCounts
-			result["by_type"] = typeCounts
 		}
 
-		// Get board info.
+		// Get board info and count its issues by column.
 		if boardID != 0 {
 			board, err := client.GetBoard(boardID)
 			if err != nil {
@@ -445,6 +695,23 @@ func opSummary(client *jira.Client, defaultProject string, defaultBoard int) age
 				"name": board.Name,
 				"type": board.Type,
 			}
+
+			cfg, err := client.GetBoardConfiguration(boardID)
+			if err != nil {
+				return nil, fmt.Errorf("getting board configuration: %w", err)
+			}
+			scope, err := BoardScopeJQL(client, cfg)
+			if err != nil {
+				return nil, err
+			}
+			columns, approximate, err := BoardColumnCounts(client, cfg, scope)
+			if err != nil {
+				return nil, err
+			}
+			result["by_column"] = columns
+			if approximate {
+				result["approximate"] = true
+			}
 		}
 
 		if len(result) == 0 {
@@ -458,8 +725,148 @@ func opSummary(client *jira.Client, defaultProject string, defaultBoard int) age
 	}
 }
 
+// opBoards: boards(project=X, type=scrum)
+func opBoards(client *jira.Client, defaultProject string) agentquery.OperationHandler[jira.Issue] {
+	return func(ctx agentquery.OperationContext[jira.Issue]) (any, error) {
+		project := defaultProject
+		if v := argValue(ctx.Statement.Args, "project"); v != "" {
+			project = v
+		} else if len(ctx.Statement.Args) > 0 && ctx.Statement.Args[0].Key == "" {
+			project = ctx.Statement.Args[0].Value
+		}
+		boardType := strings.ToLower(argValue(ctx.Statement.Args, "type"))
+
+		boards, err := client.ListBoards(project)
+		if err != nil {
+			return nil, err
+		}
+		rows := []map[string]any{}
+		for _, b := range boards {
+			if boardType == "" || strings.EqualFold(b.Type, boardType) {
+				rows = append(rows, boardRow(b))
+			}
+		}
+		return rows, nil
+	}
+}
+
+// opSprints: sprints(board=42, state=active)
+func opSprints(client *jira.Client, defaultBoard int) agentquery.OperationHandler[jira.Issue] {
+	return func(ctx agentquery.OperationContext[jira.Issue]) (any, error) {
+		boardID, err := boardArg("sprints", ctx.Statement.Args, defaultBoard)
+		if err != nil {
+			return nil, err
+		}
+		states, err := sprintStateArg(ctx.Statement.Args)
+		if err != nil {
+			return nil, err
+		}
+
+		sprints, err := client.ListSprints(boardID, states...)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]map[string]any, 0, len(sprints))
+		for _, s := range sprints {
+			rows = append(rows, sprintRow(s))
+		}
+		return rows, nil
+	}
+}
+
+// opSprintIssues: sprint_issues(sprint=123, jql="...") { fields }
+// Without a sprint, the active sprints of the board are read.
+func opSprintIssues(client *jira.Client, defaultBoard int, schema *agentquery.Schema[jira.Issue], sf *schemaFields) agentquery.OperationHandler[jira.Issue] {
+	return func(ctx agentquery.OperationContext[jira.Issue]) (any, error) {
+		sprints, err := resolveSprints(client, ctx.Statement.Args, defaultBoard)
+		if err != nil {
+			return nil, err
+		}
+
+		specs, err := agentquery.ParseSortSpecs(ctx.Statement.Args)
+		if err != nil {
+			return nil, err
+		}
+		apiFields := withSortAPIFields(apiFieldsFor(ctx.Selector, sf.api), specs, sf.api)
+		var issues []jira.Issue
+		for _, s := range sprints {
+			page, err := client.ListSprintIssues(s.ID, argValue(ctx.Statement.Args, "jql"), apiFields)
+			if err != nil {
+				return nil, err
+			}
+			issues = append(issues, page...)
+		}
+		return agileIssueRows(client, ctx, issues, schema)
+	}
+}
+
+// opBacklog: backlog(board=42, jql="...", take=20) { fields }
+// Without a sort, skip/take are pushed down to the Agile API's pagination;
+// sorting reads the whole backlog and sorts it in memory.
+func opBacklog(client *jira.Client, defaultBoard int, schema *agentquery.Schema[jira.Issue], sf *schemaFields) agentquery.OperationHandler[jira.Issue] {
+	return func(ctx agentquery.OperationContext[jira.Issue]) (any, error) {
+		boardID, err := boardArg("backlog", ctx.Statement.Args, defaultBoard)
+		if err != nil {
+			return nil, err
+		}
+		specs, err := agentquery.ParseSortSpecs(ctx.Statement.Args)
+		if err != nil {
+			return nil, err
+		}
+		// Validates sort fields before any request is made.
+		if _, err := agentquery.BuildSortFunc(specs, schema.SortFields()); err != nil {
+			return nil, err
+		}
+		jql := argValue(ctx.Statement.Args, "jql")
+		apiFields := apiFieldsFor(ctx.Selector, sf.api)
+
+		if len(specs) > 0 {
+			issues, err := client.ListBacklogIssues(boardID, jql, withSortAPIFields(apiFields, specs, sf.api))
+			if err != nil {
+				return nil, err
+			}
+			return agileIssueRows(client, ctx, issues, schema)
+		}
+
+		skip, take, err := agentquery.ParseSkipTake(ctx.Statement.Args)
+		if err != nil {
+			return nil, err
+		}
+		page, err := client.ListBacklogIssuesLimited(boardID, jql, apiFields, skip, take)
+		if err != nil {
+			return nil, err
+		}
+		return issueRows(client, ctx.Selector, page)
+	}
+}
+
+// agileIssueRows sorts (if asked), paginates and projects issues fetched from the Agile API.
+func agileIssueRows(client *jira.Client, ctx agentquery.OperationContext[jira.Issue], issues []jira.Issue, schema *agentquery.Schema[jira.Issue]) (any, error) {
+	if err := agentquery.SortSlice(issues, ctx.Statement.Args, schema.SortFields()); err != nil {
+		return nil, err
+	}
+	page, err := agentquery.PaginateSlice(issues, ctx.Statement.Args)
+	if err != nil {
+		return nil, err
+	}
+	return issueRows(client, ctx.Selector, page)
+}
+
+// issueRows loads the watchers (if selected) of a page of issues and projects it.
+func issueRows(client *jira.Client, selector *agentquery.FieldSelector[jira.Issue], page []jira.Issue) (any, error) {
+	if err := loadWatchers(client, selector, page); err != nil {
+		return nil, err
+	}
+	results := make([]map[string]any, 0, len(page))
+	for _, issue := range page {
+		results = append(results, selector.Apply(issue))
+	}
+	return results, nil
+}
+
 // opSearch: search(jql="...") { fields }
-func opSearch(client *jira.Client) agentquery.OperationHandler[jira.Issue] {
+// With a stream writer, rows are written as NDJSON page by page and a Streamed marker is returned.
+func opSearch(client *jira.Client, stream io.Writer, sf *schemaFields) agentquery.OperationHandler[jira.Issue] {
 	return func(ctx agentquery.OperationContext[jira.Issue]) (any, error) {
 		var jql string
 		for _, arg := range ctx.Statement.Args {
@@ -479,12 +886,35 @@ func opSearch(client *jira.Client) agentquery.OperationHandler[jira.Issue] {
 			}
 		}
 
-		apiFields := APIFieldsFromSelector(ctx.Selector)
+		apiFields := apiFieldsFor(ctx.Selector, sf.api)
+
+		if stream != nil {
+			enc := json.NewEncoder(stream)
+			rows := 0
+			for page, err := range client.SearchPages(jql, apiFields) {
+				if err != nil {
+					return nil, err
+				}
+				if err := loadWatchers(client, ctx.Selector, page); err != nil {
+					return nil, err
+				}
+				for _, issue := range page {
+					if err := enc.Encode(ctx.Selector.Apply(issue)); err != nil {
+						return nil, err
+					}
+					rows++
+				}
+			}
+			return Streamed{Rows: rows}, nil
+		}
 
 		issues, err := client.SearchAll(jql, apiFields)
 		if err != nil {
 			return nil, err
 		}
+		if err := loadWatchers(client, ctx.Selector, issues); err != nil {
+			return nil, err
+		}
 
 		results := make([]map[string]any, 0, len(issues))
 		for _, issue := range issues {
@@ -494,3 +924,14 @@ func opSearch(client *jira.Client) agentquery.OperationHandler[jira.Issue] {
 	}
 }
 
+// opFields: fields()
+// Lists the custom fields exposed by the field catalog, with their types and JQL clauses.
+func opFields(sf *schemaFields) agentquery.OperationHandler[jira.Issue] {
+	return func(ctx agentquery.OperationContext[jira.Issue]) (any, error) {
+		fields := sf.custom
+		if fields == nil {
+			fields = []CustomField{}
+		}
+		return map[string]any{"fields": fields, "count": len(fields)}, nil
+	}
+}
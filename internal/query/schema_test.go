@@ -0,0 +1,521 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/relux-works/skill-agent-facing-api/agentquery"
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// fakeSearch serves the Cloud (/rest/api/3/search/jql) and Server (/rest/api/2/search)
+// search endpoints over a project of total issues, recording every request.
+type fakeSearch struct {
+	mu       sync.Mutex
+	total    int
+	counts   map[string]int // per-JQL totals for count requests; defaults to total
+	requests []map[string]any
+}
+
+func (f *fakeSearch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	data, _ := io.ReadAll(r.Body)
+	var req map[string]any
+	json.Unmarshal(data, &req)
+
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	f.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/rest/api/3/search/approximate-count":
+		json.NewEncoder(w).Encode(map[string]int{"count": f.countFor(req["jql"])})
+		return
+	case "/rest/api/3/project/BIG", "/rest/api/2/project/BIG":
+		json.NewEncoder(w).Encode(jira.Project{Key: "BIG", Name: "Big Project"})
+		return
+	case "/rest/api/3/project/BIG/statuses", "/rest/api/2/project/BIG/statuses":
+		json.NewEncoder(w).Encode([]jira.ProjectIssueTypeStatuses{
+			{Name: "Story", Statuses: []jira.Status{{Name: "To Do"}, {Name: "Done"}}},
+			{Name: "Bug", Statuses: []jira.Status{{Name: "To Do"}, {Name: "Fixed"}}},
+		})
+		return
+	case "/rest/agile/1.0/board/9":
+		json.NewEncoder(w).Encode(jira.Board{ID: 9, Name: "BIG board", Type: "kanban"})
+		return
+	case "/rest/agile/1.0/board/9/configuration":
+		fmt.Fprint(w, `{"id":9,"type":"kanban","filter":{"id":"100"},
+			"subQuery":{"query":"resolution is EMPTY"},
+			"columnConfig":{"constraintType":"issueCount","columns":[
+				{"name":"Backlog","statuses":[{"id":"1"}]},
+				{"name":"Doing","statuses":[{"id":"3"},{"id":"4"}],"max":5},
+				{"name":"Done","statuses":[{"id":"10001"}]},
+				{"name":"Parked","statuses":[]}]}}`)
+		return
+	case "/rest/agile/1.0/board/10":
+		json.NewEncoder(w).Encode(jira.Board{ID: 10, Name: "BIG scrum", Type: "scrum"})
+		return
+	case "/rest/agile/1.0/board/10/configuration":
+		fmt.Fprint(w, `{"id":10,"type":"scrum","filter":{"id":"200"},
+			"columnConfig":{"columns":[
+				{"name":"To Do","statuses":[{"id":"1"}]},
+				{"name":"Done","statuses":[{"id":"10001"}]}]}}`)
+		return
+	case "/rest/agile/1.0/board/10/sprint":
+		// Sprint 41 is active, 42 planned; only asking for active ones leaves 42 out.
+		sprints := `{"id":41,"state":"active"},{"id":42,"state":"future"}`
+		if r.URL.Query().Get("state") == "active" {
+			sprints = `{"id":41,"state":"active"}`
+		}
+		fmt.Fprintf(w, `{"isLast":true,"values":[%s]}`, sprints)
+		return
+	}
+
+	maxResults := 100
+	if v, ok := req["maxResults"].(float64); ok {
+		maxResults = int(v)
+	}
+	start := 0
+	switch r.URL.Path {
+	case "/rest/api/3/search/jql":
+		if tok, ok := req["nextPageToken"].(string); ok {
+			fmt.Sscanf(tok, "page-%d", &start)
+		}
+	case "/rest/api/2/search":
+		if v, ok := req["startAt"].(float64); ok {
+			start = int(v)
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	var issues []jira.Issue
+	for n := start; n < min(start+maxResults, f.total); n++ {
+		issues = append(issues, jira.Issue{Key: fmt.Sprintf("BIG-%d", n+1)})
+	}
+	end := start + len(issues)
+
+	resp := map[string]any{
+		"startAt":    start,
+		"maxResults": maxResults,
+		"total":      f.countFor(req["jql"]),
+		"issues":     issues,
+		"isLast":     end >= f.total,
+	}
+	if end < f.total {
+		resp["nextPageToken"] = fmt.Sprintf("page-%d", end)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (f *fakeSearch) countFor(jql any) int {
+	if n, ok := f.counts[fmt.Sprint(jql)]; ok {
+		return n
+	}
+	return f.total
+}
+
+func (f *fakeSearch) requestCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.requests)
+}
+
+func newTestSchema(t *testing.T, total int, instanceType jira.InstanceType) (*agentquery.Schema[jira.Issue], *fakeSearch) {
+	t.Helper()
+	fake := &fakeSearch{total: total}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	client, err := jira.NewClient(jira.Config{
+		BaseURL:      srv.URL,
+		Email:        "user@test.com",
+		Token:        "test-token",
+		InstanceType: instanceType,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return NewSchema(client, "BIG", 0), fake
+}
+
+func listKeys(t *testing.T, result any) []string {
+	t.Helper()
+	rows, ok := result.([]map[string]any)
+	if !ok {
+		t.Fatalf("result type = %T, want []map[string]any", result)
+	}
+	keys := make([]string, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, row["key"].(string))
+	}
+	return keys
+}
+
+func TestList_TakePushedDown_Cloud(t *testing.T) {
+	schema, fake := newTestSchema(t, 40000, jira.InstanceCloud)
+
+	result, err := schema.Query("list(take=5, sort_updated=desc) { minimal }")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := listKeys(t, result); len(got) != 5 || got[0] != "BIG-1" {
+		t.Errorf("keys = %v, want BIG-1..BIG-5", got)
+	}
+	if n := fake.requestCount(); n != 1 {
+		t.Fatalf("requests = %d, want 1", n)
+	}
+	req := fake.requests[0]
+	if req["jql"] != `project = "BIG" ORDER BY updated DESC` {
+		t.Errorf("jql = %q", req["jql"])
+	}
+	if req["maxResults"] != float64(5) {
+		t.Errorf("maxResults = %v, want 5", req["maxResults"])
+	}
+}
+
+func TestList_SkipTakePushedDown_Server(t *testing.T) {
+	schema, fake := newTestSchema(t, 40000, jira.InstanceServer)
+
+	result, err := schema.Query("list(type=Bug, status=\"In Progress\", sort_key=asc, sort_priority=desc, skip=250, take=150) { minimal }")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keys := listKeys(t, result)
+	if len(keys) != 150 || keys[0] != "BIG-251" || keys[149] != "BIG-400" {
+		t.Errorf("got %d keys from %v", len(keys), keys[:1])
+	}
+	if n := fake.requestCount(); n != 2 {
+		t.Fatalf("requests = %d, want 2", n)
+	}
+	first := fake.requests[0]
+	wantJQL := `project = "BIG" AND issuetype = "Bug" AND status = "In Progress" ORDER BY key ASC, priority DESC`
+	if first["jql"] != wantJQL {
+		t.Errorf("jql = %q, want %q", first["jql"], wantJQL)
+	}
+	if first["startAt"] != float64(250) || first["maxResults"] != float64(100) {
+		t.Errorf("first page startAt=%v maxResults=%v", first["startAt"], first["maxResults"])
+	}
+	second := fake.requests[1]
+	if second["startAt"] != float64(350) || second["maxResults"] != float64(50) {
+		t.Errorf("second page startAt=%v maxResults=%v", second["startAt"], second["maxResults"])
+	}
+}
+
+func TestList_SkipOnCloudFetchesOnlyNeededPages(t *testing.T) {
+	schema, fake := newTestSchema(t, 40000, jira.InstanceCloud)
+
+	result, err := schema.Query("list(skip=150, take=10) { minimal }")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keys := listKeys(t, result)
+	if len(keys) != 10 || keys[0] != "BIG-151" {
+		t.Errorf("keys = %v", keys)
+	}
+	if n := fake.requestCount(); n != 2 {
+		t.Errorf("requests = %d, want 2", n)
+	}
+}
+
+func TestList_NoTakeFetchesAll(t *testing.T) {
+	schema, fake := newTestSchema(t, 250, jira.InstanceCloud)
+
+	result, err := schema.Query("list() { minimal }")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if keys := listKeys(t, result); len(keys) != 250 {
+		t.Errorf("got %d keys, want 250", len(keys))
+	}
+	if n := fake.requestCount(); n != 3 {
+		t.Errorf("requests = %d, want 3", n)
+	}
+	if jql := fake.requests[0]["jql"]; jql != `project = "BIG"` {
+		t.Errorf("jql = %q", jql)
+	}
+}
+
+func TestList_UnpushableSortFallsBackToMemory(t *testing.T) {
+	schema, fake := newTestSchema(t, 250, jira.InstanceCloud)
+	// Sortable in the DSL but absent from JQLSortFieldMap.
+	agentquery.SortableField(schema, "project", func(i jira.Issue) string { return i.Key })
+
+	result, err := schema.Query("list(sort_project=desc, take=2) { minimal }")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if keys := listKeys(t, result); len(keys) != 2 || keys[0] != "BIG-99" {
+		t.Errorf("keys = %v, want lexically last keys first", keys)
+	}
+	if n := fake.requestCount(); n != 3 {
+		t.Errorf("requests = %d, want 3 (full fetch)", n)
+	}
+	if jql := fake.requests[0]["jql"].(string); strings.Contains(jql, "ORDER BY") {
+		t.Errorf("jql = %q, want no ORDER BY", jql)
+	}
+	fields, _ := fake.requests[0]["fields"].([]any)
+	if len(fields) != 2 || fields[1] != "project" {
+		t.Errorf("fields = %v, want status plus sort field project", fields)
+	}
+}
+
+func TestList_InvalidSortFieldMakesNoRequest(t *testing.T) {
+	schema, fake := newTestSchema(t, 10, jira.InstanceCloud)
+
+	result, err := schema.Query("list(sort_description=asc) { minimal }")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	errMap, _ := result.(map[string]any)["error"].(map[string]any)
+	if msg, _ := errMap["message"].(string); !strings.Contains(msg, "not sortable") {
+		t.Errorf("result = %v, want not sortable error", result)
+	}
+	if n := fake.requestCount(); n != 0 {
+		t.Errorf("requests = %d, want 0", n)
+	}
+}
+
+func TestCount_Cloud_UsesApproximateCount(t *testing.T) {
+	schema, fake := newTestSchema(t, 40000, jira.InstanceCloud)
+
+	result, err := schema.Query("count(type=Bug)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := result.(map[string]any)
+	if got["count"] != 40000 || got["approximate"] != true {
+		t.Errorf("result = %v", got)
+	}
+	if n := fake.requestCount(); n != 1 {
+		t.Errorf("requests = %d, want 1", n)
+	}
+	if jql := fake.requests[0]["jql"]; jql != `project = "BIG" AND issuetype = "Bug"` {
+		t.Errorf("jql = %q", jql)
+	}
+}
+
+func TestCount_Server_UsesZeroResultSearch(t *testing.T) {
+	schema, fake := newTestSchema(t, 40000, jira.InstanceServer)
+
+	result, err := schema.Query("count(status=Done)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := result.(map[string]any)
+	if got["count"] != 40000 {
+		t.Errorf("count = %v, want 40000", got["count"])
+	}
+	if _, ok := got["approximate"]; ok {
+		t.Error("Server counts are exact")
+	}
+	if n := fake.requestCount(); n != 1 {
+		t.Errorf("requests = %d, want 1", n)
+	}
+	if fake.requests[0]["maxResults"] != float64(0) {
+		t.Errorf("maxResults = %v, want 0", fake.requests[0]["maxResults"])
+	}
+}
+
+func TestCount_ExactOnCloudScansIDs(t *testing.T) {
+	schema, fake := newTestSchema(t, 250, jira.InstanceCloud)
+
+	result, err := schema.Query("count(exact=true)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.(map[string]any); got["count"] != 250 || got["approximate"] != nil {
+		t.Errorf("result = %v", got)
+	}
+	if n := fake.requestCount(); n != 3 {
+		t.Errorf("requests = %d, want 3", n)
+	}
+}
+
+func TestSummary_CountsWithoutFetchingIssues(t *testing.T) {
+	schema, fake := newTestSchema(t, 0, jira.InstanceServer)
+	fake.counts = map[string]int{
+		`project = "BIG"`:                         40000,
+		`project = "BIG" AND status = "To Do"`:    30000,
+		`project = "BIG" AND status = "Done"`:     10000,
+		`project = "BIG" AND issuetype = "Story"`: 25000,
+		`project = "BIG" AND issuetype = "Bug"`:   15000,
+	}
+
+	result, err := schema.Query("summary()")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, ok := result.(map[string]any)
+	if !ok {
+		t.Fatalf("result = %v", result)
+	}
+
+	if got["total_issues"] != 40000 {
+		t.Errorf("total_issues = %v", got["total_issues"])
+	}
+	wantStatus := map[string]int{"To Do": 30000, "Done": 10000}
+	if fmt.Sprint(got["by_status"]) != fmt.Sprint(wantStatus) {
+		t.Errorf("by_status = %v, want %v (Fixed has no issues)", got["by_status"], wantStatus)
+	}
+	wantType := map[string]int{"Story": 25000, "Bug": 15000}
+	if fmt.Sprint(got["by_type"]) != fmt.Sprint(wantType) {
+		t.Errorf("by_type = %v, want %v", got["by_type"], wantType)
+	}
+
+	// project + statuses + 1 total + 3 statuses + 2 types, none returning issues.
+	if n := fake.requestCount(); n != 8 {
+		t.Errorf("requests = %d, want 8", n)
+	}
+	for _, req := range fake.requests {
+		if v, ok := req["maxResults"]; ok && v != float64(0) {
+			t.Errorf("count request with maxResults = %v", v)
+		}
+	}
+}
+
+func TestSummary_StatusesOutsideWorkflowCountAsOther(t *testing.T) {
+	schema, fake := newTestSchema(t, 0, jira.InstanceServer)
+	fake.counts = map[string]int{
+		`project = "BIG"`:                      40,
+		`project = "BIG" AND status = "To Do"`: 30,
+		`project = "BIG" AND status = "Done"`:  6,
+	}
+
+	result, err := schema.Query("summary()")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantStatus := map[string]int{"To Do": 30, "Done": 6, OtherStatus: 4}
+	if got := result.(map[string]any)["by_status"]; fmt.Sprint(got) != fmt.Sprint(wantStatus) {
+		t.Errorf("by_status = %v, want %v", got, wantStatus)
+	}
+}
+
+func TestSummary_BoardCountsByColumn(t *testing.T) {
+	schema, fake := newTestSchema(t, 0, jira.InstanceServer)
+	fake.counts = map[string]int{
+		`project = "BIG"`:                                              40,
+		`project = "BIG" AND issuetype = "Story"`:                      25,
+		`project = "BIG" AND issuetype = "Bug"`:                        15,
+		`filter = 100 AND (resolution is EMPTY) AND status in (1)`:     12,
+		`filter = 100 AND (resolution is EMPTY) AND status in (3, 4)`:  7,
+		`filter = 100 AND (resolution is EMPTY) AND status in (10001)`: 0,
+	}
+
+	result, err := schema.Query("summary(board=9)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := result.(map[string]any)
+
+	if _, ok := got["by_status"]; ok {
+		t.Errorf("by_status reported alongside board columns: %v", got["by_status"])
+	}
+	columns, ok := got["by_column"].([]ColumnCount)
+	if !ok || len(columns) != 4 {
+		t.Fatalf("by_column = %v", got["by_column"])
+	}
+	if columns[0].Issues != 12 || columns[0].WIP != "" {
+		t.Errorf("Backlog = %+v", columns[0])
+	}
+	if columns[1].Issues != 7 || columns[1].WIP != "over" || *columns[1].Max != 5 {
+		t.Errorf("Doing = %+v", columns[1])
+	}
+	if columns[3].Issues != 0 {
+		t.Errorf("Parked = %+v", columns[3])
+	}
+
+	// project + statuses + total + 2 types + board + configuration + 3 columns.
+	if n := fake.requestCount(); n != 10 {
+		t.Errorf("requests = %d, want 10", n)
+	}
+}
+
+func TestSummary_ScrumBoardCountsActiveSprintsOnly(t *testing.T) {
+	schema, fake := newTestSchema(t, 0, jira.InstanceServer)
+	fake.counts = map[string]int{
+		// openSprints() also matches future sprint 42, with 9 planned issues.
+		`filter = 200 AND sprint in openSprints() AND status in (1)`: 14,
+		`filter = 200 AND sprint in (41) AND status in (1)`:          5,
+		`filter = 200 AND sprint in (41) AND status in (10001)`:      2,
+	}
+
+	result, err := schema.Query("summary(board=10)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	columns := result.(map[string]any)["by_column"].([]ColumnCount)
+	if len(columns) != 2 || columns[0].Issues != 5 || columns[1].Issues != 2 {
+		t.Errorf("by_column = %+v, want the active sprint's 5 and 2", columns)
+	}
+}
+
+func TestSearch_WithStreamWritesNDJSON(t *testing.T) {
+	fake := &fakeSearch{total: 250}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "test-token"})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var buf bytes.Buffer
+	schema := NewSchema(client, "BIG", 0, WithStream(&buf))
+	result, err := schema.Query(`search(jql="project = BIG") { key }; count()`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batch := result.([]any)
+	if batch[0] != (Streamed{Rows: 250}) {
+		t.Errorf("search result = %v, want Streamed{250}", batch[0])
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 250 || lines[0] != `{"key":"BIG-1"}` {
+		t.Errorf("got %d lines, first %q", len(lines), lines[0])
+	}
+
+	buf.Reset()
+	if err := WriteNDJSON(&buf, result); err != nil {
+		t.Fatalf("WriteNDJSON: %v", err)
+	}
+	if got := buf.String(); got != `{"approximate":true,"count":250}`+"\n" {
+		t.Errorf("WriteNDJSON = %q, want only the count line", got)
+	}
+}
+
+func TestJQLOrderBy(t *testing.T) {
+	got, ok := JQLOrderBy([]agentquery.SortSpec{
+		{Field: "type", Direction: agentquery.Asc},
+		{Field: "created", Direction: agentquery.Desc},
+	}, nil)
+	if !ok || got != "ORDER BY issuetype ASC, created DESC" {
+		t.Errorf("JQLOrderBy = %q, %v", got, ok)
+	}
+
+	if _, ok := JQLOrderBy([]agentquery.SortSpec{{Field: "labels"}}, nil); ok {
+		t.Error("expected labels to be unpushable")
+	}
+}
+
+func TestIssueFilterJQL_Quoting(t *testing.T) {
+	f := IssueFilter{Project: "P", Status: `Say "hi" \ bye`}
+	want := `project = "P" AND status = "Say \"hi\" \\ bye"`
+	if got := f.JQL(); got != want {
+		t.Errorf("JQL = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+// Streaming output: with WithStream, search() writes each projected issue as an
+// NDJSON line while pages are still being fetched instead of buffering the result.
+
+package query
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// Option configures NewSchema.
+type Option func(*schemaOptions)
+
+type schemaOptions struct {
+	stream  io.Writer
+	catalog []jira.Field
+	aliases map[string]string
+}
+
+// WithStream makes search() write its rows to w as newline-delimited JSON as
+// they arrive. The operation then returns a Streamed marker instead of the rows.
+func WithStream(w io.Writer) Option {
+	return func(o *schemaOptions) { o.stream = w }
+}
+
+// Streamed is returned by operations whose rows were already written to the stream.
+type Streamed struct {
+	Rows int `json:"rows"`
+}
+
+// WriteNDJSON writes a query result as newline-delimited JSON: one line per row
+// for list results, one line otherwise. Batch results are flattened in order and
+// Streamed markers are skipped, their rows having been written already.
+func WriteNDJSON(w io.Writer, result any) error {
+	enc := json.NewEncoder(w)
+	switch v := result.(type) {
+	case Streamed:
+		return nil
+	case []any:
+		for _, item := range v {
+			if err := WriteNDJSON(w, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []map[string]any:
+		for _, row := range v {
+			if err := enc.Encode(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return enc.Encode(v)
+	}
+}
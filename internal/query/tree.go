@@ -0,0 +1,196 @@
+// Issue hierarchy for tree(): children are fetched level by level, one search
+// per batch of parents, and every node reports status-category rollups of its
+// descendants.
+
+package query
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/relux-works/skill-agent-facing-api/agentquery"
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+const (
+	defaultTreeDepth = 3
+	maxTreeDepth     = 6
+
+	// treeBatchSize bounds the parent keys in one "parent in (...)" search.
+	treeBatchSize = 50
+)
+
+// treeAPIFields are always fetched: they link children to parents and feed rollups.
+var treeAPIFields = []string{"status", "parent", "issuetype"}
+
+// TreeRollup counts a node's descendants by status category.
+// Progress is the share of descendants in the Done category, in percent.
+type TreeRollup struct {
+	Total      int     `json:"total"`
+	ToDo       int     `json:"to_do"`
+	InProgress int     `json:"in_progress"`
+	Done       int     `json:"done"`
+	Progress   float64 `json:"progress"`
+}
+
+func (r *TreeRollup) add(other TreeRollup) {
+	r.Total += other.Total
+	r.ToDo += other.ToDo
+	r.InProgress += other.InProgress
+	r.Done += other.Done
+}
+
+func (r *TreeRollup) count(issue jira.Issue) {
+	r.Total++
+	category := ""
+	if issue.Fields.Status != nil && issue.Fields.Status.StatusCategory != nil {
+		category = issue.Fields.Status.StatusCategory.Key
+	}
+	switch category {
+	case "done":
+		r.Done++
+	case "indeterminate":
+		r.InProgress++
+	default:
+		r.ToDo++
+	}
+}
+
+type treeNode struct {
+	issue    jira.Issue
+	children []*treeNode
+}
+
+// fetchTree loads root and up to depth levels of children. On Cloud, children
+// are issues whose parent is the node (epic children and subtasks alike). On
+// Server/DC, epics additionally get the issues in their Epic Link; epicName is
+// the Epic Name field ID used to recognise them (see jira.Issue.IsEpic).
+func fetchTree(client *jira.Client, rootKey string, depth int, apiFields []string, epicName string) (*treeNode, error) {
+	root, err := client.GetIssue(rootKey, apiFields)
+	if err != nil {
+		return nil, err
+	}
+
+	rootNode := &treeNode{issue: *root}
+	seen := map[string]bool{root.Key: true}
+	level := []*treeNode{rootNode}
+	for d := 0; d < depth && len(level) > 0; d++ {
+		children, err := fetchChildren(client, level, apiFields, epicName)
+		if err != nil {
+			return nil, err
+		}
+		var next []*treeNode
+		for _, node := range level {
+			for _, child := range children[node.issue.Key] {
+				if seen[child.Key] {
+					continue
+				}
+				seen[child.Key] = true
+				childNode := &treeNode{issue: child}
+				node.children = append(node.children, childNode)
+				next = append(next, childNode)
+			}
+		}
+		level = next
+	}
+	return rootNode, nil
+}
+
+// fetchChildren returns the children of nodes keyed by parent issue key.
+func fetchChildren(client *jira.Client, nodes []*treeNode, apiFields []string, epicName string) (map[string][]jira.Issue, error) {
+	byParent := map[string][]jira.Issue{}
+	keys := make([]string, len(nodes))
+	for i, node := range nodes {
+		keys[i] = node.issue.Key
+	}
+
+	for batch := range slices.Chunk(keys, treeBatchSize) {
+		issues, err := client.SearchAll(fmt.Sprintf("parent in (%s) ORDER BY key ASC", quoteJQLList(batch)), apiFields)
+		if err != nil {
+			return nil, fmt.Errorf("fetching children: %w", err)
+		}
+		for _, issue := range issues {
+			if issue.Fields.Parent != nil {
+				byParent[issue.Fields.Parent.Key] = append(byParent[issue.Fields.Parent.Key], issue)
+			}
+		}
+	}
+
+	if client.IsCloud() {
+		return byParent, nil
+	}
+	for _, node := range nodes {
+		if !node.issue.IsEpic(epicName) {
+			continue
+		}
+		key := node.issue.Key
+		issues, err := client.SearchAll(fmt.Sprintf(`"Epic Link" = %s ORDER BY key ASC`, quoteJQL(key)), apiFields)
+		if err != nil {
+			return nil, fmt.Errorf("fetching epic %s issues: %w", key, err)
+		}
+		byParent[key] = append(byParent[key], issues...)
+	}
+	return byParent, nil
+}
+
+// render projects a node with the selector and attaches children and rollups.
+// The returned rollup covers the node's descendants.
+func (n *treeNode) render(sel *agentquery.FieldSelector[jira.Issue]) (map[string]any, TreeRollup) {
+	out := sel.Apply(n.issue)
+	var rollup TreeRollup
+	if len(n.children) == 0 {
+		return out, rollup
+	}
+
+	children := make([]map[string]any, 0, len(n.children))
+	for _, child := range n.children {
+		rendered, childRollup := child.render(sel)
+		children = append(children, rendered)
+		rollup.count(child.issue)
+		rollup.add(childRollup)
+	}
+	rollup.Progress = math.Round(float64(rollup.Done)/float64(rollup.Total)*1000) / 10
+	out["children"] = children
+	out["rollup"] = rollup
+	return out, rollup
+}
+
+// parseTreeArgs reads the root key (positional or key=) and depth=.
+func parseTreeArgs(args []agentquery.Arg) (string, int, error) {
+	key, depth := "", defaultTreeDepth
+	for _, arg := range args {
+		switch arg.Key {
+		case "", "key":
+			if key == "" {
+				key = arg.Value
+			}
+		case "depth":
+			d, err := strconv.Atoi(arg.Value)
+			if err != nil || d < 0 || d > maxTreeDepth {
+				return "", 0, &agentquery.Error{
+					Code:    agentquery.ErrValidation,
+					Message: fmt.Sprintf("depth must be 0-%d, got %q", maxTreeDepth, arg.Value),
+				}
+			}
+			depth = d
+		}
+	}
+	if key == "" {
+		return "", 0, &agentquery.Error{
+			Code:    agentquery.ErrValidation,
+			Message: "tree requires an issue key argument",
+		}
+	}
+	return key, depth, nil
+}
+
+func quoteJQLList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = quoteJQL(v)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,184 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// treeIssue builds an issue with a status category and optional parent.
+func treeIssue(key, typ, category, parent string) jira.Issue {
+	issue := jira.Issue{Key: key, Fields: jira.IssueFields{
+		Summary:   key + " summary",
+		IssueType: jira.IssueType{Name: typ},
+		Status:    &jira.Status{Name: category, StatusCategory: &jira.StatusCategory{Key: category}},
+	}}
+	if parent != "" {
+		issue.Fields.Parent = &jira.Issue{Key: parent}
+	}
+	return issue
+}
+
+func newTreeSchema(t *testing.T, instanceType jira.InstanceType, issues []jira.Issue, epicLinks map[string][]string, opts ...Option) (*fakeTree, func(string) map[string]any) {
+	t.Helper()
+	fake := &fakeTree{issues: map[string]jira.Issue{}, epicLinks: epicLinks}
+	for _, issue := range issues {
+		fake.issues[issue.Key] = issue
+		fake.order = append(fake.order, issue.Key)
+	}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: instanceType})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	schema := NewSchema(client, "P", 0, opts...)
+	return fake, func(q string) map[string]any {
+		t.Helper()
+		result, err := schema.Query(q)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		data, _ := json.Marshal(result)
+		var out map[string]any
+		json.Unmarshal(data, &out)
+		return out
+	}
+}
+
+// fakeTree serves issue lookups and answers "parent in (...)" and "Epic Link" searches.
+type fakeTree struct {
+	issues    map[string]jira.Issue
+	order     []string
+	epicLinks map[string][]string // Server only: epic → linked issues
+	jqls      []string
+}
+
+func (f *fakeTree) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if key, ok := strings.CutPrefix(r.URL.Path, "/rest/api/3/issue/"); ok {
+		json.NewEncoder(w).Encode(f.issues[key])
+		return
+	}
+	if key, ok := strings.CutPrefix(r.URL.Path, "/rest/api/2/issue/"); ok {
+		json.NewEncoder(w).Encode(f.issues[key])
+		return
+	}
+
+	data, _ := io.ReadAll(r.Body)
+	var req map[string]any
+	json.Unmarshal(data, &req)
+	jql := fmt.Sprint(req["jql"])
+	f.jqls = append(f.jqls, jql)
+
+	var found []jira.Issue
+	if strings.HasPrefix(jql, `"Epic Link"`) {
+		for epic, keys := range f.epicLinks {
+			if strings.Contains(jql, `"`+epic+`"`) {
+				for _, k := range keys {
+					found = append(found, f.issues[k])
+				}
+			}
+		}
+	} else {
+		for _, k := range f.order {
+			issue := f.issues[k]
+			if issue.Fields.Parent != nil && strings.Contains(jql, `"`+issue.Fields.Parent.Key+`"`) {
+				found = append(found, issue)
+			}
+		}
+	}
+	json.NewEncoder(w).Encode(map[string]any{"issues": found, "isLast": true, "total": len(found)})
+}
+
+func TestTree_Cloud(t *testing.T) {
+	fake, query := newTreeSchema(t, jira.InstanceCloud, []jira.Issue{
+		treeIssue("P-1", "Epic", "indeterminate", ""),
+		treeIssue("P-2", "Story", "done", "P-1"),
+		treeIssue("P-3", "Story", "indeterminate", "P-1"),
+		treeIssue("P-4", "Sub-task", "done", "P-3"),
+		treeIssue("P-5", "Sub-task", "new", "P-3"),
+	}, nil)
+
+	out := query("tree(P-1) { key status }")
+	if out["key"] != "P-1" {
+		t.Fatalf("root = %v", out)
+	}
+	rollup := out["rollup"].(map[string]any)
+	if rollup["total"] != 4.0 || rollup["done"] != 2.0 || rollup["in_progress"] != 1.0 || rollup["to_do"] != 1.0 || rollup["progress"] != 50.0 {
+		t.Errorf("root rollup = %v", rollup)
+	}
+
+	children := out["children"].([]any)
+	if len(children) != 2 {
+		t.Fatalf("children = %v", children)
+	}
+	story := children[1].(map[string]any)
+	if story["key"] != "P-3" || len(story["children"].([]any)) != 2 {
+		t.Errorf("story = %v", story)
+	}
+	if _, ok := children[0].(map[string]any)["rollup"]; ok {
+		t.Error("leaf must not carry a rollup")
+	}
+
+	// One search per level: P-1's children, then P-2/P-3's, then P-4/P-5's.
+	if len(fake.jqls) != 3 || fake.jqls[1] != `parent in ("P-2", "P-3") ORDER BY key ASC` {
+		t.Errorf("jqls = %q", fake.jqls)
+	}
+}
+
+func TestTree_ServerEpicLinkAndDepth(t *testing.T) {
+	fake, query := newTreeSchema(t, jira.InstanceServer, []jira.Issue{
+		treeIssue("P-1", "Epic", "new", ""),
+		treeIssue("P-2", "Story", "done", ""),
+		treeIssue("P-3", "Sub-task", "done", "P-2"),
+	}, map[string][]string{"P-1": {"P-2"}})
+
+	out := query("tree(P-1, depth=1) { minimal }")
+	children := out["children"].([]any)
+	if len(children) != 1 || children[0].(map[string]any)["key"] != "P-2" {
+		t.Fatalf("children = %v", children)
+	}
+	if _, ok := children[0].(map[string]any)["children"]; ok {
+		t.Error("depth=1 must not fetch grandchildren")
+	}
+	if !strings.Contains(strings.Join(fake.jqls, ";"), `"Epic Link" = "P-1"`) {
+		t.Errorf("jqls = %q, want an Epic Link search", fake.jqls)
+	}
+}
+
+func TestTree_ServerLocalizedEpic(t *testing.T) {
+	epic := treeIssue("P-1", "Эпик", "new", "")
+	epic.Fields.CustomFields = map[string]json.RawMessage{"customfield_10011": json.RawMessage(`"Release"`)}
+	fake, query := newTreeSchema(t, jira.InstanceServer, []jira.Issue{
+		epic,
+		treeIssue("P-2", "История", "done", ""),
+	}, map[string][]string{"P-1": {"P-2"}}, WithFieldCatalog([]jira.Field{{
+		ID: "customfield_10011", Name: "Имя эпика", Custom: true,
+		Schema: &jira.FieldSchema{Type: "string", Custom: "com.pyxis.greenhopper.jira:gh-epic-label", CustomID: 10011},
+	}}, nil))
+
+	// The epic is recognised by its Epic Name field, not its type name.
+	children := query("tree(P-1) { minimal }")["children"].([]any)
+	if len(children) != 1 || children[0].(map[string]any)["key"] != "P-2" {
+		t.Fatalf("children = %v", children)
+	}
+	if !strings.Contains(strings.Join(fake.jqls, ";"), `"Epic Link" = "P-1"`) {
+		t.Errorf("jqls = %q, want an Epic Link search", fake.jqls)
+	}
+}
+
+func TestTree_Errors(t *testing.T) {
+	_, query := newTreeSchema(t, jira.InstanceCloud, nil, nil)
+	for _, q := range []string{"tree()", "tree(P-1, depth=99)", "tree(P-1, depth=x)"} {
+		if _, ok := query(q)["error"]; !ok {
+			t.Errorf("%s: expected error", q)
+		}
+	}
+}
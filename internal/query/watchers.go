@@ -0,0 +1,46 @@
+// The watchers field: the "watches" issue field only carries a count, so the
+// watcher list is fetched per watched issue when the field is selected.
+
+package query
+
+import (
+	"slices"
+
+	"github.com/relux-works/skill-agent-facing-api/agentquery"
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/relux-works/skill-jira-management/internal/parallel"
+)
+
+// loadWatchers fills the watcher lists of issues in place when the selector
+// includes watchers. Issues nobody watches are skipped.
+func loadWatchers(client *jira.Client, sel *agentquery.FieldSelector[jira.Issue], issues []jira.Issue) error {
+	if !slices.Contains(sel.Fields(), "watchers") {
+		return nil
+	}
+
+	watches, err := parallel.Map(len(issues), func(i int) (*jira.Watchers, error) {
+		if w := issues[i].Fields.Watches; w != nil && w.WatchCount == 0 {
+			return w, nil
+		}
+		return client.GetWatchers(issues[i].Key)
+	})
+	if err != nil {
+		return err
+	}
+	for i := range issues {
+		issues[i].Fields.Watches = watches[i]
+	}
+	return nil
+}
+
+// watcherNames returns the display names of an issue's watchers.
+func watcherNames(i jira.Issue) any {
+	if i.Fields.Watches == nil {
+		return nil
+	}
+	names := []string{}
+	for _, u := range i.Fields.Watches.Watchers {
+		names = append(names, u.DisplayName)
+	}
+	return names
+}
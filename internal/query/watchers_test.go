@@ -0,0 +1,107 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+func TestSearch_WatchersFetchedForWatchedIssuesOnly(t *testing.T) {
+	var watcherRequests []string
+	var searchFields []any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/rest/api/3/issue/"), "/watchers"); ok {
+			watcherRequests = append(watcherRequests, key)
+			w.Write([]byte(`{"watchCount":2,"watchers":[{"displayName":"Ann"},{"displayName":"Bob"}]}`))
+			return
+		}
+		var req map[string]any
+		json.NewDecoder(r.Body).Decode(&req)
+		searchFields, _ = req["fields"].([]any)
+		w.Write([]byte(`{"issues":[
+			{"key":"W-1","fields":{"watches":{"watchCount":0},"votes":{"votes":0}}},
+			{"key":"W-2","fields":{"watches":{"watchCount":2},"votes":{"votes":4,"hasVoted":true}}}
+		],"isLast":true}`))
+	}))
+	defer srv.Close()
+
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: jira.InstanceCloud})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	result, err := NewSchema(client, "W", 0).Query(`search(jql="project = W"){key watchers votes}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := json.Marshal(result)
+	var rows []map[string]any
+	json.Unmarshal(data, &rows)
+	if len(rows) != 2 {
+		t.Fatalf("rows = %v", rows)
+	}
+	if w := rows[0]["watchers"].([]any); len(w) != 0 {
+		t.Errorf("W-1 watchers = %v", w)
+	}
+	if w := rows[1]["watchers"].([]any); len(w) != 2 || w[0] != "Ann" || rows[1]["votes"] != float64(4) {
+		t.Errorf("W-2 = %v", rows[1])
+	}
+	if len(watcherRequests) != 1 || watcherRequests[0] != "W-2" {
+		t.Errorf("watcher requests = %v, want only W-2", watcherRequests)
+	}
+	if len(searchFields) != 2 || searchFields[0] != "watches" || searchFields[1] != "votes" {
+		t.Errorf("search fields = %v, want watches, votes", searchFields)
+	}
+}
+
+func TestSearch_StreamLoadsPageWatchersConcurrently(t *testing.T) {
+	const watched = 3
+	var arrived atomic.Int32
+	var alone atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/watchers") {
+			// Wait for the other watcher requests of the page to come in.
+			arrived.Add(1)
+			for deadline := time.Now().Add(time.Second); arrived.Load() < watched && time.Now().Before(deadline); {
+				time.Sleep(time.Millisecond)
+			}
+			if arrived.Load() < watched {
+				alone.Store(true)
+			}
+			w.Write([]byte(`{"watchCount":1,"watchers":[{"displayName":"Ann"}]}`))
+			return
+		}
+		w.Write([]byte(`{"issues":[
+			{"key":"W-1","fields":{"watches":{"watchCount":1}}},
+			{"key":"W-2","fields":{"watches":{"watchCount":1}}},
+			{"key":"W-3","fields":{"watches":{"watchCount":1}}}
+		],"isLast":true}`))
+	}))
+	defer srv.Close()
+
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: jira.InstanceCloud})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	var buf bytes.Buffer
+	result, err := NewSchema(client, "W", 0, WithStream(&buf)).Query(`search(jql="project = W"){key watchers}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != (Streamed{Rows: watched}) {
+		t.Errorf("result = %v", result)
+	}
+	if alone.Load() {
+		t.Error("watchers of a page were loaded one issue at a time")
+	}
+	if n := strings.Count(buf.String(), `"watchers":["Ann"]`); n != watched {
+		t.Errorf("streamed rows with watchers = %d, want %d:\n%s", n, watched, buf.String())
+	}
+}
@@ -0,0 +1,116 @@
+// Package release renders release notes for a project version: the issues
+// fixed in it, grouped by issue type, as an ADF document that also renders to
+// Markdown.
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// typeOrder lists the usual issue types in release-note order; other types follow alphabetically.
+var typeOrder = []string{"epic", "new feature", "feature", "story", "improvement", "task", "bug"}
+
+// Group is the issues of one type.
+type Group struct {
+	Type   string
+	Issues []jira.Issue
+}
+
+// GroupByType groups issues by type name, keeping their order within a group.
+func GroupByType(issues []jira.Issue) []Group {
+	var groups []Group
+	index := map[string]int{}
+	for _, issue := range issues {
+		name := issue.Fields.IssueType.Name
+		if name == "" {
+			name = "Other"
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, Group{Type: name})
+		}
+		groups[i].Issues = append(groups[i].Issues, issue)
+	}
+	slices.SortStableFunc(groups, func(a, b Group) int {
+		ra, rb := typeRank(a.Type), typeRank(b.Type)
+		if ra != rb {
+			return ra - rb
+		}
+		return strings.Compare(a.Type, b.Type)
+	})
+	return groups
+}
+
+func typeRank(name string) int {
+	if i := slices.Index(typeOrder, strings.ToLower(name)); i >= 0 {
+		return i
+	}
+	return len(typeOrder)
+}
+
+// Notes builds the release notes for a version. Issue keys link to baseURL/browse.
+// The version description is left out: --publish description stores the notes
+// there, and the next run would nest them in the new notes.
+func Notes(version jira.Version, issues []jira.Issue, baseURL string) *jira.ADFDoc {
+	doc := &jira.ADFDoc{Type: "doc", Version: 1}
+	doc.Content = append(doc.Content, heading(1, "Release "+version.Name))
+	if version.ReleaseDate != "" {
+		state := "Planned for"
+		if version.Released {
+			state = "Released"
+		}
+		doc.Content = append(doc.Content, paragraph(jira.ADFNode{
+			Type:  "text",
+			Text:  fmt.Sprintf("%s %s", state, version.ReleaseDate),
+			Marks: []jira.ADFMark{{Type: "em"}},
+		}))
+	}
+
+	if len(issues) == 0 {
+		doc.Content = append(doc.Content, paragraph(text("No issues in this version.")))
+		return doc
+	}
+
+	baseURL = strings.TrimRight(baseURL, "/")
+	for _, group := range GroupByType(issues) {
+		doc.Content = append(doc.Content, heading(2, fmt.Sprintf("%s (%d)", group.Type, len(group.Issues))))
+		list := jira.ADFNode{Type: "bulletList"}
+		for _, issue := range group.Issues {
+			key := text(issue.Key)
+			if baseURL != "" {
+				key.Marks = []jira.ADFMark{link(baseURL + "/browse/" + issue.Key)}
+			}
+			list.Content = append(list.Content, jira.ADFNode{
+				Type:    "listItem",
+				Content: []jira.ADFNode{paragraph(key, text(" "+issue.Fields.Summary))},
+			})
+		}
+		doc.Content = append(doc.Content, list)
+	}
+	return doc
+}
+
+func heading(level int, s string) jira.ADFNode {
+	attrs, _ := json.Marshal(map[string]int{"level": level})
+	return jira.ADFNode{Type: "heading", Attrs: attrs, Content: []jira.ADFNode{text(s)}}
+}
+
+func paragraph(nodes ...jira.ADFNode) jira.ADFNode {
+	return jira.ADFNode{Type: "paragraph", Content: nodes}
+}
+
+func text(s string) jira.ADFNode {
+	return jira.ADFNode{Type: "text", Text: s}
+}
+
+func link(href string) jira.ADFMark {
+	attrs, _ := json.Marshal(map[string]string{"href": href})
+	return jira.ADFMark{Type: "link", Attrs: attrs}
+}
@@ -0,0 +1,70 @@
+package release
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+func testIssue(key, issueType, summary string) jira.Issue {
+	return jira.Issue{Key: key, Fields: jira.IssueFields{
+		Summary:   summary,
+		IssueType: jira.IssueType{Name: issueType},
+	}}
+}
+
+func TestGroupByType(t *testing.T) {
+	groups := GroupByType([]jira.Issue{
+		testIssue("P-1", "Bug", "Crash on login"),
+		testIssue("P-2", "Spike", "Try a new parser"),
+		testIssue("P-3", "Story", "Search by tag"),
+		testIssue("P-4", "Bug", "Wrong totals"),
+		testIssue("P-5", "Chore", "Bump deps"),
+	})
+
+	var order []string
+	for _, g := range groups {
+		order = append(order, g.Type)
+	}
+	if got := strings.Join(order, ","); got != "Story,Bug,Chore,Spike" {
+		t.Fatalf("order = %s", got)
+	}
+	if bugs := groups[1].Issues; len(bugs) != 2 || bugs[0].Key != "P-1" || bugs[1].Key != "P-4" {
+		t.Errorf("bugs = %+v", bugs)
+	}
+}
+
+func TestNotesMarkdown(t *testing.T) {
+	version := jira.Version{Name: "2.3", ReleaseDate: "2026-03-13", Released: true, Description: "Search release"}
+	doc := Notes(version, []jira.Issue{
+		testIssue("P-4", "Bug", "Wrong totals"),
+		testIssue("P-3", "Story", "Search by tag"),
+	}, "https://example.atlassian.net/")
+
+	md := doc.Markdown()
+	for _, want := range []string{
+		"# Release 2.3",
+		"_Released 2026-03-13_",
+		"## Story (1)",
+		"[P-3](https://example.atlassian.net/browse/P-3) Search by tag",
+		"## Bug (1)",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "Search release") {
+		t.Errorf("notes repeat the version description:\n%s", md)
+	}
+	if strings.Index(md, "## Story") > strings.Index(md, "## Bug") {
+		t.Errorf("bugs before stories:\n%s", md)
+	}
+}
+
+func TestNotesEmpty(t *testing.T) {
+	md := Notes(jira.Version{Name: "2.4"}, nil, "").Markdown()
+	if !strings.Contains(md, "No issues in this version.") {
+		t.Errorf("markdown = %s", md)
+	}
+}
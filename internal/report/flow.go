@@ -0,0 +1,314 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// flowAPIFields are the issue fields a flow report reads.
+var flowAPIFields = []string{"status", "created", "project", "issuetype", "summary"}
+
+// FlowReport summarizes how issues moved through their workflow, in days.
+// Lead time runs from creation to done, cycle time from the first move into
+// an In Progress status to done. Done means the issue's final status is in
+// the Done category; issues that never reached In Progress have no cycle time.
+type FlowReport struct {
+	JQL          string       `json:"jql"`
+	Issues       int          `json:"issues"`
+	Completed    int          `json:"completed"`
+	LeadTime     Distribution `json:"lead_time"`
+	CycleTime    Distribution `json:"cycle_time"`
+	TimeInStatus []StatusTime `json:"time_in_status"`
+	Throughput   []WeekCount  `json:"throughput"`
+	Items        []FlowIssue  `json:"items"`
+}
+
+// Distribution describes a set of durations in days.
+type Distribution struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean"`
+	P50   float64 `json:"p50"`
+	P85   float64 `json:"p85"`
+	P95   float64 `json:"p95"`
+	Max   float64 `json:"max"`
+}
+
+// StatusTime is the time issues spent in one status before they were done
+// (or until now, for open issues).
+type StatusTime struct {
+	Status   string  `json:"status"`
+	Category string  `json:"category"`
+	Total    float64 `json:"total"`
+	Distribution
+}
+
+// WeekCount is the number of issues completed in an ISO week.
+type WeekCount struct {
+	Week      string `json:"week"`  // e.g. 2026-W10
+	Start     string `json:"start"` // Monday of the week
+	Completed int    `json:"completed"`
+}
+
+// FlowIssue is one issue's timeline. Started and Done are empty when the
+// issue has not reached them; LeadDays and CycleDays are then nil.
+type FlowIssue struct {
+	Key          string             `json:"key"`
+	Type         string             `json:"type,omitempty"`
+	Status       string             `json:"status"`
+	Created      string             `json:"created"`
+	Started      string             `json:"started,omitempty"`
+	Done         string             `json:"done,omitempty"`
+	LeadDays     *float64           `json:"lead_days,omitempty"`
+	CycleDays    *float64           `json:"cycle_days,omitempty"`
+	TimeInStatus map[string]float64 `json:"time_in_status"`
+}
+
+// Flow builds a flow report for the issues a JQL query matches.
+func (r *Reporter) Flow(jql string) (*FlowReport, error) {
+	issues, err := r.client.SearchAll(jql, flowAPIFields)
+	if err != nil {
+		return nil, fmt.Errorf("searching issues: %w", err)
+	}
+	categories, err := r.projectCategories(issues)
+	if err != nil {
+		return nil, fmt.Errorf("loading project statuses: %w", err)
+	}
+	histories, err := fetchHistories(r.client, issues)
+	if err != nil {
+		return nil, fmt.Errorf("loading changelogs: %w", err)
+	}
+	report := buildFlowReport(histories, categories, r.now())
+	report.JQL = jql
+	return report, nil
+}
+
+// projectCategories maps the statuses of every project in issues to categories.
+func (r *Reporter) projectCategories(issues []jira.Issue) (map[string]string, error) {
+	var statuses []jira.Status
+	seen := map[string]bool{}
+	for _, issue := range issues {
+		project := issue.Fields.Project.Key
+		if project == "" || seen[project] {
+			continue
+		}
+		seen[project] = true
+		types, err := r.client.ListProjectStatuses(project)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range types {
+			statuses = append(statuses, t.Statuses...)
+		}
+	}
+	return categoryMap(statuses), nil
+}
+
+// statusSegment is a stretch of time an issue spent in one status.
+type statusSegment struct {
+	status   fieldValue
+	category string
+	from, to time.Time
+}
+
+// timeline splits the issue's life from creation to now into status segments.
+func (h *issueHistory) timeline(categories map[string]string, now time.Time) []statusSegment {
+	status := h.statusAt(h.created)
+	at := h.created
+	var segments []statusSegment
+	for _, c := range h.changes {
+		if !isStatusItem(c.item) || c.at.Before(h.created) {
+			continue
+		}
+		segments = append(segments, statusSegment{status: status, category: category(categories, status), from: at, to: c.at})
+		status, at = fieldValue{c.item.To, c.item.ToString}, c.at
+	}
+	return append(segments, statusSegment{status: status, category: category(categories, status), from: at, to: now})
+}
+
+// flowIssue derives an issue's start, done and per-status times from its timeline.
+func flowIssue(h *issueHistory, segments []statusSegment) FlowIssue {
+	item := FlowIssue{
+		Key:          h.issue.Key,
+		Type:         h.issue.Fields.IssueType.Name,
+		Created:      h.created.Format(time.RFC3339),
+		TimeInStatus: map[string]float64{},
+	}
+	last := segments[len(segments)-1]
+	item.Status = statusName(last.status)
+
+	var started, done time.Time
+	for _, s := range segments {
+		if started.IsZero() && s.category == "indeterminate" {
+			started = s.from
+		}
+	}
+	if last.category == "done" {
+		done = last.from
+		segments = segments[:len(segments)-1]
+	}
+	for _, s := range segments {
+		item.TimeInStatus[statusName(s.status)] += days(s.to.Sub(s.from))
+	}
+	for name, d := range item.TimeInStatus {
+		item.TimeInStatus[name] = round1(d)
+	}
+
+	if !started.IsZero() && (done.IsZero() || !started.After(done)) {
+		item.Started = started.Format(time.RFC3339)
+	}
+	if !done.IsZero() {
+		item.Done = done.Format(time.RFC3339)
+		lead := round1(days(done.Sub(h.created)))
+		item.LeadDays = &lead
+		if item.Started != "" {
+			cycle := round1(days(done.Sub(started)))
+			item.CycleDays = &cycle
+		}
+	}
+	return item
+}
+
+func buildFlowReport(histories []issueHistory, categories map[string]string, now time.Time) *FlowReport {
+	report := &FlowReport{Issues: len(histories), TimeInStatus: []StatusTime{}, Throughput: []WeekCount{}, Items: []FlowIssue{}}
+
+	var lead, cycle []float64
+	var doneAt []time.Time
+	inStatus := map[string][]float64{}
+	statusCategory := map[string]string{}
+	for i := range histories {
+		h := &histories[i]
+		segments := h.timeline(categories, now)
+		item := flowIssue(h, segments)
+		report.Items = append(report.Items, item)
+
+		for _, s := range segments {
+			statusCategory[statusName(s.status)] = s.category
+		}
+		for name, d := range item.TimeInStatus {
+			inStatus[name] = append(inStatus[name], d)
+		}
+		if item.LeadDays != nil {
+			report.Completed++
+			lead = append(lead, *item.LeadDays)
+			doneAt = append(doneAt, segments[len(segments)-1].from)
+		}
+		if item.CycleDays != nil {
+			cycle = append(cycle, *item.CycleDays)
+		}
+	}
+
+	report.LeadTime = distribution(lead)
+	report.CycleTime = distribution(cycle)
+	for name, values := range inStatus {
+		total := 0.0
+		for _, v := range values {
+			total += v
+		}
+		report.TimeInStatus = append(report.TimeInStatus, StatusTime{
+			Status:       name,
+			Category:     statusCategory[name],
+			Total:        round1(total),
+			Distribution: distribution(values),
+		})
+	}
+	slices.SortFunc(report.TimeInStatus, func(a, b StatusTime) int {
+		if c := categoryOrder(a.Category) - categoryOrder(b.Category); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Status, b.Status)
+	})
+	report.Throughput = weeklyThroughput(doneAt)
+	return report
+}
+
+// distribution summarizes values with nearest-rank percentiles.
+func distribution(values []float64) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sorted := slices.Sorted(slices.Values(values))
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	return Distribution{
+		Count: len(sorted),
+		Mean:  round1(sum / float64(len(sorted))),
+		P50:   percentile(sorted, 50),
+		P85:   percentile(sorted, 85),
+		P95:   percentile(sorted, 95),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile of sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// weeklyThroughput counts completions per ISO week, including empty weeks
+// between the first and last completion.
+func weeklyThroughput(doneAt []time.Time) []WeekCount {
+	if len(doneAt) == 0 {
+		return []WeekCount{}
+	}
+	counts := map[time.Time]int{}
+	first, last := weekStart(doneAt[0]), weekStart(doneAt[0])
+	for _, t := range doneAt {
+		week := weekStart(t)
+		counts[week]++
+		if week.Before(first) {
+			first = week
+		}
+		if week.After(last) {
+			last = week
+		}
+	}
+
+	var weeks []WeekCount
+	for week := first; !week.After(last); week = week.AddDate(0, 0, 7) {
+		year, n := week.ISOWeek()
+		weeks = append(weeks, WeekCount{
+			Week:      fmt.Sprintf("%d-W%02d", year, n),
+			Start:     week.Format(dateLayout),
+			Completed: counts[week],
+		})
+	}
+	return weeks
+}
+
+// weekStart returns midnight UTC of the Monday starting t's week.
+func weekStart(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+func categoryOrder(key string) int {
+	switch key {
+	case "new":
+		return 0
+	case "indeterminate":
+		return 1
+	case "done":
+		return 2
+	}
+	return 3
+}
+
+func statusName(v fieldValue) string {
+	if v.text != "" {
+		return v.text
+	}
+	return v.id
+}
+
+func days(d time.Duration) float64 {
+	return d.Hours() / 24
+}
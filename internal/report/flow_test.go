@@ -0,0 +1,118 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// flowCategories: To Do (1) new, In Progress (3) and Review (4) indeterminate, Done (10001).
+var flowCategories = map[string]string{"1": "new", "3": "indeterminate", "4": "indeterminate", "10001": "done"}
+
+func flowStatusChange(from, to string) jira.ChangelogItem {
+	names := map[string]string{"1": "To Do", "3": "In Progress", "4": "Review", "10001": "Done"}
+	return jira.ChangelogItem{Field: "status", From: from, FromString: names[from], To: to, ToString: names[to]}
+}
+
+func flowHistories() []issueHistory {
+	done := &jira.Status{ID: "10001", Name: "Done"}
+	issue := func(key, created string, status *jira.Status) jira.Issue {
+		i := testIssue(key, created, status.ID, 0)
+		i.Fields.Status = status
+		return i
+	}
+	return []issueHistory{
+		// Lead 4d, cycle 2d: 2d To Do, 1d In Progress, 1d Review.
+		newIssueHistory(issue("P-1", "2026-03-02T09:00:00Z", done), []jira.ChangelogHistory{
+			testHistory("2026-03-04T09:00:00Z", flowStatusChange("1", "3")),
+			testHistory("2026-03-05T09:00:00Z", flowStatusChange("3", "4")),
+			testHistory("2026-03-06T09:00:00Z", flowStatusChange("4", "10001")),
+		}),
+		// Lead 10d, cycle 6d, done the following week.
+		newIssueHistory(issue("P-2", "2026-03-02T09:00:00Z", done), []jira.ChangelogHistory{
+			testHistory("2026-03-06T09:00:00Z", flowStatusChange("1", "3")),
+			testHistory("2026-03-12T09:00:00Z", flowStatusChange("3", "10001")),
+		}),
+		// Closed straight from To Do: lead time but no cycle time. Done two weeks later.
+		newIssueHistory(issue("P-3", "2026-03-02T09:00:00Z", done), []jira.ChangelogHistory{
+			testHistory("2026-03-20T09:00:00Z", flowStatusChange("1", "10001")),
+		}),
+		// Still in progress.
+		newIssueHistory(issue("P-4", "2026-03-09T09:00:00Z", &jira.Status{ID: "3", Name: "In Progress"}), []jira.ChangelogHistory{
+			testHistory("2026-03-10T09:00:00Z", flowStatusChange("1", "3")),
+		}),
+	}
+}
+
+func TestBuildFlowReport(t *testing.T) {
+	r := buildFlowReport(flowHistories(), flowCategories, ts("2026-03-25T09:00:00Z"))
+
+	if r.Issues != 4 || r.Completed != 3 {
+		t.Fatalf("issues = %d, completed = %d", r.Issues, r.Completed)
+	}
+	if r.LeadTime != (Distribution{Count: 3, Mean: 10.7, P50: 10, P85: 18, P95: 18, Max: 18}) {
+		t.Errorf("lead time = %+v", r.LeadTime)
+	}
+	if r.CycleTime != (Distribution{Count: 2, Mean: 4, P50: 2, P85: 6, P95: 6, Max: 6}) {
+		t.Errorf("cycle time = %+v", r.CycleTime)
+	}
+
+	p1 := r.Items[0]
+	if p1.TimeInStatus["To Do"] != 2 || p1.TimeInStatus["In Progress"] != 1 || p1.TimeInStatus["Review"] != 1 {
+		t.Errorf("P-1 time in status = %v", p1.TimeInStatus)
+	}
+	if _, ok := p1.TimeInStatus["Done"]; ok {
+		t.Errorf("time in the final Done status counted: %v", p1.TimeInStatus)
+	}
+	if p4 := r.Items[3]; p4.Done != "" || p4.LeadDays != nil || p4.Started == "" || p4.TimeInStatus["In Progress"] != 15 {
+		t.Errorf("P-4 = %+v", p4)
+	}
+}
+
+func TestBuildFlowReport_TimeInStatusOrder(t *testing.T) {
+	r := buildFlowReport(flowHistories(), flowCategories, ts("2026-03-25T09:00:00Z"))
+
+	var order []string
+	for _, s := range r.TimeInStatus {
+		order = append(order, s.Status)
+	}
+	want := []string{"To Do", "In Progress", "Review"}
+	if len(order) != len(want) {
+		t.Fatalf("statuses = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("statuses = %v, want %v", order, want)
+		}
+	}
+	if inProgress := r.TimeInStatus[1]; inProgress.Count != 3 || inProgress.Total != 22 || inProgress.Category != "indeterminate" {
+		t.Errorf("In Progress = %+v", inProgress)
+	}
+}
+
+func TestWeeklyThroughput(t *testing.T) {
+	r := buildFlowReport(flowHistories(), flowCategories, ts("2026-03-25T09:00:00Z"))
+
+	want := []WeekCount{
+		{Week: "2026-W10", Start: "2026-03-02", Completed: 1},
+		{Week: "2026-W11", Start: "2026-03-09", Completed: 1},
+		{Week: "2026-W12", Start: "2026-03-16", Completed: 1},
+	}
+	if len(r.Throughput) != len(want) {
+		t.Fatalf("throughput = %+v", r.Throughput)
+	}
+	for i := range want {
+		if r.Throughput[i] != want[i] {
+			t.Errorf("week %d = %+v, want %+v", i, r.Throughput[i], want[i])
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	for _, c := range []struct{ p, want float64 }{{50, 5}, {85, 9}, {95, 10}, {1, 1}} {
+		if got := percentile(sorted, c.p); got != c.want {
+			t.Errorf("p%v = %v, want %v", c.p, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+// Package report builds agile reports (sprint burndown, velocity, flow) by replaying
+// issue changelogs: every field value is reconstructed as of a point in time
+// from the issue's current value and its recorded changes.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+	"github.com/relux-works/skill-jira-management/internal/parallel"
+)
+
+// jiraTimeLayout is the timestamp format of Jira REST responses.
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// pointsFieldNames are the story points fields Jira ships with (company- and
+// team-managed projects), tried when no field is configured.
+var pointsFieldNames = []string{"Story Points", "Story point estimate"}
+
+// pointsAliases are field_aliases entries that name the story points field.
+var pointsAliases = []string{"story_points", "sp", "points"}
+
+// Reporter builds reports from one Jira instance.
+type Reporter struct {
+	client     *jira.Client
+	points     jira.Field
+	now        func() time.Time
+	categories map[string]string // status ID or lower-case name → category key
+}
+
+// New returns a Reporter that estimates with the given story points field.
+func New(client *jira.Client, points jira.Field) *Reporter {
+	return &Reporter{client: client, points: points, now: time.Now}
+}
+
+// PointsField resolves the story points field: ref (a field ID or name) when
+// set, else a field alias named story_points, sp or points, else a catalog
+// field named "Story Points" or "Story point estimate".
+func PointsField(catalog []jira.Field, aliases map[string]string, ref string) (jira.Field, error) {
+	if ref = strings.TrimSpace(ref); ref != "" {
+		if target, ok := aliases[ref]; ok {
+			ref = target
+		}
+		if field, ok := findField(catalog, ref); ok {
+			return field, nil
+		}
+		return jira.Field{}, fmt.Errorf("story points field %q not found", ref)
+	}
+	for _, alias := range pointsAliases {
+		if target, ok := aliases[alias]; ok {
+			if field, ok := findField(catalog, target); ok {
+				return field, nil
+			}
+		}
+	}
+	for _, name := range pointsFieldNames {
+		if field, ok := findField(catalog, name); ok {
+			return field, nil
+		}
+	}
+	return jira.Field{}, fmt.Errorf("no story points field found: use --points-field or jira-mgmt config set field_alias.story_points <field>")
+}
+
+func findField(catalog []jira.Field, ref string) (jira.Field, bool) {
+	for _, field := range catalog {
+		if strings.EqualFold(field.ID, ref) {
+			return field, true
+		}
+	}
+	for _, field := range catalog {
+		if strings.EqualFold(field.Name, ref) {
+			return field, true
+		}
+	}
+	return jira.Field{}, false
+}
+
+// statusCategories maps status IDs and lower-case names to category keys
+// (new, indeterminate, done), loading all statuses once.
+func (r *Reporter) statusCategories() (map[string]string, error) {
+	if r.categories != nil {
+		return r.categories, nil
+	}
+	statuses, err := r.client.ListStatuses()
+	if err != nil {
+		return nil, err
+	}
+	r.categories = categoryMap(statuses)
+	return r.categories, nil
+}
+
+func categoryMap(statuses []jira.Status) map[string]string {
+	categories := make(map[string]string, 2*len(statuses))
+	for _, s := range statuses {
+		if s.StatusCategory == nil {
+			continue
+		}
+		categories[s.ID] = s.StatusCategory.Key
+		categories[strings.ToLower(s.Name)] = s.StatusCategory.Key
+	}
+	return categories
+}
+
+// change is one changelog item with its timestamp.
+type change struct {
+	at   time.Time
+	item jira.ChangelogItem
+}
+
+// fieldValue is a field's raw value (an ID) and its display text.
+type fieldValue struct {
+	id, text string
+}
+
+// issueHistory is an issue with its flattened, time-ordered changes.
+type issueHistory struct {
+	issue   jira.Issue
+	created time.Time
+	changes []change
+}
+
+func newIssueHistory(issue jira.Issue, histories []jira.ChangelogHistory) issueHistory {
+	h := issueHistory{issue: issue}
+	h.created, _ = time.Parse(jiraTimeLayout, issue.Fields.Created)
+	for _, history := range histories {
+		at, err := time.Parse(jiraTimeLayout, history.Created)
+		if err != nil {
+			continue
+		}
+		for _, item := range history.Items {
+			h.changes = append(h.changes, change{at: at, item: item})
+		}
+	}
+	slices.SortStableFunc(h.changes, func(a, b change) int { return a.at.Compare(b.at) })
+	return h
+}
+
+// fieldAt returns a field's value at t: the last matching change at or before
+// t, else the value before the first change, else current if it never changed.
+func (h *issueHistory) fieldAt(t time.Time, match func(jira.ChangelogItem) bool, current fieldValue) fieldValue {
+	value, seen := current, false
+	for _, c := range h.changes {
+		if !match(c.item) {
+			continue
+		}
+		if !seen {
+			value, seen = fieldValue{c.item.From, c.item.FromString}, true
+		}
+		if c.at.After(t) {
+			break
+		}
+		value = fieldValue{c.item.To, c.item.ToString}
+	}
+	return value
+}
+
+// exists reports whether the issue had been created by t.
+func (h *issueHistory) exists(t time.Time) bool {
+	return h.created.IsZero() || !h.created.After(t)
+}
+
+func isStatusItem(item jira.ChangelogItem) bool {
+	return strings.EqualFold(item.Field, "status")
+}
+
+// statusAt returns the issue's status at t.
+func (h *issueHistory) statusAt(t time.Time) fieldValue {
+	current := fieldValue{}
+	if s := h.issue.Fields.Status; s != nil {
+		current = fieldValue{s.ID, s.Name}
+	}
+	return h.fieldAt(t, isStatusItem, current)
+}
+
+// category returns a status's category key, looked up by ID then by name.
+func category(categories map[string]string, status fieldValue) string {
+	if key, ok := categories[status.id]; ok {
+		return key
+	}
+	return categories[strings.ToLower(status.text)]
+}
+
+// pointsAt returns the issue's story points at t.
+func (h *issueHistory) pointsAt(t time.Time, field jira.Field) float64 {
+	match := func(item jira.ChangelogItem) bool {
+		return item.FieldID == field.ID || strings.EqualFold(item.Field, field.Name)
+	}
+	current := fieldValue{text: rawNumber(h.issue.Fields.CustomFields[field.ID])}
+	v := h.fieldAt(t, match, current)
+	points, _ := strconv.ParseFloat(strings.TrimSpace(v.text), 64)
+	return points
+}
+
+// rawNumber renders a numeric (or numeric string) custom field value as text.
+func rawNumber(raw json.RawMessage) string {
+	var n float64
+	if json.Unmarshal(raw, &n) == nil {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return ""
+}
+
+// fetchHistories loads every issue's changelog concurrently, in issue order.
+func fetchHistories(client *jira.Client, issues []jira.Issue) ([]issueHistory, error) {
+	return parallel.Map(len(issues), func(i int) (issueHistory, error) {
+		histories, err := client.ListChangelog(issues[i].Key)
+		if err != nil {
+			return issueHistory{}, err
+		}
+		return newIssueHistory(issues[i], histories), nil
+	})
+}
+
+// round1 rounds to one decimal place.
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+// percent returns part/whole in percent with one decimal, or 0 for an empty whole.
+func percent(part, whole float64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return round1(part / whole * 100)
+}
@@ -0,0 +1,355 @@
+package report
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+const dateLayout = "2006-01-02"
+
+// SprintReport summarizes one sprint in story points. Committed is the scope
+// at sprint start; Added and Removed are issues that joined or left the sprint
+// afterwards. CarryOver is the scope not done when the sprint closed (for an
+// active sprint, the work still open). RemovalsIncomplete is set when the
+// removed issues could not be listed, so Committed and Removed may miss them.
+type SprintReport struct {
+	Sprint         SprintInfo    `json:"sprint"`
+	PointsField    string        `json:"points_field"`
+	Committed      ScopeTotal    `json:"committed"`
+	Added          ScopeTotal    `json:"added"`
+	Removed        ScopeTotal    `json:"removed"`
+	Completed      ScopeTotal    `json:"completed"`
+	CarryOver      ScopeTotal    `json:"carry_over"`
+	CompletionRate float64       `json:"completion_rate"` // completed / committed, in percent
+	Burndown       []BurndownDay `json:"burndown"`
+
+	RemovalsIncomplete bool     `json:"removals_incomplete,omitempty"`
+	Warnings           []string `json:"warnings,omitempty"`
+}
+
+// SprintInfo identifies the sprint a report covers.
+type SprintInfo struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+	Goal  string `json:"goal,omitempty"`
+}
+
+// ScopeTotal counts issues and their story points.
+type ScopeTotal struct {
+	Issues int      `json:"issues"`
+	Points float64  `json:"points"`
+	Keys   []string `json:"keys,omitempty"`
+}
+
+func (s *ScopeTotal) add(key string, points float64, withKey bool) {
+	s.Issues++
+	s.Points = round1(s.Points + points)
+	if withKey {
+		s.Keys = append(s.Keys, key)
+	}
+}
+
+// BurndownDay is the open scope at the end of a sprint day, next to the ideal
+// straight line from the committed points to zero at the planned end.
+type BurndownDay struct {
+	Date      string  `json:"date"`
+	Remaining float64 `json:"remaining"`
+	Ideal     float64 `json:"ideal"`
+}
+
+// Sprint builds the report for a started sprint.
+func (r *Reporter) Sprint(sprintID int) (*SprintReport, error) {
+	sprint, err := r.client.GetSprint(sprintID)
+	if err != nil {
+		return nil, err
+	}
+	return r.sprintReport(sprint)
+}
+
+func (r *Reporter) sprintReport(sprint *jira.Sprint) (*SprintReport, error) {
+	if sprint.StartDate == nil {
+		return nil, fmt.Errorf("sprint %d (%s) has not started", sprint.ID, sprint.Name)
+	}
+	categories, err := r.statusCategories()
+	if err != nil {
+		return nil, fmt.Errorf("loading status categories: %w", err)
+	}
+	fields := []string{"status", "created", r.points.ID}
+	issues, err := r.client.ListSprintIssues(sprint.ID, "", fields)
+	if err != nil {
+		return nil, fmt.Errorf("listing sprint issues: %w", err)
+	}
+	// The removed issues come from an internal API that may be missing or
+	// forbidden (it is not exposed through the OAuth gateway): the report is
+	// then built from the sprint's listed issues alone.
+	removed, removedErr := r.removedIssues(sprint, issues, fields)
+	issues = append(issues, removed...)
+	histories, err := fetchHistories(r.client, issues)
+	if err != nil {
+		return nil, fmt.Errorf("loading changelogs: %w", err)
+	}
+	report := buildSprintReport(*sprint, histories, r.points, categories, r.now())
+	if removedErr != nil {
+		report.RemovalsIncomplete = true
+		report.Warnings = append(report.Warnings, fmt.Sprintf("issues removed from the sprint could not be listed, committed and removed scope may miss them: %v", removedErr))
+	}
+	return report, nil
+}
+
+// removedIssues fetches the issues that left the sprint after it started, which
+// the sprint's issue list no longer includes. Their changelogs still count them
+// in the committed scope and the burndown until they left.
+func (r *Reporter) removedIssues(sprint *jira.Sprint, inSprint []jira.Issue, fields []string) ([]jira.Issue, error) {
+	if sprint.OriginBoardID == 0 {
+		return nil, fmt.Errorf("sprint %d has no board", sprint.ID)
+	}
+	keys, err := r.client.ListSprintRemovedIssueKeys(sprint.OriginBoardID, sprint.ID)
+	if err != nil {
+		return nil, err
+	}
+	keys = slices.DeleteFunc(keys, func(key string) bool {
+		return slices.ContainsFunc(inSprint, func(issue jira.Issue) bool { return issue.Key == key })
+	})
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	return r.client.SearchAll(fmt.Sprintf("key in (%s)", strings.Join(keys, ",")), fields)
+}
+
+// buildSprintReport replays the histories over the sprint. The report is as of
+// the sprint's completion, or now for a sprint that is still running.
+func buildSprintReport(sprint jira.Sprint, histories []issueHistory, points jira.Field, categories map[string]string, now time.Time) *SprintReport {
+	start := *sprint.StartDate
+	plannedEnd := start
+	if sprint.EndDate != nil {
+		plannedEnd = *sprint.EndDate
+	}
+	asOf := plannedEnd
+	switch {
+	case sprint.CompleteDate != nil:
+		asOf = *sprint.CompleteDate
+	case now.Before(asOf) || sprint.State == "active":
+		asOf = now
+	}
+
+	report := &SprintReport{
+		Sprint: SprintInfo{
+			ID:    sprint.ID,
+			Name:  sprint.Name,
+			State: sprint.State,
+			Start: start.Format(time.RFC3339),
+			End:   plannedEnd.Format(time.RFC3339),
+			Goal:  sprint.Goal,
+		},
+		PointsField: points.Name,
+	}
+
+	sprintID := strconv.Itoa(sprint.ID)
+	for i := range histories {
+		h := &histories[i]
+		key := h.issue.Key
+		if h.inSprint(start, sprintID) {
+			report.Committed.add(key, h.pointsAt(start, points), false)
+		}
+
+		added, removed := h.scopeChanges(start, asOf, sprintID)
+		if !added.IsZero() {
+			report.Added.add(key, h.pointsAt(added, points), true)
+		}
+		if !removed.IsZero() {
+			report.Removed.add(key, h.pointsAt(removed.Add(-time.Nanosecond), points), true)
+		}
+
+		if !h.inSprint(asOf, sprintID) {
+			continue
+		}
+		if category(categories, h.statusAt(asOf)) == "done" {
+			report.Completed.add(key, h.pointsAt(asOf, points), false)
+		} else {
+			report.CarryOver.add(key, h.pointsAt(asOf, points), true)
+		}
+	}
+	report.CompletionRate = percent(report.Completed.Points, report.Committed.Points)
+	report.Burndown = burndown(histories, start, plannedEnd, asOf, sprintID, points, categories, report.Committed.Points)
+	return report
+}
+
+// burndown returns the open points at the end of each day from start to asOf.
+func burndown(histories []issueHistory, start, plannedEnd, asOf time.Time, sprintID string, points jira.Field, categories map[string]string, committed float64) []BurndownDay {
+	var days []BurndownDay
+	span := plannedEnd.Sub(start)
+	// Step over calendar days from the start's midnight: stepping from the start
+	// time itself would skip a close day that ends earlier than the sprint began.
+	y, m, d := start.Date()
+	for day := time.Date(y, m, d, 0, 0, 0, 0, start.Location()); !day.After(asOf); day = day.AddDate(0, 0, 1) {
+		t := endOfDay(day)
+		if t.After(asOf) {
+			t = asOf
+		}
+		remaining := 0.0
+		for i := range histories {
+			h := &histories[i]
+			if h.inSprint(t, sprintID) && category(categories, h.statusAt(t)) != "done" {
+				remaining += h.pointsAt(t, points)
+			}
+		}
+		ideal := 0.0
+		if span > 0 && t.Before(plannedEnd) {
+			ideal = committed * (1 - float64(t.Sub(start))/float64(span))
+		}
+		days = append(days, BurndownDay{Date: day.Format(dateLayout), Remaining: round1(remaining), Ideal: round1(ideal)})
+	}
+	return days
+}
+
+func endOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, t.Location()).Add(-time.Nanosecond)
+}
+
+func isSprintItem(item jira.ChangelogItem) bool {
+	return strings.EqualFold(item.Field, "Sprint")
+}
+
+// inSprint reports whether the issue was in the sprint at t. Issues that never
+// changed sprint have been in it since they were created.
+func (h *issueHistory) inSprint(t time.Time, sprintID string) bool {
+	if !h.exists(t) {
+		return false
+	}
+	v := h.fieldAt(t, isSprintItem, fieldValue{id: sprintID})
+	return containsSprint(v.id, sprintID)
+}
+
+// scopeChanges returns when the issue first joined and last left the sprint
+// after start and up to asOf, zero when it did not.
+func (h *issueHistory) scopeChanges(start, asOf time.Time, sprintID string) (added, removed time.Time) {
+	times := []time.Time{h.created}
+	for _, c := range h.changes {
+		if isSprintItem(c.item) {
+			times = append(times, c.at)
+		}
+	}
+	for _, t := range times {
+		if !t.After(start) || t.After(asOf) {
+			continue
+		}
+		before, after := h.inSprint(t.Add(-time.Nanosecond), sprintID), h.inSprint(t, sprintID)
+		switch {
+		case !before && after && added.IsZero():
+			added = t
+		case before && !after:
+			removed = t
+		}
+	}
+	return added, removed
+}
+
+// containsSprint reports whether a Sprint field value ("12, 13") includes id.
+func containsSprint(value, id string) bool {
+	return slices.ContainsFunc(strings.Split(value, ","), func(s string) bool {
+		return strings.TrimSpace(s) == id
+	})
+}
+
+// VelocityReport compares committed and completed points across closed sprints.
+type VelocityReport struct {
+	Board            int              `json:"board"`
+	PointsField      string           `json:"points_field"`
+	Sprints          []VelocitySprint `json:"sprints"`
+	AverageCommitted float64          `json:"average_committed"`
+	AverageCompleted float64          `json:"average_completed"`
+	Warnings         []string         `json:"warnings,omitempty"`
+}
+
+// VelocitySprint is one closed sprint in a velocity report.
+type VelocitySprint struct {
+	ID             int     `json:"id"`
+	Name           string  `json:"name"`
+	End            string  `json:"end"`
+	Committed      float64 `json:"committed"`
+	Added          float64 `json:"added"`
+	Removed        float64 `json:"removed"`
+	Completed      float64 `json:"completed"`
+	CarryOver      float64 `json:"carry_over"`
+	CompletionRate float64 `json:"completion_rate"`
+
+	RemovalsIncomplete bool `json:"removals_incomplete,omitempty"`
+}
+
+// Velocity reports the last closed sprints of a board, oldest first.
+func (r *Reporter) Velocity(boardID, last int) (*VelocityReport, error) {
+	sprints, err := r.client.ListSprints(boardID, "closed")
+	if err != nil {
+		return nil, err
+	}
+	sprints = slices.DeleteFunc(sprints, func(s jira.Sprint) bool { return s.StartDate == nil })
+	slices.SortStableFunc(sprints, func(a, b jira.Sprint) int { return sprintEnd(a).Compare(sprintEnd(b)) })
+	if last > 0 && len(sprints) > last {
+		sprints = sprints[len(sprints)-last:]
+	}
+
+	report := &VelocityReport{Board: boardID, PointsField: r.points.Name, Sprints: []VelocitySprint{}}
+	for _, sprint := range sprints {
+		if sprint.OriginBoardID == 0 {
+			sprint.OriginBoardID = boardID
+		}
+		sr, err := r.sprintReport(&sprint)
+		if err != nil {
+			return nil, fmt.Errorf("sprint %d: %w", sprint.ID, err)
+		}
+		report.Sprints = append(report.Sprints, velocitySprint(sr, sprintEnd(sprint)))
+		for _, w := range sr.Warnings {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s: %s", sprint.Name, w))
+		}
+	}
+	report.AverageCommitted, report.AverageCompleted = averages(report.Sprints)
+	return report, nil
+}
+
+func velocitySprint(sr *SprintReport, end time.Time) VelocitySprint {
+	return VelocitySprint{
+		ID:             sr.Sprint.ID,
+		Name:           sr.Sprint.Name,
+		End:            end.Format(dateLayout),
+		Committed:      sr.Committed.Points,
+		Added:          sr.Added.Points,
+		Removed:        sr.Removed.Points,
+		Completed:      sr.Completed.Points,
+		CarryOver:      sr.CarryOver.Points,
+		CompletionRate: sr.CompletionRate,
+
+		RemovalsIncomplete: sr.RemovalsIncomplete,
+	}
+}
+
+func averages(sprints []VelocitySprint) (committed, completed float64) {
+	if len(sprints) == 0 {
+		return 0, 0
+	}
+	for _, s := range sprints {
+		committed += s.Committed
+		completed += s.Completed
+	}
+	n := float64(len(sprints))
+	return round1(committed / n), round1(completed / n)
+}
+
+// sprintEnd is when a sprint closed, or its planned end.
+func sprintEnd(s jira.Sprint) time.Time {
+	switch {
+	case s.CompleteDate != nil:
+		return *s.CompleteDate
+	case s.EndDate != nil:
+		return *s.EndDate
+	}
+	return time.Time{}
+}
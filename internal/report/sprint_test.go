@@ -0,0 +1,297 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+var (
+	testPoints     = jira.Field{ID: "customfield_10016", Name: "Story Points"}
+	testCategories = map[string]string{"1": "new", "3": "indeterminate", "10001": "done"}
+)
+
+func ts(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func testIssue(key, created, statusID string, points float64) jira.Issue {
+	raw, _ := json.Marshal(points)
+	return jira.Issue{Key: key, Fields: jira.IssueFields{
+		Created:      ts(created).Format(jiraTimeLayout),
+		Status:       &jira.Status{ID: statusID},
+		CustomFields: map[string]json.RawMessage{testPoints.ID: raw},
+	}}
+}
+
+func testHistory(at string, items ...jira.ChangelogItem) jira.ChangelogHistory {
+	return jira.ChangelogHistory{Created: ts(at).Format(jiraTimeLayout), Items: items}
+}
+
+func sprintChange(from, to string) jira.ChangelogItem {
+	return jira.ChangelogItem{Field: "Sprint", From: from, To: to}
+}
+
+func statusChange(from, to string) jira.ChangelogItem {
+	return jira.ChangelogItem{Field: "status", From: from, To: to}
+}
+
+func pointsChange(from, to string) jira.ChangelogItem {
+	return jira.ChangelogItem{Field: "Story Points", FieldID: testPoints.ID, FromString: from, ToString: to}
+}
+
+func testSprint() jira.Sprint {
+	start, end, complete := ts("2026-03-02T09:00:00Z"), ts("2026-03-06T17:00:00Z"), ts("2026-03-06T16:00:00Z")
+	return jira.Sprint{ID: 7, Name: "Sprint 7", State: "closed", StartDate: &start, EndDate: &end, CompleteDate: &complete}
+}
+
+func testHistories() []issueHistory {
+	return []issueHistory{
+		// Committed, done on day 2.
+		newIssueHistory(testIssue("P-1", "2026-02-20T10:00:00Z", "10001", 5), []jira.ChangelogHistory{
+			testHistory("2026-02-27T10:00:00Z", sprintChange("", "7")),
+			testHistory("2026-03-03T12:00:00Z", statusChange("1", "10001")),
+		}),
+		// Committed at 3 points, re-estimated to 8, still open: carry-over into sprint 8.
+		newIssueHistory(testIssue("P-2", "2026-02-20T10:00:00Z", "3", 8), []jira.ChangelogHistory{
+			testHistory("2026-02-27T10:00:00Z", sprintChange("", "7")),
+			testHistory("2026-03-04T10:00:00Z", pointsChange("3", "8"), statusChange("1", "3")),
+			testHistory("2026-03-06T16:05:00Z", sprintChange("7", "7, 8")),
+		}),
+		// Created directly in the sprint on day 3 and done on day 4.
+		newIssueHistory(testIssue("P-3", "2026-03-04T11:00:00Z", "10001", 2), []jira.ChangelogHistory{
+			testHistory("2026-03-05T15:00:00Z", statusChange("1", "10001")),
+		}),
+		// Committed, removed on day 2.
+		newIssueHistory(testIssue("P-4", "2026-02-20T10:00:00Z", "1", 3), []jira.ChangelogHistory{
+			testHistory("2026-02-27T10:00:00Z", sprintChange("", "7")),
+			testHistory("2026-03-03T09:30:00Z", sprintChange("7", "")),
+		}),
+	}
+}
+
+func TestBuildSprintReport(t *testing.T) {
+	r := buildSprintReport(testSprint(), testHistories(), testPoints, testCategories, ts("2026-04-01T00:00:00Z"))
+
+	if r.Committed.Issues != 3 || r.Committed.Points != 11 {
+		t.Errorf("committed = %+v, want 3 issues / 11 points", r.Committed)
+	}
+	if r.Added.Points != 2 || !slices.Equal(r.Added.Keys, []string{"P-3"}) {
+		t.Errorf("added = %+v", r.Added)
+	}
+	if r.Removed.Points != 3 || !slices.Equal(r.Removed.Keys, []string{"P-4"}) {
+		t.Errorf("removed = %+v", r.Removed)
+	}
+	if r.Completed.Issues != 2 || r.Completed.Points != 7 {
+		t.Errorf("completed = %+v, want 2 issues / 7 points", r.Completed)
+	}
+	if r.CarryOver.Points != 8 || !slices.Equal(r.CarryOver.Keys, []string{"P-2"}) {
+		t.Errorf("carry-over = %+v", r.CarryOver)
+	}
+	if r.CompletionRate != 63.6 {
+		t.Errorf("completion rate = %v, want 63.6", r.CompletionRate)
+	}
+}
+
+func TestBuildSprintReport_Burndown(t *testing.T) {
+	r := buildSprintReport(testSprint(), testHistories(), testPoints, testCategories, ts("2026-04-01T00:00:00Z"))
+
+	want := []struct {
+		date      string
+		remaining float64
+	}{
+		{"2026-03-02", 11}, // P-1 5 + P-2 3 + P-4 3
+		{"2026-03-03", 3},  // P-1 done, P-4 removed
+		{"2026-03-04", 10}, // P-2 re-estimated to 8, P-3 added
+		{"2026-03-05", 8},  // P-3 done
+		{"2026-03-06", 8},
+	}
+	if len(r.Burndown) != len(want) {
+		t.Fatalf("burndown = %+v", r.Burndown)
+	}
+	for i, w := range want {
+		if r.Burndown[i].Date != w.date || r.Burndown[i].Remaining != w.remaining {
+			t.Errorf("day %d = %+v, want %s remaining %v", i, r.Burndown[i], w.date, w.remaining)
+		}
+	}
+	if r.Burndown[0].Ideal >= 11 || r.Burndown[0].Ideal <= 0 || r.Burndown[4].Ideal > 0.5 {
+		t.Errorf("ideal line = %+v", r.Burndown)
+	}
+}
+
+func TestBuildSprintReport_BurndownIncludesEarlyCloseDay(t *testing.T) {
+	// Started Monday 09:00, closed Friday 08:30: Friday still gets its point.
+	sprint := testSprint()
+	complete := ts("2026-03-06T08:30:00Z")
+	sprint.CompleteDate = &complete
+
+	r := buildSprintReport(sprint, testHistories(), testPoints, testCategories, ts("2026-04-01T00:00:00Z"))
+	if len(r.Burndown) != 5 {
+		t.Fatalf("burndown = %+v, want 5 days", r.Burndown)
+	}
+	if last := r.Burndown[4]; last.Date != "2026-03-06" || last.Remaining != 8 {
+		t.Errorf("close day = %+v, want 2026-03-06 remaining 8", last)
+	}
+}
+
+func TestBuildSprintReport_ActiveSprintStopsAtNow(t *testing.T) {
+	sprint := testSprint()
+	sprint.State, sprint.CompleteDate = "active", nil
+
+	r := buildSprintReport(sprint, testHistories(), testPoints, testCategories, ts("2026-03-03T18:00:00Z"))
+	if len(r.Burndown) != 2 {
+		t.Fatalf("burndown days = %d, want 2", len(r.Burndown))
+	}
+	// P-2 is still open, P-3 does not exist yet.
+	if r.CarryOver.Points != 3 || r.Added.Issues != 0 {
+		t.Errorf("carry-over = %+v, added = %+v", r.CarryOver, r.Added)
+	}
+}
+
+func TestSprint_IncludesRemovedIssues(t *testing.T) {
+	changelog := func(items string) string {
+		return `{"isLast":true,"values":[` + items + `]}`
+	}
+	responses := map[string]string{
+		"/rest/agile/1.0/sprint/7": `{"id":7,"name":"Sprint 7","state":"closed","originBoardId":3,` +
+			`"startDate":"2026-03-02T09:00:00.000Z","endDate":"2026-03-06T17:00:00.000Z","completeDate":"2026-03-06T16:00:00.000Z"}`,
+		"/rest/api/3/status": `[{"id":"1","name":"To Do","statusCategory":{"key":"new"}},{"id":"10001","name":"Done","statusCategory":{"key":"done"}}]`,
+		"/rest/agile/1.0/sprint/7/issue": `{"total":1,"issues":[{"key":"P-1","fields":{"status":{"id":"10001"},` +
+			`"created":"2026-02-20T10:00:00.000+0000","customfield_10016":5}}]}`,
+		"/rest/greenhopper/1.0/rapid/charts/sprintreport": `{"contents":{"puntedIssues":[{"id":4,"key":"P-4"}]}}`,
+		"/rest/api/3/search/jql": `{"isLast":true,"issues":[{"key":"P-4","fields":{"status":{"id":"1"},` +
+			`"created":"2026-02-20T10:00:00.000+0000","customfield_10016":3}}]}`,
+		"/rest/api/3/issue/P-1/changelog": changelog(`{"created":"2026-02-27T10:00:00.000+0000","items":[{"field":"Sprint","from":"","to":"7"}]},` +
+			`{"created":"2026-03-03T12:00:00.000+0000","items":[{"field":"status","from":"1","to":"10001"}]}`),
+		"/rest/api/3/issue/P-4/changelog": changelog(`{"created":"2026-02-27T10:00:00.000+0000","items":[{"field":"Sprint","from":"","to":"7"}]},` +
+			`{"created":"2026-03-03T09:30:00.000+0000","items":[{"field":"Sprint","from":"7","to":""}]}`),
+	}
+	var searched string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/greenhopper/1.0/rapid/charts/sprintreport" && r.URL.Query().Get("rapidViewId") != "3" {
+			t.Errorf("sprint report query = %s", r.URL.RawQuery)
+		}
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			searched = string(body)
+		}
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(resp))
+	}))
+	defer srv.Close()
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: jira.InstanceCloud})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	r, err := New(client, testPoints).Sprint(7)
+	if err != nil {
+		t.Fatalf("Sprint: %v", err)
+	}
+	if !strings.Contains(searched, "key in (P-4)") {
+		t.Errorf("search = %s", searched)
+	}
+	if r.Committed.Issues != 2 || r.Committed.Points != 8 {
+		t.Errorf("committed = %+v, want 2 issues / 8 points", r.Committed)
+	}
+	if r.Removed.Points != 3 || !slices.Equal(r.Removed.Keys, []string{"P-4"}) {
+		t.Errorf("removed = %+v", r.Removed)
+	}
+	if len(r.Burndown) == 0 || r.Burndown[0].Remaining != 8 {
+		t.Errorf("burndown = %+v", r.Burndown)
+	}
+}
+
+func TestSprint_RemovedIssuesUnavailable(t *testing.T) {
+	responses := map[string]string{
+		"/rest/agile/1.0/sprint/7": `{"id":7,"name":"Sprint 7","state":"closed","originBoardId":3,` +
+			`"startDate":"2026-03-02T09:00:00.000Z","endDate":"2026-03-06T17:00:00.000Z","completeDate":"2026-03-06T16:00:00.000Z"}`,
+		"/rest/api/3/status": `[{"id":"1","name":"To Do","statusCategory":{"key":"new"}},{"id":"10001","name":"Done","statusCategory":{"key":"done"}}]`,
+		"/rest/agile/1.0/sprint/7/issue": `{"total":1,"issues":[{"key":"P-1","fields":{"status":{"id":"10001"},` +
+			`"created":"2026-02-20T10:00:00.000+0000","customfield_10016":5}}]}`,
+		"/rest/api/3/issue/P-1/changelog": `{"isLast":true,"values":[{"created":"2026-02-27T10:00:00.000+0000","items":[{"field":"Sprint","from":"","to":"7"}]},` +
+			`{"created":"2026-03-03T12:00:00.000+0000","items":[{"field":"status","from":"1","to":"10001"}]}]}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/greenhopper/1.0/rapid/charts/sprintreport" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errorMessages":["forbidden"]}`))
+			return
+		}
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(resp))
+	}))
+	defer srv.Close()
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: jira.InstanceCloud})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	r, err := New(client, testPoints).Sprint(7)
+	if err != nil {
+		t.Fatalf("Sprint: %v", err)
+	}
+	if !r.RemovalsIncomplete || len(r.Warnings) != 1 {
+		t.Errorf("removals incomplete = %v, warnings = %v", r.RemovalsIncomplete, r.Warnings)
+	}
+	if r.Committed.Points != 5 || r.Completed.Points != 5 || r.Removed.Issues != 0 {
+		t.Errorf("committed = %+v, completed = %+v, removed = %+v", r.Committed, r.Completed, r.Removed)
+	}
+}
+
+func TestPointsField(t *testing.T) {
+	catalog := []jira.Field{
+		{ID: "customfield_10016", Name: "Story point estimate"},
+		{ID: "customfield_10028", Name: "Story Points"},
+	}
+
+	cases := []struct {
+		name    string
+		aliases map[string]string
+		ref     string
+		want    string
+	}{
+		{"default name", nil, "", "customfield_10028"},
+		{"alias", map[string]string{"sp": "customfield_10016"}, "", "customfield_10016"},
+		{"explicit name", nil, "story point estimate", "customfield_10016"},
+		{"explicit alias", map[string]string{"est": "customfield_10016"}, "est", "customfield_10016"},
+	}
+	for _, c := range cases {
+		field, err := PointsField(catalog, c.aliases, c.ref)
+		if err != nil || field.ID != c.want {
+			t.Errorf("%s: got %q (%v), want %q", c.name, field.ID, err, c.want)
+		}
+	}
+
+	if _, err := PointsField(catalog, nil, "Velocity"); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestAverages(t *testing.T) {
+	committed, completed := averages([]VelocitySprint{{Committed: 10, Completed: 8}, {Committed: 12, Completed: 9}})
+	if committed != 11 || completed != 8.5 {
+		t.Errorf("averages = %v, %v", committed, completed)
+	}
+}
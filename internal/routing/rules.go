@@ -0,0 +1,170 @@
+// Package routing suggests components for new issues from a rule file that
+// maps file paths and keywords to components:
+//
+//	rules:
+//	  - component: Backend
+//	    paths: ["internal/api/**", "*.proto"]
+//	    keywords: [endpoint, "rate limit"]
+//	  - component: Mobile
+//	    paths: ["ios/**", "android/**"]
+//
+// Paths are globs matched against path-like words in the issue text; "**"
+// spans directories and a pattern without "/" matches the base name.
+// Keywords match whole words in any script, case-insensitively.
+package routing
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rule maps paths and keywords to a component.
+type Rule struct {
+	Component string   `yaml:"component"`
+	Paths     []string `yaml:"paths,omitempty"`
+	Keywords  []string `yaml:"keywords,omitempty"`
+}
+
+// Rules is a parsed rule file.
+type Rules struct {
+	Rules []Rule `yaml:"rules"`
+
+	paths    [][]*regexp.Regexp
+	keywords [][]*regexp.Regexp
+}
+
+// Suggestion is a component whose rule matched, with what matched it.
+type Suggestion struct {
+	Component string   `json:"component"`
+	Matches   []string `json:"matches"`
+}
+
+// Load reads and compiles a rule file.
+func Load(file string) (*Rules, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading component rules: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse compiles rules from YAML.
+func Parse(data []byte) (*Rules, error) {
+	var r Rules
+	if err := yaml.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parsing component rules: %w", err)
+	}
+	for i, rule := range r.Rules {
+		if strings.TrimSpace(rule.Component) == "" {
+			return nil, fmt.Errorf("component rule %d: component is required", i+1)
+		}
+		var paths, keywords []*regexp.Regexp
+		for _, p := range rule.Paths {
+			paths = append(paths, globRegexp(p))
+		}
+		for _, k := range rule.Keywords {
+			if k = strings.TrimSpace(k); k != "" {
+				keywords = append(keywords, regexp.MustCompile(`(?i)(?:^|[^\pL\pN_])`+regexp.QuoteMeta(k)+`(?:$|[^\pL\pN_])`))
+			}
+		}
+		r.paths = append(r.paths, paths)
+		r.keywords = append(r.keywords, keywords)
+	}
+	return &r, nil
+}
+
+// Suggest returns the components whose rules match text, most matches first
+// and then in rule order. A component named by several rules is suggested once.
+func (r *Rules) Suggest(text string) []Suggestion {
+	words := pathWords(text)
+	var suggestions []Suggestion
+	index := map[string]int{}
+	for i, rule := range r.Rules {
+		var matches []string
+		for j, re := range r.paths[i] {
+			for _, w := range words {
+				if re.MatchString(w) || (!strings.Contains(rule.Paths[j], "/") && re.MatchString(path.Base(w))) {
+					matches = append(matches, w)
+				}
+			}
+		}
+		for j, re := range r.keywords[i] {
+			if re.MatchString(text) {
+				matches = append(matches, rule.Keywords[j])
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+
+		n, ok := index[strings.ToLower(rule.Component)]
+		if !ok {
+			n = len(suggestions)
+			index[strings.ToLower(rule.Component)] = n
+			suggestions = append(suggestions, Suggestion{Component: rule.Component})
+		}
+		for _, m := range matches {
+			if !slices.Contains(suggestions[n].Matches, m) {
+				suggestions[n].Matches = append(suggestions[n].Matches, m)
+			}
+		}
+	}
+	slices.SortStableFunc(suggestions, func(a, b Suggestion) int {
+		return len(b.Matches) - len(a.Matches)
+	})
+	return suggestions
+}
+
+// pathWords returns the words of text that look like file paths: they contain
+// a "/" or end in a file extension.
+func pathWords(text string) []string {
+	var words []string
+	for _, w := range strings.Fields(text) {
+		w = strings.Trim(w, "`'\"()[]{}<>,;:!?")
+		w = strings.TrimSuffix(strings.TrimPrefix(w, "./"), ".")
+		if w == "" || strings.Contains(w, "://") {
+			continue
+		}
+		if strings.Contains(w, "/") || extensionRe.MatchString(w) {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+var extensionRe = regexp.MustCompile(`^[\w.-]+\.[A-Za-z][A-Za-z0-9]{0,5}$`)
+
+// globRegexp compiles a path glob: "**" matches across directories, "*" and
+// "?" within one.
+func globRegexp(glob string) *regexp.Regexp {
+	glob = strings.TrimPrefix(strings.TrimSpace(glob), "./")
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	if strings.HasSuffix(glob, "/") {
+		b.WriteString(".*")
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
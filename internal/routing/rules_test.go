@@ -0,0 +1,56 @@
+package routing
+
+import "testing"
+
+const testRules = `
+rules:
+  - component: Backend
+    paths: ["internal/api/**", "*.proto"]
+    keywords: [endpoint, "rate limit"]
+  - component: Mobile
+    paths: ["ios/**"]
+    keywords: [iOS, "экран входа"]
+  - component: backend
+    keywords: [database]
+`
+
+func TestSuggest(t *testing.T) {
+	rules, err := Parse([]byte(testRules))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	got := rules.Suggest("Crash in `ios/Login/View.swift` on iOS 18; the login endpoint in ./internal/api/auth/login.go returns 500 from the database.")
+	if len(got) != 2 {
+		t.Fatalf("suggestions = %+v", got)
+	}
+	if got[0].Component != "Backend" || len(got[0].Matches) != 3 {
+		t.Errorf("first = %+v, want Backend with 3 matches", got[0])
+	}
+	if got[1].Component != "Mobile" || len(got[1].Matches) != 2 {
+		t.Errorf("second = %+v, want Mobile with 2 matches", got[1])
+	}
+}
+
+func TestSuggest_BaseNameGlobAndUnicodeKeywords(t *testing.T) {
+	rules, err := Parse([]byte(testRules))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if got := rules.Suggest("Update proto/v1/user.proto"); len(got) != 1 || got[0].Component != "Backend" {
+		t.Errorf("proto suggestions = %+v", got)
+	}
+	if got := rules.Suggest("Поправить экран входа"); len(got) != 1 || got[0].Component != "Mobile" {
+		t.Errorf("keyword suggestions = %+v", got)
+	}
+	if got := rules.Suggest("Endpoints overview; internal/apiary/x.go"); len(got) != 0 {
+		t.Errorf("partial words matched: %+v", got)
+	}
+}
+
+func TestParse_RequiresComponent(t *testing.T) {
+	if _, err := Parse([]byte("rules:\n  - keywords: [x]\n")); err == nil {
+		t.Error("expected an error for a rule without a component")
+	}
+}
@@ -6,6 +6,7 @@ package search
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"regexp"
 	"strings"
 
@@ -27,8 +28,15 @@ type GrepOptions struct {
 	ContextLines    int
 }
 
-// GrepIssues searches across a slice of issues for lines matching pattern.
-func GrepIssues(issues []jira.Issue, pattern string, opts GrepOptions) ([]Match, error) {
+// Matcher applies a compiled grep pattern to issues and comments one at a time,
+// so callers can stream results without holding the whole project in memory.
+type Matcher struct {
+	re    *regexp.Regexp
+	scope string
+}
+
+// NewMatcher compiles pattern with the given options.
+func NewMatcher(pattern string, opts GrepOptions) (*Matcher, error) {
 	if opts.CaseInsensitive {
 		pattern = "(?i)" + pattern
 	}
@@ -42,70 +50,59 @@ func GrepIssues(issues []jira.Issue, pattern string, opts GrepOptions) ([]Match,
 	if scope == "" {
 		scope = "all"
 	}
+	return &Matcher{re: re, scope: scope}, nil
+}
+
+// Issue returns the matches in an issue's summary, description and labels.
+func (m *Matcher) Issue(issue *jira.Issue) []Match {
+	if m.scope != "issues" && m.scope != "all" {
+		return nil
+	}
 
 	var results []Match
 
-	for i := range issues {
-		issue := &issues[i]
+	// Search summary
+	if m.re.MatchString(issue.Fields.Summary) {
+		results = append(results, Match{
+			IssueKey: issue.Key,
+			Field:    "summary",
+			Content:  issue.Fields.Summary,
+			Line:     1,
+		})
+	}
 
-		if scope == "issues" || scope == "all" {
-			// Search summary
-			if re.MatchString(issue.Fields.Summary) {
+	// Search description (handles both ADF and plain string)
+	descText := issue.Fields.DescriptionText()
+	if descText != "" {
+		lines := strings.Split(descText, "\n")
+		for lineNum, line := range lines {
+			if m.re.MatchString(line) {
 				results = append(results, Match{
 					IssueKey: issue.Key,
-					Field:    "summary",
-					Content:  issue.Fields.Summary,
-					Line:     1,
+					Field:    "description",
+					Content:  line,
+					Line:     lineNum + 1,
 				})
 			}
-
-			// Search description (handles both ADF and plain string)
-			descText := issue.Fields.DescriptionText()
-			if descText != "" {
-				lines := strings.Split(descText, "\n")
-				for lineNum, line := range lines {
-					if re.MatchString(line) {
-						results = append(results, Match{
-							IssueKey: issue.Key,
-							Field:    "description",
-							Content:  line,
-							Line:     lineNum + 1,
-						})
-					}
-				}
-			}
-
-			// Search labels
-			for _, label := range issue.Fields.Labels {
-				if re.MatchString(label) {
-					results = append(results, Match{
-						IssueKey: issue.Key,
-						Field:    "labels",
-						Content:  label,
-						Line:     1,
-					})
-				}
-			}
 		}
 	}
 
-	if results == nil {
-		results = []Match{}
+	// Search labels
+	for _, label := range issue.Fields.Labels {
+		if m.re.MatchString(label) {
+			results = append(results, Match{
+				IssueKey: issue.Key,
+				Field:    "labels",
+				Content:  label,
+				Line:     1,
+			})
+		}
 	}
-	return results, nil
+	return results
 }
 
-// GrepComments searches across issue comments for lines matching pattern.
-func GrepComments(comments []jira.Comment, issueKey, pattern string, opts GrepOptions) ([]Match, error) {
-	if opts.CaseInsensitive {
-		pattern = "(?i)" + pattern
-	}
-
-	re, err := regexp.Compile(pattern)
-	if err != nil {
-		return nil, fmt.Errorf("invalid regex: %w", err)
-	}
-
+// Comments returns the matches in an issue's comments.
+func (m *Matcher) Comments(comments []jira.Comment, issueKey string) []Match {
 	var results []Match
 
 	for _, comment := range comments {
@@ -115,7 +112,7 @@ func GrepComments(comments []jira.Comment, issueKey, pattern string, opts GrepOp
 		text := extractADFText(comment.Body)
 		lines := strings.Split(text, "\n")
 		for lineNum, line := range lines {
-			if re.MatchString(line) {
+			if m.re.MatchString(line) {
 				results = append(results, Match{
 					IssueKey: issueKey,
 					Field:    fmt.Sprintf("comment/%s", comment.ID),
@@ -125,7 +122,31 @@ func GrepComments(comments []jira.Comment, issueKey, pattern string, opts GrepOp
 			}
 		}
 	}
+	return results
+}
+
+// GrepIssues searches across a slice of issues for lines matching pattern.
+func GrepIssues(issues []jira.Issue, pattern string, opts GrepOptions) ([]Match, error) {
+	m, err := NewMatcher(pattern, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []Match{}
+	for i := range issues {
+		results = append(results, m.Issue(&issues[i])...)
+	}
+	return results, nil
+}
 
+// GrepComments searches across issue comments for lines matching pattern.
+func GrepComments(comments []jira.Comment, issueKey, pattern string, opts GrepOptions) ([]Match, error) {
+	m, err := NewMatcher(pattern, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	results := m.Comments(comments, issueKey)
 	if results == nil {
 		results = []Match{}
 	}
@@ -163,6 +184,17 @@ func PrintJSON(matches []Match) ([]byte, error) {
 	return json.MarshalIndent(matches, "", "  ")
 }
 
+// WriteNDJSON writes matches as newline-delimited JSON, one match per line.
+func WriteNDJSON(w io.Writer, matches []Match) error {
+	enc := json.NewEncoder(w)
+	for _, m := range matches {
+		if err := enc.Encode(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // PrintText outputs matches in grep-style format: issue_key:field:line:content
 func PrintText(matches []Match) string {
 	var sb strings.Builder
@@ -1,6 +1,7 @@
 package search
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/relux-works/skill-jira-management/internal/jira"
@@ -197,6 +198,34 @@ func TestPrintJSON(t *testing.T) {
 	}
 }
 
+func TestWriteNDJSON(t *testing.T) {
+	matches := []Match{
+		{IssueKey: "A-1", Field: "summary", Line: 1, Content: "Fix auth"},
+		{IssueKey: "A-2", Field: "labels", Line: 1, Content: "auth"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteNDJSON(&buf, matches); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"issue_key":"A-1","field":"summary","content":"Fix auth","line":1}` + "\n" +
+		`{"issue_key":"A-2","field":"labels","content":"auth","line":1}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected NDJSON output:\n  got:    %q\n  expect: %q", buf.String(), expected)
+	}
+}
+
+func TestMatcher_ScopeComments_SkipsIssueFields(t *testing.T) {
+	m, err := NewMatcher("auth", GrepOptions{Scope: "comments"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	issue := jira.Issue{Key: "A-1", Fields: jira.IssueFields{Summary: "Fix auth"}}
+	if matches := m.Issue(&issue); len(matches) != 0 {
+		t.Errorf("expected no issue matches for comments scope, got %d", len(matches))
+	}
+}
+
 func TestExtractADFText(t *testing.T) {
 	doc := &jira.ADFDoc{
 		Type:    "doc",
@@ -0,0 +1,350 @@
+// Package taskboard reads and writes the local task board: a directory tree of
+// EPIC, STORY and TASK elements, each a <ID>_<slug> directory holding a
+// README.md (title, description, scope, acceptance criteria) and a progress.md
+// (status, assignee, dates, dependencies, checklist and notes). The board is
+// located through task-board.config.json. Syncer mirrors Jira issues into the
+// board and pushes local status and note changes back.
+package taskboard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConfigFile is the name of the task board configuration file.
+const ConfigFile = "task-board.config.json"
+
+// stateFile records, inside the board directory, which elements mirror which
+// issues and what both sides looked like at the last sync.
+const stateFile = ".jira-sync.json"
+
+// Config is the content of task-board.config.json.
+type Config struct {
+	Mode  string `json:"mode"`
+	Local struct {
+		BoardDir string `json:"board_dir"`
+	} `json:"local"`
+}
+
+// Board is a local task board directory and its sync state.
+type Board struct {
+	Dir   string
+	state State
+}
+
+// State maps issue keys to the elements mirroring them.
+type State struct {
+	Issues map[string]*Record `json:"issues"`
+}
+
+// Record is the last synced state of one mirrored issue.
+type Record struct {
+	Dir     string `json:"dir"`     // element directory, relative to the board
+	Updated string `json:"updated"` // the issue's updated field at the last sync
+	Status  string `json:"status"`  // the element's status at the last sync
+	Notes   string `json:"notes"`   // the element's notes at the last sync
+}
+
+// FindConfig looks for task-board.config.json in dir and its parents.
+func FindConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, ConfigFile)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no %s found in %s or its parents", ConfigFile, dir)
+		}
+		dir = parent
+	}
+}
+
+// LoadConfig reads a task board configuration file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Open finds the board configured for dir and loads its sync state. Only local
+// boards can be opened; the board directory is relative to the config file.
+func Open(dir string) (*Board, error) {
+	path, err := FindConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Mode != "local" {
+		return nil, fmt.Errorf("%s: mode %q is not supported, only local boards can be synced", path, cfg.Mode)
+	}
+	if cfg.Local.BoardDir == "" {
+		return nil, fmt.Errorf("%s: local.board_dir is not set", path)
+	}
+	boardDir := cfg.Local.BoardDir
+	if !filepath.IsAbs(boardDir) {
+		boardDir = filepath.Join(filepath.Dir(path), boardDir)
+	}
+	return OpenDir(boardDir)
+}
+
+// OpenDir loads the board in dir without a configuration file.
+func OpenDir(dir string) (*Board, error) {
+	b := &Board{Dir: dir, state: State{Issues: map[string]*Record{}}}
+	data, err := os.ReadFile(filepath.Join(dir, stateFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &b.state); err != nil {
+		return nil, fmt.Errorf("%s: %w", stateFile, err)
+	}
+	if b.state.Issues == nil {
+		b.state.Issues = map[string]*Record{}
+	}
+	return b, nil
+}
+
+// Record returns the sync record of an issue, or nil if it is not mirrored.
+func (b *Board) Record(key string) *Record {
+	return b.state.Issues[key]
+}
+
+// SetRecord stores the sync record of an issue; SaveState persists it.
+func (b *Board) SetRecord(key string, r *Record) {
+	b.state.Issues[key] = r
+}
+
+// SaveState writes the sync state into the board directory.
+func (b *Board) SaveState() error {
+	data, err := json.MarshalIndent(b.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(b.Dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(b.Dir, stateFile), append(data, '\n'), 0o644)
+}
+
+// Element is one board item. Sections the sync does not manage (scope,
+// acceptance criteria, checklist, resources) are kept as they are.
+type Element struct {
+	ID          string // e.g. STORY-PROJ-12
+	Type        string // EPIC, STORY or TASK
+	Dir         string // relative to the board directory
+	Title       string
+	Description string
+	Status      string
+	AssignedTo  string // empty when unassigned
+	Created     string
+	LastUpdate  string
+	BlockedBy   []string
+	Blocks      []string
+	Notes       string
+
+	readme   *document
+	progress *document
+}
+
+// NewElement returns an element of the given type with the sections of a
+// freshly created board item, to be saved in dir.
+func NewElement(typ, id, dir string) *Element {
+	scope := fmt.Sprintf("(define %s scope)", strings.ToLower(typ))
+	return &Element{
+		ID:   id,
+		Type: typ,
+		Dir:  dir,
+		readme: &document{sections: []section{
+			{name: "Description"},
+			{name: "Scope", body: scope},
+			{name: "Acceptance Criteria", body: "(define acceptance criteria)"},
+		}},
+		progress: &document{sections: []section{
+			{name: "Status"}, {name: "Assigned To"}, {name: "Created"}, {name: "Last Update"},
+			{name: "Blocked By"}, {name: "Blocks"}, {name: "Checklist", body: "(empty)"}, {name: "Notes"},
+		}},
+	}
+}
+
+// LoadElement reads the element in dir, relative to the board directory.
+func (b *Board) LoadElement(dir string) (*Element, error) {
+	readme, err := os.ReadFile(filepath.Join(b.Dir, dir, "README.md"))
+	if err != nil {
+		return nil, err
+	}
+	progress, err := os.ReadFile(filepath.Join(b.Dir, dir, "progress.md"))
+	if err != nil {
+		return nil, err
+	}
+	id, _, _ := strings.Cut(filepath.Base(dir), "_")
+	typ, _, _ := strings.Cut(id, "-")
+	e := &Element{
+		ID:       id,
+		Type:     typ,
+		Dir:      dir,
+		readme:   parseDocument(string(readme)),
+		progress: parseDocument(string(progress)),
+	}
+	_, e.Title, _ = strings.Cut(strings.TrimPrefix(e.readme.head, "# "), ": ")
+	e.Description = e.readme.get("Description")
+	e.Status = e.progress.get("Status")
+	if e.AssignedTo = e.progress.get("Assigned To"); e.AssignedTo == none {
+		e.AssignedTo = ""
+	}
+	e.Created = e.progress.get("Created")
+	e.LastUpdate = e.progress.get("Last Update")
+	e.BlockedBy = parseList(e.progress.get("Blocked By"))
+	e.Blocks = parseList(e.progress.get("Blocks"))
+	e.Notes = e.progress.get("Notes")
+	return e, nil
+}
+
+// SaveElement writes the element's README.md and progress.md.
+func (b *Board) SaveElement(e *Element) error {
+	e.readme.head = fmt.Sprintf("# %s: %s", e.ID, e.Title)
+	e.readme.set("Description", e.Description)
+	e.progress.set("Status", e.Status)
+	e.progress.set("Assigned To", orNone(e.AssignedTo))
+	e.progress.set("Created", e.Created)
+	e.progress.set("Last Update", e.LastUpdate)
+	e.progress.set("Blocked By", formatList(e.BlockedBy))
+	e.progress.set("Blocks", formatList(e.Blocks))
+	e.progress.set("Notes", e.Notes)
+
+	dir := filepath.Join(b.Dir, e.Dir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte(e.readme.String()), 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "progress.md"), []byte(e.progress.String()), 0o644)
+}
+
+const none = "(none)"
+
+func orNone(s string) string {
+	if s == "" {
+		return none
+	}
+	return s
+}
+
+// parseList reads a "- ID" list; "- (none)" is the empty list.
+func parseList(body string) []string {
+	var items []string
+	for _, line := range strings.Split(body, "\n") {
+		item := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "- "))
+		if item != "" && item != none && item != "-" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func formatList(items []string) string {
+	if len(items) == 0 {
+		return "- " + none
+	}
+	return "- " + strings.Join(items, "\n- ")
+}
+
+// document is a Markdown file split into its "## " sections, so that the
+// sections the sync does not touch are written back unchanged.
+type document struct {
+	head     string // text before the first section, e.g. "# ID: title"
+	sections []section
+}
+
+type section struct {
+	name string
+	body string
+}
+
+func parseDocument(text string) *document {
+	d := &document{}
+	var head, body []string
+	cur := -1
+	flush := func() {
+		if cur >= 0 {
+			d.sections[cur].body = strings.Trim(strings.Join(body, "\n"), "\n")
+		}
+		body = nil
+	}
+	fenced := false
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			fenced = !fenced
+		}
+		if !fenced && strings.HasPrefix(line, "## ") {
+			flush()
+			d.sections = append(d.sections, section{name: strings.TrimSpace(line[3:])})
+			cur = len(d.sections) - 1
+			continue
+		}
+		if cur < 0 {
+			head = append(head, line)
+		} else {
+			body = append(body, line)
+		}
+	}
+	flush()
+	d.head = strings.Trim(strings.Join(head, "\n"), "\n")
+	return d
+}
+
+func (d *document) get(name string) string {
+	for _, s := range d.sections {
+		if s.name == name {
+			return s.body
+		}
+	}
+	return ""
+}
+
+func (d *document) set(name, body string) {
+	body = strings.Trim(body, "\n")
+	for i := range d.sections {
+		if d.sections[i].name == name {
+			d.sections[i].body = body
+			return
+		}
+	}
+	d.sections = append(d.sections, section{name: name, body: body})
+}
+
+func (d *document) String() string {
+	var parts []string
+	if d.head != "" {
+		parts = append(parts, d.head+"\n")
+	}
+	for _, s := range d.sections {
+		if s.body == "" {
+			parts = append(parts, "## "+s.name+"\n")
+		} else {
+			parts = append(parts, "## "+s.name+"\n"+s.body+"\n")
+		}
+	}
+	return strings.Join(parts, "\n")
+}
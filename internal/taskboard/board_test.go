@@ -0,0 +1,110 @@
+package taskboard
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const storyReadme = `# STORY-260212-31lihk: comments
+
+## Description
+Add comment to issue, list comments
+
+## Scope
+(define story scope)
+
+## Acceptance Criteria
+- comments can be listed
+`
+
+const storyProgress = "## Status\ndone\n\n## Assigned To\nagent-lib\n\n## Created\n2026-02-12T11:39:39Z\n\n" +
+	"## Last Update\n2026-02-12T11:59:17Z\n\n## Blocked By\n- (none)\n\n## Blocks\n- STORY-260212-cx2nw0\n\n" +
+	"## Checklist\n(empty)\n\n## Notes\nsee the ADF helpers\n\n## Outcome Resources\n- internal/jira/comments.go\n"
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOpen_FindsConfigInParent(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ConfigFile), `{"mode": "local", "local": {"board_dir": ".task-board"}}`)
+	sub := filepath.Join(root, "cmd", "tool")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := Open(sub)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if b.Dir != filepath.Join(root, ".task-board") {
+		t.Errorf("Dir = %q", b.Dir)
+	}
+}
+
+func TestOpen_RejectsOtherModes(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ConfigFile), `{"mode": "jira"}`)
+	if _, err := Open(root); err == nil || !strings.Contains(err.Error(), "only local boards") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestElement_RoundTrip(t *testing.T) {
+	b, err := OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join("EPIC-260212-1lc5rd_cli", "STORY-260212-31lihk_comments")
+	writeFile(t, filepath.Join(b.Dir, dir, "README.md"), storyReadme)
+	writeFile(t, filepath.Join(b.Dir, dir, "progress.md"), storyProgress)
+
+	e, err := b.LoadElement(dir)
+	if err != nil {
+		t.Fatalf("LoadElement: %v", err)
+	}
+	if e.ID != "STORY-260212-31lihk" || e.Type != "STORY" || e.Title != "comments" || e.Status != "done" ||
+		e.AssignedTo != "agent-lib" || len(e.BlockedBy) != 0 || !slices.Equal(e.Blocks, []string{"STORY-260212-cx2nw0"}) ||
+		e.Notes != "see the ADF helpers" {
+		t.Errorf("element = %+v", e)
+	}
+
+	// Unchanged elements are written back byte for byte.
+	if err := b.SaveElement(e); err != nil {
+		t.Fatalf("SaveElement: %v", err)
+	}
+	for name, want := range map[string]string{"README.md": storyReadme, "progress.md": storyProgress} {
+		got, _ := os.ReadFile(filepath.Join(b.Dir, dir, name))
+		if string(got) != want {
+			t.Errorf("%s =\n%s\nwant\n%s", name, got, want)
+		}
+	}
+
+	e.Status, e.AssignedTo, e.BlockedBy = "in-progress", "", []string{"TASK-PROJ-3"}
+	if err := b.SaveElement(e); err != nil {
+		t.Fatalf("SaveElement: %v", err)
+	}
+	got, _ := os.ReadFile(filepath.Join(b.Dir, dir, "progress.md"))
+	for _, want := range []string{"## Status\nin-progress\n", "## Assigned To\n(none)\n", "## Blocked By\n- TASK-PROJ-3\n", "## Outcome Resources\n"} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("progress.md missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestStatusSlug(t *testing.T) {
+	for in, want := range map[string]string{"In Progress": "in-progress", "Done": "done", " To  Do ": "to-do", "in-progress": "in-progress"} {
+		if got := StatusSlug(in); got != want {
+			t.Errorf("StatusSlug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
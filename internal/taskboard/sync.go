@@ -0,0 +1,373 @@
+package taskboard
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// SyncFields are the issue fields Sync needs.
+var SyncFields = []string{"summary", "description", "status", "assignee", "issuetype", "parent", "issuelinks", "created", "updated"}
+
+// Sync actions.
+const (
+	ActionCreated   = "created"   // a new element mirrors the issue
+	ActionPulled    = "pulled"    // the element was updated from Jira
+	ActionPushed    = "pushed"    // local changes were sent to Jira
+	ActionConflict  = "conflict"  // both sides changed since the last sync
+	ActionUnchanged = "unchanged" // neither side changed
+	ActionFailed    = "failed"
+)
+
+// Conflict resolutions, for elements changed on both sides.
+const (
+	PreferJira  = "jira"  // overwrite local changes with the issue
+	PreferLocal = "local" // push local changes regardless
+)
+
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// Syncer mirrors Jira issues into a board and pushes local status and note
+// changes back. A change is detected against the record of the last sync: the
+// issue changed when its updated field differs, the element changed when its
+// status or notes differ. When both changed, the element is left alone and a
+// conflict is reported unless Prefer says which side wins.
+type Syncer struct {
+	Client *jira.Client
+	Board  *Board
+	Prefer string // "", PreferJira or PreferLocal
+	DryRun bool
+
+	// EpicNameField is the Server/DC Epic Name field ID (jira.EpicNameFieldID),
+	// which tells epics apart whatever the type is called there.
+	EpicNameField string
+}
+
+// Fields returns the issue fields Sync needs: SyncFields plus EpicNameField.
+func (s *Syncer) Fields() []string {
+	if s.EpicNameField == "" {
+		return SyncFields
+	}
+	return append(slices.Clone(SyncFields), s.EpicNameField)
+}
+
+// Result is the outcome of a sync.
+type Result struct {
+	BoardDir  string  `json:"board_dir"`
+	DryRun    bool    `json:"dry_run,omitempty"`
+	Items     []Entry `json:"items"`
+	Created   int     `json:"created"`
+	Pulled    int     `json:"pulled"`
+	Pushed    int     `json:"pushed"`
+	Conflicts int     `json:"conflicts"`
+	Failed    int     `json:"failed"`
+}
+
+// Entry is the outcome for one issue.
+type Entry struct {
+	Key    string `json:"key"`
+	ID     string `json:"id"`
+	Action string `json:"action"`
+	Detail string `json:"detail,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Sync mirrors issues, fetched with Fields, into the board. Epics become
+// EPIC elements, subtasks TASK elements and other issues STORY elements, nested
+// under their mirrored parent. The sync state is saved after every change.
+func (s *Syncer) Sync(issues []jira.Issue) *Result {
+	res := &Result{BoardDir: s.Board.Dir, DryRun: s.DryRun}
+	issues = slices.Clone(issues)
+	slices.SortStableFunc(issues, func(a, b jira.Issue) int { return cmp.Compare(s.level(&a), s.level(&b)) })
+
+	// Element IDs and directories of every issue, so links and children can
+	// refer to elements created later in this run.
+	ids := map[string]string{}
+	dirs := map[string]string{}
+	for key, r := range s.Board.state.Issues {
+		dirs[key] = r.Dir
+		ids[key], _, _ = strings.Cut(filepath.Base(r.Dir), "_")
+	}
+	for i := range issues {
+		if _, ok := ids[issues[i].Key]; !ok {
+			ids[issues[i].Key] = s.elementType(&issues[i]) + "-" + issues[i].Key
+		}
+	}
+
+	for i := range issues {
+		issue := &issues[i]
+		e := s.syncIssue(issue, ids, dirs)
+		switch e.Action {
+		case ActionCreated:
+			res.Created++
+		case ActionPulled:
+			res.Pulled++
+		case ActionPushed:
+			res.Pushed++
+		case ActionConflict:
+			res.Conflicts++
+		case ActionFailed:
+			res.Failed++
+		}
+		res.Items = append(res.Items, e)
+	}
+	return res
+}
+
+func (s *Syncer) syncIssue(issue *jira.Issue, ids, dirs map[string]string) Entry {
+	key := issue.Key
+	entry := Entry{Key: key, ID: ids[key]}
+	fail := func(err error) Entry {
+		entry.Action, entry.Error = ActionFailed, err.Error()
+		return entry
+	}
+
+	rec := s.Board.Record(key)
+	var el *Element
+	if rec != nil {
+		var err error
+		if el, err = s.Board.LoadElement(rec.Dir); err != nil {
+			// The element was removed locally: mirror the issue again.
+			el = nil
+		}
+	}
+	if el == nil {
+		dir := ids[key] + "_" + slug(issue.Fields.Summary)
+		if parent := parentKey(issue); parent != "" && dirs[parent] != "" {
+			dir = filepath.Join(dirs[parent], dir)
+		}
+		dirs[key] = dir
+		el = NewElement(s.elementType(issue), ids[key], dir)
+		pull(el, issue, ids)
+		entry.Action, entry.Detail = ActionCreated, el.Dir
+		if !s.DryRun {
+			if err := s.save(key, el, el.Status, el.Notes, issue.Fields.Updated); err != nil {
+				return fail(err)
+			}
+		}
+		return entry
+	}
+	dirs[key] = el.Dir
+
+	statusChanged := el.Status != rec.Status
+	notesChanged := el.Notes != rec.Notes
+	localChanged := statusChanged || notesChanged
+	jiraChanged := issue.Fields.Updated != rec.Updated
+
+	if localChanged && jiraChanged && s.Prefer == "" {
+		entry.Action = ActionConflict
+		entry.Detail = fmt.Sprintf("changed locally (%s) and in Jira (updated %s) since the last sync; use --prefer jira or --prefer local",
+			describeLocal(rec, el, statusChanged, notesChanged), localTime(issue.Fields.Updated))
+		return entry
+	}
+
+	switch {
+	case localChanged && (!jiraChanged || s.Prefer == PreferLocal):
+		entry.Action, entry.Detail = ActionPushed, describeLocal(rec, el, statusChanged, notesChanged)
+		if s.DryRun {
+			return entry
+		}
+		// Record what went through, so a retry repeats only what failed.
+		localStatus, syncedNotes := el.Status, rec.Notes
+		var errs []string
+		transitioned := true
+		if statusChanged {
+			if err := s.transition(key, el.Status); err != nil {
+				errs = append(errs, err.Error())
+				transitioned = false
+			}
+		}
+		if notesChanged {
+			if text := newNotes(rec.Notes, el.Notes); text != "" {
+				if _, err := s.Client.AddComment(key, jira.NewADFParagraphs(paragraphs(text))); err != nil {
+					errs = append(errs, err.Error())
+				} else {
+					syncedNotes = el.Notes
+				}
+			} else {
+				syncedNotes = el.Notes
+			}
+		}
+		fresh, err := s.Client.GetIssue(key, s.Fields())
+		if err != nil {
+			return fail(err)
+		}
+		pull(el, fresh, ids)
+		syncedStatus := el.Status
+		if !transitioned {
+			// Keep the unpushed local status instead of Jira's.
+			el.Status, syncedStatus = localStatus, rec.Status
+		}
+		if len(errs) > 0 {
+			entry.Action, entry.Error = ActionFailed, strings.Join(errs, "; ")
+		}
+		if err := s.save(key, el, syncedStatus, syncedNotes, fresh.Fields.Updated); err != nil {
+			return fail(err)
+		}
+		return entry
+	case jiraChanged:
+		pull(el, issue, ids)
+		entry.Action, entry.Detail = ActionPulled, fmt.Sprintf("updated %s", localTime(issue.Fields.Updated))
+		if !s.DryRun {
+			if err := s.save(key, el, el.Status, el.Notes, issue.Fields.Updated); err != nil {
+				return fail(err)
+			}
+		}
+		return entry
+	}
+	entry.Action = ActionUnchanged
+	return entry
+}
+
+// save writes the element and its sync record.
+func (s *Syncer) save(key string, el *Element, status, notes, updated string) error {
+	if err := s.Board.SaveElement(el); err != nil {
+		return err
+	}
+	s.Board.SetRecord(key, &Record{Dir: el.Dir, Updated: updated, Status: status, Notes: notes})
+	return s.Board.SaveState()
+}
+
+// transition moves an issue to the status a local status names.
+func (s *Syncer) transition(key, status string) error {
+	transitions, err := s.Client.GetTransitions(key)
+	if err != nil {
+		return err
+	}
+	var available []string
+	for _, t := range transitions {
+		if StatusSlug(t.To.Name) == StatusSlug(status) {
+			return s.Client.DoTransition(key, t.ID, nil)
+		}
+		available = append(available, StatusSlug(t.To.Name))
+	}
+	return fmt.Errorf("no transition to %q from the current status (available: %s)", status, strings.Join(available, ", "))
+}
+
+// pull copies the issue's fields into the element. Notes, scope, acceptance
+// criteria and the checklist stay local.
+func pull(el *Element, issue *jira.Issue, ids map[string]string) {
+	f := &issue.Fields
+	el.Title = f.Summary
+	el.Description = strings.TrimSpace(f.DescriptionText())
+	if f.Status != nil {
+		el.Status = StatusSlug(f.Status.Name)
+	}
+	el.AssignedTo = ""
+	if f.Assignee != nil {
+		el.AssignedTo = cmp.Or(f.Assignee.DisplayName, f.Assignee.Name)
+	}
+	el.Created = localTime(f.Created)
+	el.LastUpdate = localTime(f.Updated)
+
+	el.BlockedBy, el.Blocks = nil, nil
+	for _, l := range f.IssueLinks {
+		if !strings.EqualFold(l.Type.Name, "Blocks") {
+			continue
+		}
+		if l.OutwardIssue != nil {
+			el.Blocks = append(el.Blocks, cmp.Or(ids[l.OutwardIssue.Key], l.OutwardIssue.Key))
+		}
+		if l.InwardIssue != nil {
+			el.BlockedBy = append(el.BlockedBy, cmp.Or(ids[l.InwardIssue.Key], l.InwardIssue.Key))
+		}
+	}
+}
+
+// StatusSlug turns a Jira status name into a board status: "In Progress"
+// becomes "in-progress". Local statuses are matched to Jira ones the same way.
+func StatusSlug(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
+
+func (s *Syncer) elementType(issue *jira.Issue) string {
+	switch s.level(issue) {
+	case 0:
+		return "EPIC"
+	case 2:
+		return "TASK"
+	}
+	return "STORY"
+}
+
+// level orders issues so parents are mirrored before their children.
+func (s *Syncer) level(issue *jira.Issue) int {
+	switch {
+	case issue.IsEpic(s.EpicNameField):
+		return 0
+	case issue.Fields.IssueType.Subtask:
+		return 2
+	}
+	return 1
+}
+
+func parentKey(issue *jira.Issue) string {
+	if issue.Fields.Parent != nil {
+		return issue.Fields.Parent.Key
+	}
+	return ""
+}
+
+// slug makes a directory name from a summary, like the board does.
+func slug(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+		if b.Len() >= 48 {
+			break
+		}
+	}
+	return cmp.Or(strings.Trim(b.String(), "-"), "issue")
+}
+
+// localTime converts a Jira timestamp to the board's RFC 3339 UTC form.
+func localTime(s string) string {
+	t, err := time.Parse(jiraTimeLayout, s)
+	if err != nil {
+		return s
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// newNotes returns the text added to the notes since the last sync, or all of
+// them when earlier notes were edited.
+func newNotes(old, cur string) string {
+	if strings.HasPrefix(cur, old) {
+		return strings.TrimSpace(cur[len(old):])
+	}
+	return strings.TrimSpace(cur)
+}
+
+func paragraphs(s string) []string {
+	var out []string
+	for _, para := range strings.Split(s, "\n\n") {
+		if para = strings.TrimSpace(para); para != "" {
+			out = append(out, para)
+		}
+	}
+	return out
+}
+
+func describeLocal(rec *Record, el *Element, statusChanged, notesChanged bool) string {
+	var parts []string
+	if statusChanged {
+		parts = append(parts, fmt.Sprintf("status %s -> %s", rec.Status, el.Status))
+	}
+	if notesChanged {
+		parts = append(parts, "new notes")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,254 @@
+package taskboard
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// fakeJira serves issues and records transitions and comments.
+type fakeJira struct {
+	mu          sync.Mutex
+	issues      map[string]string // key -> issue JSON
+	transitions []string          // "KEY id"
+	comments    []string          // "KEY text"
+}
+
+func (f *fakeJira) handler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		path := strings.TrimPrefix(r.URL.Path, "/rest/api/3/issue/")
+		body, _ := io.ReadAll(r.Body)
+
+		switch {
+		case strings.HasSuffix(path, "/transitions"):
+			key := strings.TrimSuffix(path, "/transitions")
+			if r.Method == http.MethodPost {
+				var req jira.DoTransitionRequest
+				json.Unmarshal(body, &req)
+				f.transitions = append(f.transitions, key+" "+req.Transition.ID)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.Write([]byte(`{"transitions":[{"id":"11","name":"Start","to":{"name":"In Progress"}},{"id":"31","name":"Finish","to":{"name":"Done"}}]}`))
+		case strings.HasSuffix(path, "/comment"):
+			var req struct {
+				Body jira.ADFDoc `json:"body"`
+			}
+			json.Unmarshal(body, &req)
+			f.comments = append(f.comments, strings.TrimSuffix(path, "/comment")+" "+strings.TrimSpace(req.Body.PlainText()))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"100"}`))
+		default:
+			issue, ok := f.issues[path]
+			if !ok {
+				t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(issue))
+		}
+	})
+}
+
+const (
+	epicJSON = `{"key":"PROJ-1","fields":{"summary":"Auth system","issuetype":{"name":"Epic"},"status":{"name":"In Progress"},` +
+		`"created":"2026-03-01T10:00:00.000+0000","updated":"2026-03-02T10:00:00.000+0000"}}`
+	storyJSON = `{"key":"PROJ-2","fields":{"summary":"Login flow","issuetype":{"name":"Story"},"status":{"name":"To Do"},` +
+		`"assignee":{"displayName":"Ann"},"parent":{"key":"PROJ-1"},` +
+		`"issuelinks":[{"type":{"name":"Blocks"},"outwardIssue":{"key":"PROJ-3"}}],` +
+		`"created":"2026-03-01T11:00:00.000+0000","updated":"2026-03-02T11:00:00.000+0000"}}`
+	subtaskJSON = `{"key":"PROJ-3","fields":{"summary":"Write tests","issuetype":{"name":"Sub-task","subtask":true},"status":{"name":"To Do"},` +
+		`"parent":{"key":"PROJ-2"},"issuelinks":[{"type":{"name":"Blocks"},"inwardIssue":{"key":"PROJ-2"}}],` +
+		`"created":"2026-03-01T12:00:00.000+0000","updated":"2026-03-02T12:00:00.000+0000"}}`
+)
+
+func decodeIssues(t *testing.T, docs ...string) []jira.Issue {
+	t.Helper()
+	var issues []jira.Issue
+	for _, doc := range docs {
+		var issue jira.Issue
+		if err := json.Unmarshal([]byte(doc), &issue); err != nil {
+			t.Fatalf("decode issue: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+func newSyncer(t *testing.T, f *fakeJira) *Syncer {
+	t.Helper()
+	srv := httptest.NewServer(f.handler(t))
+	t.Cleanup(srv.Close)
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: jira.InstanceCloud})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	b, err := OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Syncer{Client: client, Board: b}
+}
+
+func TestSync_MirrorsIssues(t *testing.T) {
+	s := newSyncer(t, &fakeJira{})
+	// Children first: the sync still nests them under their parents.
+	res := s.Sync(decodeIssues(t, subtaskJSON, storyJSON, epicJSON))
+	if res.Created != 3 || res.Failed != 0 {
+		t.Fatalf("result = %+v", res)
+	}
+
+	dir := filepath.Join("EPIC-PROJ-1_auth-system", "STORY-PROJ-2_login-flow")
+	story, err := s.Board.LoadElement(dir)
+	if err != nil {
+		t.Fatalf("LoadElement: %v", err)
+	}
+	if story.Title != "Login flow" || story.Status != "to-do" || story.AssignedTo != "Ann" ||
+		story.Created != "2026-03-01T11:00:00Z" || len(story.Blocks) != 1 || story.Blocks[0] != "TASK-PROJ-3" {
+		t.Errorf("story = %+v", story)
+	}
+	task, err := s.Board.LoadElement(filepath.Join(dir, "TASK-PROJ-3_write-tests"))
+	if err != nil {
+		t.Fatalf("LoadElement: %v", err)
+	}
+	if len(task.BlockedBy) != 1 || task.BlockedBy[0] != "STORY-PROJ-2" {
+		t.Errorf("task = %+v", task)
+	}
+
+	// A second run with nothing changed touches nothing.
+	b, err := OpenDir(s.Board.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Board = b
+	res = s.Sync(decodeIssues(t, epicJSON, storyJSON, subtaskJSON))
+	for _, e := range res.Items {
+		if e.Action != ActionUnchanged {
+			t.Errorf("second run: %+v", e)
+		}
+	}
+}
+
+func TestSync_LocalizedEpics(t *testing.T) {
+	s := newSyncer(t, &fakeJira{})
+	s.EpicNameField = "customfield_10011"
+	res := s.Sync(decodeIssues(t,
+		// Cloud: the hierarchy level marks the epic.
+		`{"key":"PROJ-1","fields":{"summary":"Auth","issuetype":{"name":"Эпик","hierarchyLevel":1},"status":{"name":"To Do"}}}`,
+		// Server/DC: only epics carry the Epic Name field.
+		`{"key":"PROJ-2","fields":{"summary":"Billing","issuetype":{"name":"Эпик"},"status":{"name":"To Do"},"customfield_10011":"Billing"}}`,
+		`{"key":"PROJ-3","fields":{"summary":"Login","issuetype":{"name":"История"},"status":{"name":"To Do"}}}`,
+	))
+	want := map[string]string{"PROJ-1": "EPIC-PROJ-1", "PROJ-2": "EPIC-PROJ-2", "PROJ-3": "STORY-PROJ-3"}
+	for _, e := range res.Items {
+		if e.ID != want[e.Key] {
+			t.Errorf("%s: id = %q, want %q", e.Key, e.ID, want[e.Key])
+		}
+	}
+}
+
+func TestSync_PushesStatusAndNotes(t *testing.T) {
+	pushed := strings.Replace(storyJSON, `"To Do"`, `"In Progress"`, 1)
+	pushed = strings.Replace(pushed, "2026-03-02T11:00:00.000", "2026-03-05T09:00:00.000", 1)
+	f := &fakeJira{issues: map[string]string{"PROJ-2": pushed}}
+	s := newSyncer(t, f)
+	s.Sync(decodeIssues(t, storyJSON))
+
+	dir := "STORY-PROJ-2_login-flow"
+	el, _ := s.Board.LoadElement(dir)
+	el.Status, el.Notes = "In Progress", "Started on the form.\n\nNeeds a design review."
+	if err := s.Board.SaveElement(el); err != nil {
+		t.Fatal(err)
+	}
+
+	res := s.Sync(decodeIssues(t, storyJSON))
+	if res.Pushed != 1 || res.Failed != 0 {
+		t.Fatalf("result = %+v", res)
+	}
+	if len(f.transitions) != 1 || f.transitions[0] != "PROJ-2 11" {
+		t.Errorf("transitions = %v", f.transitions)
+	}
+	if len(f.comments) != 1 || !strings.HasPrefix(f.comments[0], "PROJ-2 Started on the form.") {
+		t.Errorf("comments = %v", f.comments)
+	}
+	rec := s.Board.Record("PROJ-2")
+	if rec.Status != "in-progress" || rec.Updated != "2026-03-05T09:00:00.000+0000" {
+		t.Errorf("record = %+v", rec)
+	}
+
+	// Only notes added after the last sync become a new comment.
+	el, _ = s.Board.LoadElement(dir)
+	el.Notes += "\n\nReview done."
+	s.Board.SaveElement(el)
+	s.Sync(decodeIssues(t, pushed))
+	if len(f.comments) != 2 || f.comments[1] != "PROJ-2 Review done." {
+		t.Errorf("comments = %v", f.comments)
+	}
+}
+
+func TestSync_Conflict(t *testing.T) {
+	f := &fakeJira{}
+	s := newSyncer(t, f)
+	s.Sync(decodeIssues(t, storyJSON))
+
+	el, _ := s.Board.LoadElement("STORY-PROJ-2_login-flow")
+	el.Status = "done"
+	s.Board.SaveElement(el)
+	changed := strings.Replace(storyJSON, "2026-03-02T11:00:00.000", "2026-03-04T08:00:00.000", 1)
+	changed = strings.Replace(changed, `"Login flow"`, `"Login and logout"`, 1)
+
+	res := s.Sync(decodeIssues(t, changed))
+	if res.Conflicts != 1 || len(f.transitions) != 0 {
+		t.Fatalf("result = %+v, transitions = %v", res, f.transitions)
+	}
+	el, _ = s.Board.LoadElement("STORY-PROJ-2_login-flow")
+	if el.Status != "done" || el.Title != "Login flow" {
+		t.Errorf("conflicting element was changed: %+v", el)
+	}
+
+	s.Prefer = PreferJira
+	res = s.Sync(decodeIssues(t, changed))
+	el, _ = s.Board.LoadElement("STORY-PROJ-2_login-flow")
+	if res.Pulled != 1 || el.Status != "to-do" || el.Title != "Login and logout" {
+		t.Errorf("prefer jira: result = %+v, element = %+v", res, el)
+	}
+}
+
+func TestSync_DryRunWritesNothing(t *testing.T) {
+	s := newSyncer(t, &fakeJira{})
+	s.DryRun = true
+	res := s.Sync(decodeIssues(t, epicJSON, storyJSON))
+	if res.Created != 2 || res.Items[1].Detail != filepath.Join("EPIC-PROJ-1_auth-system", "STORY-PROJ-2_login-flow") {
+		t.Errorf("result = %+v", res)
+	}
+	if entries, _ := os.ReadDir(s.Board.Dir); len(entries) != 0 {
+		t.Errorf("dry run wrote %v", entries)
+	}
+}
+
+func TestSync_PreferJiraStillPushesLocalOnlyChanges(t *testing.T) {
+	f := &fakeJira{issues: map[string]string{"PROJ-2": storyJSON}}
+	s := newSyncer(t, f)
+	s.Sync(decodeIssues(t, storyJSON))
+
+	el, _ := s.Board.LoadElement("STORY-PROJ-2_login-flow")
+	el.Status, el.Notes = "in-progress", "Started."
+	s.Board.SaveElement(el)
+
+	// Jira did not change, so there is no conflict for Prefer to resolve.
+	s.Prefer = PreferJira
+	res := s.Sync(decodeIssues(t, storyJSON))
+	if res.Pushed != 1 || len(f.transitions) != 1 || len(f.comments) != 1 || f.comments[0] != "PROJ-2 Started." {
+		t.Errorf("result = %+v, transitions = %v, comments = %v", res, f.transitions, f.comments)
+	}
+}
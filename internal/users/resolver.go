@@ -0,0 +1,177 @@
+// Package users resolves what people type for a user (an email, a display
+// name, a Server/DC username or "me") to a Jira user, caching the answers per
+// instance so repeated assignments skip the user search.
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+// DefaultCacheTTL is how long a resolved user is reused without searching again.
+const DefaultCacheTTL = 30 * 24 * time.Hour
+
+// Resolver resolves user references against one Jira instance.
+type Resolver struct {
+	client    *jira.Client
+	cachePath string
+	ttl       time.Duration
+	cache     map[string]cachedUser
+	loaded    bool
+}
+
+type cachedUser struct {
+	User       jira.User `json:"user"`
+	ResolvedAt time.Time `json:"resolved_at"`
+}
+
+// NewResolver returns a Resolver caching in cachePath; an empty path disables the cache.
+func NewResolver(client *jira.Client, cachePath string) *Resolver {
+	return &Resolver{client: client, cachePath: cachePath, ttl: DefaultCacheTTL}
+}
+
+// IsMe reports whether s refers to the current user.
+func IsMe(s string) bool {
+	return strings.EqualFold(strings.TrimSpace(s), "me")
+}
+
+// Resolve finds the user s refers to. "me" is the authenticated user. Other
+// values are searched among the users assignable in projectKey (or issueKey);
+// with neither set, among all users. A value matching exactly one user's
+// email, display name, username or account ID wins; otherwise the search must
+// return a single user. Only exact matches are cached, per project or issue, so
+// a fuzzy hit or a user found outside the project is searched again next time.
+func (r *Resolver) Resolve(s, projectKey, issueKey string) (*jira.User, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("empty user")
+	}
+	if IsMe(s) {
+		me, err := r.client.GetMyself()
+		if err != nil {
+			return nil, fmt.Errorf("resolving me: %w", err)
+		}
+		return me, nil
+	}
+
+	key := cacheKey(s, projectKey, issueKey)
+	r.loadCache()
+	if c, ok := r.cache[key]; ok && time.Since(c.ResolvedAt) < r.ttl {
+		user := c.User
+		return &user, nil
+	}
+
+	var (
+		candidates []jira.User
+		err        error
+	)
+	if projectKey != "" || issueKey != "" {
+		candidates, err = r.client.FindAssignableUsers(projectKey, issueKey, s)
+	} else {
+		candidates, err = r.client.SearchUsers(s, 50)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("searching users for %q: %w", s, err)
+	}
+
+	user, exact, err := pick(s, candidates)
+	if err != nil {
+		return nil, err
+	}
+	if exact {
+		r.cache[key] = cachedUser{User: *user, ResolvedAt: time.Now().UTC()}
+		r.saveCache()
+	}
+	return user, nil
+}
+
+// cacheKey scopes a cached answer to where it was searched: the users
+// assignable in a project differ from those assignable on one of its issues
+// and from all users.
+func cacheKey(s, projectKey, issueKey string) string {
+	scope := "*"
+	switch {
+	case issueKey != "":
+		scope = "issue:" + strings.ToUpper(issueKey)
+	case projectKey != "":
+		scope = "project:" + strings.ToUpper(projectKey)
+	}
+	return scope + " " + strings.ToLower(s)
+}
+
+// pick chooses the user s refers to among search results; exact reports
+// whether s matched the user's email, name, username or account ID rather
+// than being the search's only result.
+func pick(s string, candidates []jira.User) (user *jira.User, exact bool, err error) {
+	var matches []jira.User
+	for _, u := range candidates {
+		if strings.EqualFold(u.EmailAddress, s) || strings.EqualFold(u.DisplayName, s) ||
+			strings.EqualFold(u.Name, s) || u.AccountID == s {
+			matches = append(matches, u)
+		}
+	}
+	switch {
+	case len(matches) == 1:
+		return &matches[0], true, nil
+	case len(matches) == 0 && len(candidates) == 1:
+		return &candidates[0], false, nil
+	case len(candidates) == 0:
+		return nil, false, fmt.Errorf("no user matches %q", s)
+	}
+
+	if len(matches) > 1 {
+		candidates = matches
+	}
+	names := make([]string, 0, len(candidates))
+	for _, u := range candidates {
+		names = append(names, describe(u))
+	}
+	return nil, false, fmt.Errorf("%q matches %d users: %s; use an email or username", s, len(candidates), strings.Join(names, ", "))
+}
+
+func describe(u jira.User) string {
+	switch {
+	case u.EmailAddress != "":
+		return fmt.Sprintf("%s <%s>", u.DisplayName, u.EmailAddress)
+	case u.Name != "":
+		return fmt.Sprintf("%s (%s)", u.DisplayName, u.Name)
+	}
+	return fmt.Sprintf("%s (%s)", u.DisplayName, u.AccountID)
+}
+
+func (r *Resolver) loadCache() {
+	if r.loaded {
+		return
+	}
+	r.loaded = true
+	r.cache = map[string]cachedUser{}
+	if r.cachePath == "" {
+		return
+	}
+	if data, err := os.ReadFile(r.cachePath); err == nil {
+		if json.Unmarshal(data, &r.cache) != nil || r.cache == nil {
+			r.cache = map[string]cachedUser{}
+		}
+	}
+}
+
+// saveCache writes the cache; failures only cost a search next time.
+func (r *Resolver) saveCache() {
+	if r.cachePath == "" {
+		return
+	}
+	data, err := json.Marshal(r.cache)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(r.cachePath), 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(r.cachePath, data, 0o600)
+}
@@ -0,0 +1,125 @@
+package users
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relux-works/skill-jira-management/internal/jira"
+)
+
+func newTestResolver(t *testing.T, handler http.HandlerFunc, cachePath string) *Resolver {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	client, err := jira.NewClient(jira.Config{BaseURL: srv.URL, Email: "user@test.com", Token: "t", InstanceType: jira.InstanceCloud})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return NewResolver(client, cachePath)
+}
+
+func TestResolve_AssignableAndCached(t *testing.T) {
+	searches := 0
+	cachePath := filepath.Join(t.TempDir(), "users.json")
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/user/assignable/search" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if q := r.URL.Query(); q.Get("query") != "alice@corp.com" || q.Get("project") != "PROJ" {
+			t.Errorf("query = %v", q)
+		}
+		searches++
+		w.Write([]byte(`[{"accountId":"a1","displayName":"Alice Smith","emailAddress":"alice@corp.com"},
+			{"accountId":"a2","displayName":"Alice Stone","emailAddress":"alice@corp.com.au"}]`))
+	}
+
+	user, err := newTestResolver(t, handler, cachePath).Resolve("alice@corp.com", "PROJ", "")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if ref := user.Ref(); ref.AccountID != "a1" || ref.Name != "" {
+		t.Errorf("ref = %+v", ref)
+	}
+
+	// A new resolver on the same cache file does not search again.
+	user, err = newTestResolver(t, handler, cachePath).Resolve("Alice@Corp.com", "PROJ", "")
+	if err != nil || user.AccountID != "a1" {
+		t.Fatalf("cached Resolve = %+v, %v", user, err)
+	}
+	if searches != 1 {
+		t.Errorf("searches = %d, want 1", searches)
+	}
+}
+
+func TestResolve_CachesOnlyExactMatchesPerScope(t *testing.T) {
+	searches := map[string]int{}
+	cachePath := filepath.Join(t.TempDir(), "users.json")
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		searches[r.URL.Path+" "+q.Get("project")+" "+q.Get("query")]++
+		w.Write([]byte(`[{"accountId":"b1","displayName":"Bob Jones","emailAddress":"bob@corp.com"}]`))
+	}
+
+	// "bob" is only a fuzzy hit: it resolves but is searched again next time.
+	for range 2 {
+		user, err := newTestResolver(t, handler, cachePath).Resolve("bob", "PROJ", "")
+		if err != nil || user.AccountID != "b1" {
+			t.Fatalf("Resolve(bob) = %+v, %v", user, err)
+		}
+	}
+	if n := searches["/rest/api/3/user/assignable/search PROJ bob"]; n != 2 {
+		t.Errorf("fuzzy searches = %d, want 2", n)
+	}
+
+	// An exact match is cached for its project only, and a user found among
+	// all users (watch) is not reused for an assignment.
+	for _, project := range []string{"", "PROJ", "PROJ", "OTHER"} {
+		if _, err := newTestResolver(t, handler, cachePath).Resolve("bob@corp.com", project, ""); err != nil {
+			t.Fatalf("Resolve(%q): %v", project, err)
+		}
+	}
+	for key, want := range map[string]int{
+		"/rest/api/3/user/search  bob@corp.com":                 1,
+		"/rest/api/3/user/assignable/search PROJ bob@corp.com":  1,
+		"/rest/api/3/user/assignable/search OTHER bob@corp.com": 1,
+	} {
+		if searches[key] != want {
+			t.Errorf("searches[%q] = %d, want %d", key, searches[key], want)
+		}
+	}
+}
+
+func TestResolve_Me(t *testing.T) {
+	r := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/myself" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"accountId":"me1","displayName":"Me"}`))
+	}, "")
+
+	user, err := r.Resolve("ME", "PROJ", "")
+	if err != nil || user.AccountID != "me1" {
+		t.Fatalf("Resolve(me) = %+v, %v", user, err)
+	}
+}
+
+func TestPick(t *testing.T) {
+	ann := jira.User{Name: "ann", DisplayName: "Ann Lee"}
+	anna := jira.User{Name: "anna", DisplayName: "Anna Lee"}
+
+	if u, exact, err := pick("ann", []jira.User{ann, anna}); err != nil || u.Name != "ann" || !exact {
+		t.Errorf("pick(ann) = %+v, %v, %v", u, exact, err)
+	}
+	if u, exact, err := pick("anna l", []jira.User{anna}); err != nil || u.Name != "anna" || exact {
+		t.Errorf("single candidate = %+v, %v, %v", u, exact, err)
+	}
+	if _, _, err := pick("lee", []jira.User{ann, anna}); err == nil || !strings.Contains(err.Error(), "matches 2 users") {
+		t.Errorf("ambiguous err = %v", err)
+	}
+	if _, _, err := pick("bob", nil); err == nil {
+		t.Error("expected an error for no match")
+	}
+}